@@ -0,0 +1,409 @@
+// Command truecaller-lite runs the TrueCaller-Lite HTTP API, and optionally its gRPC API, backed by in-memory
+// storage that can be loaded from and saved to a data directory.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/yourusername/truecaller-lite/pkg/auth"
+	"github.com/yourusername/truecaller-lite/pkg/dao/mem"
+	"github.com/yourusername/truecaller-lite/pkg/enumeration"
+	"github.com/yourusername/truecaller-lite/pkg/grpcapi"
+	"github.com/yourusername/truecaller-lite/pkg/grpcapi/truecallerv1"
+	"github.com/yourusername/truecaller-lite/pkg/handler"
+	"github.com/yourusername/truecaller-lite/pkg/moderation"
+	"github.com/yourusername/truecaller-lite/pkg/names"
+	"github.com/yourusername/truecaller-lite/pkg/otp"
+	"github.com/yourusername/truecaller-lite/pkg/ratelimit"
+	"github.com/yourusername/truecaller-lite/pkg/search"
+	"github.com/yourusername/truecaller-lite/pkg/service"
+	"github.com/yourusername/truecaller-lite/pkg/snapshot"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "HTTP listen address")
+	grpcAddr := flag.String("grpc-addr", "", "gRPC listen address (empty disables the gRPC API)")
+	grpcTimeout := flag.Duration("grpc-timeout", 30*time.Second, "maximum duration of a gRPC call (0 for no limit beyond the client's deadline)")
+	spamInterval := flag.Duration("spam-interval", 0, "interval between spam status update runs (0, the default, disables the job, which currently marks every number as spam)")
+	chunkSize := flag.Int("stream-chunk-size", service.DefaultStreamChunkSize, "contacts stored per chunk for NDJSON uploads")
+	maxPerRequest := flag.Int("max-contacts-per-request", 50000, "maximum contacts in one NDJSON upload (0 for no limit)")
+	maxPerOwner := flag.Int("max-contacts-per-owner", 100000, "maximum contacts in one phone book (0 for no limit)")
+	smsFile := flag.String("sms-file", "", "append verification SMS to this file instead of sending them (development only)")
+	logOTP := flag.Bool("insecure-log-otp", false, "write verification SMS, including their codes, to the server log (development only)")
+	sessionTTL := flag.Duration("session-ttl", auth.DefaultTokenTTL, "lifetime of session tokens issued after phone verification")
+	keyRotation := flag.Duration("key-rotation", 0, "interval between token signing key rotations (0 disables rotation)")
+	lookupLimit := flag.String("lookup-limit", "60/1m", "lookups allowed per client IP and per verified session number, as <n>/<interval> (0 disables)")
+	uploadLimit := flag.String("upload-limit", "10/1m", "uploads allowed per owner number and per client IP (0 disables)")
+	otpLimit := flag.String("otp-limit", "10/1m", "verification requests allowed per client IP (0 disables)")
+	privacyLimit := flag.String("privacy-limit", "10/1m", "export, erasure and unlisting requests allowed per verified owner number and per client IP (0 disables)")
+	erasureRetention := flag.Duration("erasure-retention", service.DefaultTombstoneRetention, "how long an erased number is kept from getting crowd-sourced names again")
+	lookupLogRetention := flag.Duration("lookup-log-retention", service.DefaultLookupLogRetention, "how long authenticated lookups are kept for \"who viewed me\"")
+	detectEnumeration := flag.Bool("detect-enumeration", true, "throttle and block clients that scan number ranges via lookups")
+	nameLexicon := flag.String("name-lexicon", "", "file of extra relationship words and placeholders to keep out of caller ID, one per line")
+	moderationWords := flag.String("moderation-words", "", "file of extra abusive words and phrases to keep out of caller ID, one per line")
+	dataDir := flag.String("data-dir", "", "load data from this directory at startup and save it there on shutdown, locking it meanwhile (empty keeps data in memory only)")
+	rateLimitKeys := flag.Int("rate-limit-max-keys", ratelimit.DefaultMaxKeys, "maximum keys tracked by each rate limiter")
+	flag.Parse()
+
+	// There is no SMS gateway yet, so both senders are for development; neither is chosen without being asked for,
+	// since anyone who can read their output can verify any number
+	var sender otp.SMSSender
+	switch {
+	case *smsFile != "":
+		sender = otp.NewFileSender(*smsFile)
+	case *logOTP:
+		sender = otp.NewLogSender(nil)
+	default:
+		log.Fatal("no SMS sender configured: pass -sms-file or -insecure-log-otp (development only)")
+	}
+
+	phoneBookDAO := mem.NewPhoneBookMemDAO()
+	searchIndex := search.NewIndex()
+	userDAO := search.NewIndexedUserDAO(mem.NewUserMemDAO(), phoneBookDAO, searchIndex)
+	spamReportDAO := mem.NewSpamReportMemDAO()
+	privacyDAO := mem.NewPrivacyMemDAO()
+	moderationDAO := mem.NewModerationMemDAO()
+	stores := snapshot.Stores{Users: userDAO, PhoneBooks: phoneBookDAO, SpamReports: spamReportDAO, Privacy: privacyDAO, Moderation: moderationDAO}
+	if *dataDir != "" {
+		// The lock keeps truecallerctl from changing the directory while the server holds its data in memory
+		lock, err := snapshot.Lock(*dataDir)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer lock.Unlock()
+		if err := loadData(*dataDir, stores); err != nil {
+			log.Fatal(err)
+		}
+	}
+	lexicon, err := loadNameLexicon(*nameLexicon)
+	if err != nil {
+		log.Fatal(err)
+	}
+	wordList, err := loadModerationWords(*moderationWords)
+	if err != nil {
+		log.Fatal(err)
+	}
+	nameOptions := []service.UserServiceOption{
+		service.WithNameLexicon(lexicon),
+		service.WithNameFilter(wordList),
+		service.WithModerationDAO(moderationDAO),
+	}
+	userService := service.NewUserService(userDAO, phoneBookDAO, append(nameOptions, service.WithPrivacyDAO(privacyDAO))...)
+	spamService := service.NewSpamService(userDAO, spamReportDAO)
+	sessions, err := newTokenIssuer(*sessionTTL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	lookupLogDAO := mem.NewLookupLogMemDAO()
+	privacyService := service.NewPrivacyService(userDAO, phoneBookDAO, spamReportDAO, privacyDAO,
+		service.WithLookupLogDAO(lookupLogDAO),
+		service.WithNameResolution(nameOptions...),
+		service.WithTokenRevoker(sessions),
+		service.WithTombstoneRetention(*erasureRetention),
+	)
+	lookupLogService := service.NewLookupLogService(lookupLogDAO, userDAO, privacyDAO, service.LookupLogOptions{Retention: *lookupLogRetention})
+	otpService := otp.NewService(mem.NewOTPMemDAO(), sender, sessions, otp.Options{})
+
+	opts := []handler.Option{
+		handler.WithStreamUploadOptions(service.StreamUploadOptions{
+			ChunkSize:             *chunkSize,
+			MaxContactsPerRequest: *maxPerRequest,
+			MaxContactsPerOwner:   *maxPerOwner,
+		}),
+		handler.WithPrivacyService(privacyService),
+		handler.WithOTPService(otpService),
+		handler.WithOwnerVerifier(handler.NewTokenOwnerVerifier(sessions)),
+		handler.WithLookupLog(lookupLogService, sessions),
+		handler.WithSearchService(service.NewSearchService(searchIndex, privacyDAO), sessions),
+	}
+	// The HTTP and gRPC APIs share one detector and one set of limiters, so a client has one budget across both
+	grpcInterceptors := []grpc.UnaryServerInterceptor{grpcapi.TimeoutInterceptor(*grpcTimeout)}
+	if *detectEnumeration {
+		detector := enumeration.NewDetector(mem.NewOffenderMemDAO(), enumeration.Options{})
+		opts = append(opts, handler.WithEnumerationDetector(detector, handler.KeyByTokenOrIP(sessions)))
+		grpcInterceptors = append(grpcInterceptors, grpcapi.EnumerationInterceptor(detector, grpcapi.KeyByTokenOrPeer(sessions)))
+	}
+	rateLimits, grpcRateLimits, err := rateLimitOptions(routeKeys(sessions), *rateLimitKeys, map[string]string{
+		handler.RouteLookup:  *lookupLimit,
+		handler.RouteUpload:  *uploadLimit,
+		handler.RouteOTP:     *otpLimit,
+		handler.RoutePrivacy: *privacyLimit,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	h := handler.NewHandler(userService, append(opts, rateLimits...)...)
+	grpcInterceptors = append(grpcInterceptors, grpcapi.RateLimitInterceptor(grpcRateLimits))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if *spamInterval > 0 {
+		go runSpamJob(ctx, spamService, *spamInterval)
+	}
+	go prune(ctx, "lookup log", lookupLogService.PruneLookupLog, time.Hour)
+	go prune(ctx, "erasure tombstone", privacyService.PruneTombstones, time.Hour)
+	if *keyRotation > 0 {
+		go rotateKeys(ctx, sessions, *keyRotation)
+	}
+
+	// stopping tracks the servers' graceful shutdowns, so data is saved only once in-flight calls are done.
+	var stopping sync.WaitGroup
+	if *grpcAddr != "" {
+		grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(grpcInterceptors...))
+		grpcapi.Register(grpcServer, userService, spamService,
+			grpcapi.WithOwnerVerifier(grpcapi.NewTokenOwnerVerifier(sessions)),
+			grpcapi.WithTokenVerifier(sessions),
+			grpcapi.WithLookupLog(lookupLogService),
+		)
+		lis, err := net.Listen("tcp", *grpcAddr)
+		if err != nil {
+			log.Fatal(err)
+		}
+		stopping.Add(1)
+		go func() {
+			defer stopping.Done()
+			<-ctx.Done()
+			grpcServer.GracefulStop()
+		}()
+		go func() {
+			log.Printf("gRPC listening on %s", *grpcAddr)
+			if err := grpcServer.Serve(lis); err != nil {
+				log.Fatal(err)
+			}
+		}()
+	}
+
+	srv := &http.Server{Addr: *addr, Handler: h, ReadHeaderTimeout: 10 * time.Second}
+	stopping.Add(1)
+	go func() {
+		defer stopping.Done()
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+	log.Printf("listening on %s", *addr)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+	stopping.Wait()
+	if *dataDir != "" {
+		if err := saveData(*dataDir, stores); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// loadData restores the snapshot in dir, if any, into stores.
+func loadData(dir string, stores snapshot.Stores) error {
+	snap, err := snapshot.Load(dir)
+	if err != nil {
+		return fmt.Errorf("loading %s: %w", dir, err)
+	}
+	if err := snapshot.Restore(context.Background(), stores, snap); err != nil {
+		return fmt.Errorf("loading %s: %w", dir, err)
+	}
+	log.Printf("loaded %d users and %d phone books from %s", len(snap.Users), len(snap.PhoneBooks), dir)
+	return nil
+}
+
+// saveData writes the contents of stores to dir.
+func saveData(dir string, stores snapshot.Stores) error {
+	snap, err := snapshot.Take(context.Background(), stores)
+	if err != nil {
+		return fmt.Errorf("saving %s: %w", dir, err)
+	}
+	if err := snapshot.Save(dir, snap); err != nil {
+		return fmt.Errorf("saving %s: %w", dir, err)
+	}
+	log.Printf("saved %d users and %d phone books to %s", len(snap.Users), len(snap.PhoneBooks), dir)
+	return nil
+}
+
+// loadNameLexicon returns the default name lexicon extended with the terms in path, if set.
+func loadNameLexicon(path string) (*names.Lexicon, error) {
+	terms, err := readTerms(path)
+	if err != nil {
+		return nil, fmt.Errorf("name lexicon: %w", err)
+	}
+	return names.DefaultLexicon().With(terms...), nil
+}
+
+// loadModerationWords returns the default moderation word list extended with the words in path, if set.
+func loadModerationWords(path string) (*moderation.WordList, error) {
+	words, err := readTerms(path)
+	if err != nil {
+		return nil, fmt.Errorf("moderation words: %w", err)
+	}
+	return moderation.DefaultWordList().With(words...), nil
+}
+
+// readTerms reads one term per line from path, or returns none if path is empty.
+func readTerms(path string) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return names.ReadTerms(f)
+}
+
+// routeKey is a rate limit key in its HTTP and gRPC forms. A nil grpc key does not limit gRPC calls.
+type routeKey struct {
+	http handler.RateLimitKey
+	grpc grpcapi.CallKey
+}
+
+// routeKeys lists the keys each route is rate limited by; tokens verifies the session tokens lookups and owner
+// calls are keyed by. Owner keys only apply once the caller has proven ownership, so calls that do not are
+// limited by client IP alone.
+func routeKeys(tokens otp.TokenVerifier) map[string][]routeKey {
+	byOwner := routeKey{http: handler.KeyByOwner(handler.NewTokenOwnerVerifier(tokens)), grpc: grpcapi.KeyByOwner(grpcapi.NewTokenOwnerVerifier(tokens))}
+	return map[string][]routeKey{
+		handler.RouteLookup: {
+			{http: handler.KeyByIP, grpc: grpcapi.KeyByPeer},
+			{http: handler.KeyByToken(tokens), grpc: grpcapi.KeyByToken(tokens)},
+		},
+		handler.RouteUpload: {
+			byOwner,
+			{http: handler.KeyByIP, grpc: grpcapi.KeyByPeer},
+		},
+		handler.RouteOTP:     {{http: handler.KeyByIP}},
+		handler.RoutePrivacy: {{http: byOwner.http}, {http: handler.KeyByIP}},
+	}
+}
+
+// grpcRoutes lists the gRPC methods of each route, by full method name.
+var grpcRoutes = map[string][]string{
+	handler.RouteLookup: {truecallerv1.UserService_LookupUser_FullMethodName, truecallerv1.UserService_BatchLookupUsers_FullMethodName},
+	handler.RouteUpload: {truecallerv1.UserService_UploadContacts_FullMethodName},
+}
+
+// rateLimitOptions parses the per-route limits and returns handler options and gRPC limits applying each under
+// every key in keys, with a separate limiter per route and key shared by both APIs.
+func rateLimitOptions(keys map[string][]routeKey, maxKeys int, limits map[string]string) ([]handler.Option, map[string][]grpcapi.RateLimit, error) {
+	var opts []handler.Option
+	grpcLimits := make(map[string][]grpcapi.RateLimit)
+	for route, spec := range limits {
+		limit, err := ratelimit.ParseLimit(spec)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s limit: %w", route, err)
+		}
+		if limit.Rate <= 0 {
+			continue
+		}
+		for _, key := range keys[route] {
+			limiter := ratelimit.NewLimiter(limit, ratelimit.Options{MaxKeys: maxKeys})
+			opts = append(opts, handler.WithRateLimit(route, key.http, limiter))
+			if key.grpc == nil {
+				continue
+			}
+			for _, method := range grpcRoutes[route] {
+				grpcLimits[method] = append(grpcLimits[method], grpcapi.RateLimit{Key: key.grpc, Limiter: limiter})
+			}
+		}
+	}
+	return opts, grpcLimits, nil
+}
+
+// prune calls pruneFn every interval until ctx is done, logging failures as pruning what.
+func prune(ctx context.Context, what string, pruneFn func(context.Context) (int, error), interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := pruneFn(ctx); err != nil {
+				log.Printf("%s pruning failed: %v", what, err)
+			}
+		}
+	}
+}
+
+// authKeysEnv names the environment variable holding token signing keys as "id:base64secret,...",
+// signing key first.
+const authKeysEnv = "TRUECALLER_AUTH_KEYS"
+
+// newTokenIssuer creates the session token issuer from the keys in authKeysEnv, or from a random key if unset.
+func newTokenIssuer(ttl time.Duration) (*auth.Issuer, error) {
+	keys, err := auth.ParseKeys(os.Getenv(authKeysEnv))
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		log.Printf("%s not set; using a random signing key, so tokens will not survive a restart", authKeysEnv)
+		key, err := auth.GenerateKey()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return auth.NewIssuer(keys, auth.Options{TokenTTL: ttl})
+}
+
+// rotateKeys signs new tokens with a fresh random key every interval until ctx is done. Each replaced key is
+// retired once every token it signed has expired; keys configured at startup are never retired.
+func rotateKeys(ctx context.Context, issuer *auth.Issuer, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var previous string
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			key, err := auth.GenerateKey()
+			if err != nil {
+				log.Printf("signing key rotation failed: %v", err)
+				continue
+			}
+			if err := issuer.RotateKey(key); err != nil {
+				log.Printf("signing key rotation failed: %v", err)
+				continue
+			}
+			if previous != "" {
+				retired := previous
+				time.AfterFunc(issuer.TokenTTL(), func() { issuer.RetireKey(retired) })
+			}
+			previous = key.ID
+		}
+	}
+}
+
+// runSpamJob runs the spam status update every interval until ctx is done.
+func runSpamJob(ctx context.Context, spamService service.SpamService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			changes, err := spamService.UpdateSpamStatus(ctx)
+			if err != nil {
+				log.Printf("spam status update failed after %d changes: %v", len(changes), err)
+				continue
+			}
+			log.Printf("spam status update changed %d numbers", len(changes))
+		}
+	}
+}
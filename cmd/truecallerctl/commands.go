@@ -0,0 +1,316 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao/daoerrors"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+	"github.com/yourusername/truecaller-lite/pkg/phonebookcsv"
+	"github.com/yourusername/truecaller-lite/pkg/service"
+	"github.com/yourusername/truecaller-lite/pkg/snapshot"
+	"github.com/yourusername/truecaller-lite/pkg/vcard"
+)
+
+// newFlagSet returns a flag set for a command whose usage line is usage.
+func newFlagSet(e *env, name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(e.stderr, "usage: truecallerctl %s %s\n", name, usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parse parses args into fs and checks the number of positional arguments.
+func parse(fs *flag.FlagSet, args []string, minArgs, maxArgs int) error {
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	if fs.NArg() < minArgs || (maxArgs >= 0 && fs.NArg() > maxArgs) {
+		fs.Usage()
+		return errUsage
+	}
+	return nil
+}
+
+// open opens path for reading, or returns stdin for "-".
+func (e *env) open(path string) (io.ReadCloser, error) {
+	if path == "-" {
+		return io.NopCloser(e.stdin), nil
+	}
+	return os.Open(path)
+}
+
+// writeJSON writes v to stdout as one line of JSON.
+func (e *env) writeJSON(v any) error {
+	return json.NewEncoder(e.stdout).Encode(v)
+}
+
+// runUpload uploads a phone book file for an owner.
+func runUpload(ctx context.Context, e *env, args []string) error {
+	fs := newFlagSet(e, "upload", "-owner NUMBER [-format json|csv|vcf] [-partial] FILE")
+	owner := fs.String("owner", "", "phone number of the phone book's owner")
+	format := fs.String("format", "", "file format: json, csv or vcf (default from the file extension)")
+	partial := fs.Bool("partial", false, "store the valid contacts and report the rejected ones instead of rejecting the upload")
+	if err := parse(fs, args, 1, 1); err != nil {
+		return err
+	}
+	if *owner == "" {
+		fs.Usage()
+		return errUsage
+	}
+	path := fs.Arg(0)
+	if *format == "" {
+		*format = formatFromPath(path)
+	}
+	f, err := e.open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	contacts, err := readContacts(e, f, *format, *owner)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	if *partial {
+		report, err := e.users.UploadContactsPartial(ctx, *owner, contacts)
+		if err != nil {
+			return err
+		}
+		if err := e.writeJSON(report); err != nil {
+			return err
+		}
+	} else {
+		if err := e.users.UploadContacts(ctx, *owner, contacts); err != nil {
+			return err
+		}
+		fmt.Fprintf(e.stderr, "uploaded %d contacts for %s\n", len(contacts), *owner)
+	}
+	if e.local != nil {
+		return e.local.save(ctx)
+	}
+	return nil
+}
+
+// formatFromPath guesses a phone book format from a file extension.
+func formatFromPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return "csv"
+	case ".vcf", ".vcard":
+		return "vcf"
+	default:
+		return "json"
+	}
+}
+
+// readContacts reads a phone book in the given format. JSON is either the upload API's request body or a bare
+// array of contacts. CSV rows for other owners are ignored and invalid rows are reported on stderr and skipped.
+func readContacts(e *env, r io.Reader, format, owner string) ([]models.Contact, error) {
+	switch format {
+	case "json":
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		var contacts []models.Contact
+		if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+			err = json.Unmarshal(data, &contacts)
+		} else {
+			var body struct {
+				Contacts []models.Contact `json:"contacts"`
+			}
+			err = json.Unmarshal(data, &body)
+			contacts = body.Contacts
+		}
+		return contacts, err
+	case "csv":
+		result, err := phonebookcsv.Import(r, phonebookcsv.ImportOptions{Owner: owner})
+		if err != nil {
+			return nil, err
+		}
+		for _, rowErr := range result.Errors {
+			reasons := make([]string, 0, len(rowErr.Reasons))
+			for _, reason := range rowErr.Reasons {
+				reasons = append(reasons, reason.Message)
+			}
+			fmt.Fprintf(e.stderr, "line %d skipped: %s\n", rowErr.Line, strings.Join(reasons, "; "))
+		}
+		var contacts []models.Contact
+		for _, pb := range result.PhoneBooks {
+			contacts = append(contacts, pb.Contacts...)
+		}
+		return contacts, nil
+	case "vcf":
+		return vcard.Parse(r)
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
+
+// lookupError is the lookup output line for a number that could not be looked up. Unlisted numbers keep
+// their spam status.
+type lookupError struct {
+	PhoneNumber string `json:"phone_number"`
+	Error       string `json:"error"`
+	IsSpam      bool   `json:"is_spam,omitempty"`
+}
+
+// runLookup looks up numbers and writes one JSON line per number, in order. Unknown, unlisted and invalid numbers
+// get an error line; any other error stops the command.
+func runLookup(ctx context.Context, e *env, args []string) error {
+	fs := newFlagSet(e, "lookup", "[-alternatives N] NUMBER... (\"-\" reads numbers from stdin, one per line)")
+	alternatives := fs.Int("alternatives", 0, "number of alternative names to include")
+	if err := parse(fs, args, 1, -1); err != nil {
+		return err
+	}
+	numbers := fs.Args()
+	if len(numbers) == 1 && numbers[0] == "-" {
+		var err error
+		if numbers, err = readLines(e.stdin); err != nil {
+			return err
+		}
+	}
+	failed := 0
+	for _, number := range numbers {
+		result, err := e.users.LookupUserDetailed(ctx, number, *alternatives)
+		var ve *models.ValidationError
+		if err != nil && !errors.Is(err, daoerrors.ErrUserNotFound) && !errors.Is(err, service.ErrUnlisted) && !errors.As(err, &ve) {
+			return err
+		}
+		var line any = result
+		if err != nil {
+			line = lookupError{PhoneNumber: number, Error: err.Error(), IsSpam: result.GetIsSpam()}
+			failed++
+		}
+		if err := e.writeJSON(line); err != nil {
+			return err
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d lookups failed", failed, len(numbers))
+	}
+	return nil
+}
+
+// readLines returns the non-blank lines of r, trimmed.
+func readLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// runSpamJob runs the spam status update and writes the changes it applied as JSON lines. The job currently marks
+// every number as spam, so applying it takes -confirm; with -dry-run it only writes the changes it would make.
+// Changes applied before a failure are still saved and reported.
+func runSpamJob(ctx context.Context, e *env, args []string) error {
+	fs := newFlagSet(e, "spam-job", "(-dry-run | -confirm)")
+	dryRun := fs.Bool("dry-run", false, "report the changes without applying them")
+	confirm := fs.Bool("confirm", false, "apply the changes (the job currently marks every number as spam)")
+	if err := parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+	if *dryRun {
+		changes, err := e.local.spam.PlanSpamStatusUpdate(ctx)
+		if err != nil {
+			return err
+		}
+		if err := e.writeChanges(changes); err != nil {
+			return err
+		}
+		fmt.Fprintf(e.stderr, "dry run: would change %d numbers\n", len(changes))
+		return nil
+	}
+	if !*confirm {
+		return errors.New("spam-job currently marks every number as spam: pass -confirm to apply it or -dry-run to preview it")
+	}
+	applied, err := e.local.spam.UpdateSpamStatus(ctx)
+	if len(applied) > 0 {
+		if err := e.local.save(ctx); err != nil {
+			return err
+		}
+	}
+	if err := e.writeChanges(applied); err != nil {
+		return err
+	}
+	fmt.Fprintf(e.stderr, "changed %d numbers\n", len(applied))
+	return err
+}
+
+// writeChanges writes spam status changes as JSON lines.
+func (e *env) writeChanges(changes []*models.SpamStatusChange) error {
+	for _, change := range changes {
+		if err := e.writeJSON(change); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runExport writes a snapshot of the data directory to a file or stdout.
+func runExport(ctx context.Context, e *env, args []string) error {
+	fs := newFlagSet(e, "export", "[-o FILE]")
+	out := fs.String("o", "-", "output file (\"-\" for stdout)")
+	if err := parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+	snap, err := snapshot.Take(ctx, e.local.stores)
+	if err != nil {
+		return err
+	}
+	if *out == "-" {
+		return snap.Write(e.stdout)
+	}
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	if err := snap.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// runImport replaces the data directory's contents with a snapshot. The snapshot is validated in full before
+// anything is written, and the directory's current data is never read, so import also recovers a corrupt one.
+func runImport(ctx context.Context, e *env, args []string) error {
+	fs := newFlagSet(e, "import", "FILE (\"-\" reads stdin)")
+	if err := parse(fs, args, 1, 1); err != nil {
+		return err
+	}
+	f, err := e.open(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	snap, err := snapshot.Read(f)
+	if err != nil {
+		return err
+	}
+	if err := snapshot.Restore(ctx, e.local.stores, snap); err != nil {
+		return fmt.Errorf("invalid snapshot: %w", err)
+	}
+	if err := e.local.save(ctx); err != nil {
+		return err
+	}
+	fmt.Fprintf(e.stderr, "imported %d users and %d phone books\n", len(snap.Users), len(snap.PhoneBooks))
+	return nil
+}
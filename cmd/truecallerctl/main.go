@@ -0,0 +1,198 @@
+// Command truecallerctl is the operations and development tool for TrueCaller-Lite. It uploads phone books,
+// looks up numbers, runs the spam job and exports or imports snapshots, either against a running server
+// (-server) or directly against a data directory (-data-dir) such as the one the server uses with -data-dir.
+// Both lock the data directory, so commands on it fail while a server uses it.
+//
+// Usage:
+//
+//	truecallerctl (-server URL [-token TOKEN] | -data-dir DIR) <command> [flags] [args]
+//
+// Commands:
+//
+//	upload -owner NUMBER [-format json|csv|vcf] [-partial] FILE  upload a phone book ("-" reads stdin)
+//	lookup [-alternatives N] NUMBER...                            look up numbers ("-" reads them from stdin)
+//	spam-job (-dry-run | -confirm)                                run the spam status update (data directory only)
+//	export [-o FILE]                                              write a snapshot (data directory only)
+//	import FILE                                                   replace the data with a snapshot (data directory only)
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/yourusername/truecaller-lite/pkg/client"
+	"github.com/yourusername/truecaller-lite/pkg/dao/mem"
+	"github.com/yourusername/truecaller-lite/pkg/moderation"
+	"github.com/yourusername/truecaller-lite/pkg/names"
+	"github.com/yourusername/truecaller-lite/pkg/service"
+	"github.com/yourusername/truecaller-lite/pkg/snapshot"
+)
+
+// tokenEnv names the environment variable holding the default -token.
+const tokenEnv = "TRUECALLER_TOKEN"
+
+// errUsage reports a command line error; the usage has already been printed.
+var errUsage = errors.New("usage error")
+
+// command is a truecallerctl subcommand.
+type command struct {
+	// localOnly marks commands that need a data directory, since the server API does not expose them.
+	localOnly bool
+	// replacesData marks commands that start from an empty data directory instead of loading it.
+	replacesData bool
+	run          func(ctx context.Context, env *env, args []string) error
+}
+
+var commands = map[string]command{
+	"upload":   {run: runUpload},
+	"lookup":   {run: runLookup},
+	"spam-job": {localOnly: true, run: runSpamJob},
+	"export":   {localOnly: true, run: runExport},
+	"import":   {localOnly: true, replacesData: true, run: runImport},
+}
+
+// env is what commands run against: a server or a data directory, and the standard streams.
+type env struct {
+	// users is the server's API or the data directory's service.
+	users service.UserService
+	// local is the data directory, or nil when talking to a server.
+	local  *dataDir
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	err := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+	switch {
+	case errors.Is(err, errUsage):
+		os.Exit(2)
+	case err != nil:
+		fmt.Fprintf(os.Stderr, "truecallerctl: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run parses the global flags and runs the command named in args.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("truecallerctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	server := fs.String("server", "", "base URL of a running server, e.g. http://localhost:8080")
+	token := fs.String("token", os.Getenv(tokenEnv), "session token for owner-only calls to -server (default $"+tokenEnv+")")
+	dir := fs.String("data-dir", "", "data directory to operate on directly")
+	timeout := fs.Duration("timeout", time.Minute, "maximum duration of the command (0 for no limit)")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: truecallerctl (-server URL [-token TOKEN] | -data-dir DIR) <command> [flags] [args]")
+		fmt.Fprintln(stderr, "commands: upload, lookup, spam-job, export, import")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	if fs.NArg() == 0 || (*server == "") == (*dir == "") {
+		fs.Usage()
+		return errUsage
+	}
+	cmd, ok := commands[fs.Arg(0)]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n", fs.Arg(0))
+		fs.Usage()
+		return errUsage
+	}
+	if cmd.localOnly && *dir == "" {
+		return fmt.Errorf("%s needs -data-dir: the server API does not expose it", fs.Arg(0))
+	}
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+
+	e := &env{stdin: stdin, stdout: stdout, stderr: stderr}
+	if *server != "" {
+		c, err := client.New(*server, client.WithToken(*token))
+		if err != nil {
+			return err
+		}
+		e.users = c
+	} else {
+		// A server started with the same -data-dir holds the lock until it exits, so its shutdown save cannot
+		// overwrite this command's changes
+		lock, err := snapshot.Lock(*dir)
+		if errors.Is(err, snapshot.ErrLocked) {
+			return fmt.Errorf("%w; stop the server or use -server", err)
+		}
+		if err != nil {
+			return err
+		}
+		defer lock.Unlock()
+		local := newDataDir(*dir)
+		if !cmd.replacesData {
+			if local, err = openDataDir(ctx, *dir); err != nil {
+				return err
+			}
+		}
+		e.users, e.local = local.users, local
+	}
+	return cmd.run(ctx, e, fs.Args()[1:])
+}
+
+// dataDir is a data directory loaded into in-memory storage. Commands that change data call save.
+type dataDir struct {
+	path   string
+	stores snapshot.Stores
+	users  service.UserService
+	spam   service.SpamService
+}
+
+// openDataDir loads the snapshot in path, if any, and wires the services the server would use on top of it.
+func openDataDir(ctx context.Context, path string) (*dataDir, error) {
+	d := newDataDir(path)
+	snap, err := snapshot.Load(path)
+	if err != nil {
+		return nil, fmt.Errorf("loading %s: %w", path, err)
+	}
+	if err := snapshot.Restore(ctx, d.stores, snap); err != nil {
+		return nil, fmt.Errorf("loading %s: %w", path, err)
+	}
+	return d, nil
+}
+
+// newDataDir returns an empty data directory at path.
+func newDataDir(path string) *dataDir {
+	d := &dataDir{path: path, stores: snapshot.Stores{
+		Users:       mem.NewUserMemDAO(),
+		PhoneBooks:  mem.NewPhoneBookMemDAO(),
+		SpamReports: mem.NewSpamReportMemDAO(),
+		Privacy:     mem.NewPrivacyMemDAO(),
+		Moderation:  mem.NewModerationMemDAO(),
+	}}
+	d.users = service.NewUserService(d.stores.Users, d.stores.PhoneBooks,
+		service.WithNameLexicon(names.DefaultLexicon()),
+		service.WithNameFilter(moderation.DefaultWordList()),
+		service.WithModerationDAO(d.stores.Moderation),
+		service.WithPrivacyDAO(d.stores.Privacy),
+	)
+	d.spam = service.NewSpamService(d.stores.Users, d.stores.SpamReports)
+	return d
+}
+
+// save writes the data back to the directory.
+func (d *dataDir) save(ctx context.Context) error {
+	snap, err := snapshot.Take(ctx, d.stores)
+	if err != nil {
+		return err
+	}
+	if err := snapshot.Save(d.path, snap); err != nil {
+		return fmt.Errorf("saving %s: %w", d.path, err)
+	}
+	return nil
+}
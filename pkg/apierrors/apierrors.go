@@ -0,0 +1,11 @@
+// Package apierrors holds the authentication errors shared by the servers and the Go client, so the client can
+// match them without importing the server packages.
+package apierrors
+
+import "errors"
+
+// ErrInvalidToken is returned when a session token is unknown, malformed or expired.
+var ErrInvalidToken = errors.New("invalid or expired session token")
+
+// ErrOwnerNotVerified is returned when a request or call does not prove ownership of the phone number it acts for.
+var ErrOwnerNotVerified = errors.New("request is not from the verified owner of this phone number")
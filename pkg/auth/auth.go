@@ -0,0 +1,272 @@
+// Package auth issues and verifies signed session tokens bound to a verified phone number.
+//
+// Tokens have the form "<key id>.<payload>.<signature>", where payload is base64url-encoded JSON claims and
+// signature is the base64url-encoded HMAC-SHA256 of "<key id>.<payload>". Tokens are stateless: any instance
+// holding the signing key can verify them, and rotating keys invalidates nothing until the old key is retired.
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yourusername/truecaller-lite/pkg/apierrors"
+	"github.com/yourusername/truecaller-lite/pkg/otp"
+)
+
+// DefaultTokenTTL is how long issued tokens stay valid when Options.TokenTTL is zero.
+const DefaultTokenTTL = 24 * time.Hour
+
+// MinKeySize is the minimum length of a signing key secret in bytes.
+const MinKeySize = 32
+
+var (
+	// ErrInvalidToken is returned for malformed tokens, unknown keys, bad signatures and expired tokens.
+	// It is apierrors.ErrInvalidToken, so clients can match it without importing this package.
+	ErrInvalidToken = apierrors.ErrInvalidToken
+	// ErrNoSigningKey is returned when a token is issued by an Issuer without an active key.
+	ErrNoSigningKey = errors.New("no active signing key")
+	// ErrInvalidKey is returned when a signing key has no ID, an ID containing '.', or a short secret.
+	ErrInvalidKey = errors.New("invalid signing key")
+)
+
+// Key is an HMAC signing key identified by ID. The ID is embedded in every token signed with it.
+type Key struct {
+	// ID identifies the key in tokens; it must not contain '.'.
+	ID string
+	// Secret is the HMAC secret, at least MinKeySize bytes.
+	Secret []byte
+}
+
+// validate checks the key can be used for signing.
+func (k Key) validate() error {
+	if k.ID == "" || strings.Contains(k.ID, ".") {
+		return fmt.Errorf("%w: key ID must be non-empty and must not contain '.'", ErrInvalidKey)
+	}
+	if len(k.Secret) < MinKeySize {
+		return fmt.Errorf("%w: secret of key %q must be at least %d bytes", ErrInvalidKey, k.ID, MinKeySize)
+	}
+	return nil
+}
+
+// GenerateKey returns a new random key with a random ID.
+func GenerateKey() (Key, error) {
+	id := make([]byte, 8)
+	secret := make([]byte, MinKeySize)
+	if _, err := rand.Read(id); err != nil {
+		return Key{}, err
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return Key{}, err
+	}
+	return Key{ID: hex.EncodeToString(id), Secret: secret}, nil
+}
+
+// Claims are the contents of a session token.
+type Claims struct {
+	// Subject is the verified phone number.
+	Subject string `json:"sub"`
+	// IssuedAt is when the token was issued, in Unix seconds.
+	IssuedAt int64 `json:"iat"`
+	// ExpiresAt is when the token expires, in Unix seconds.
+	ExpiresAt int64 `json:"exp"`
+}
+
+// Options configures an Issuer. Zero fields use the package defaults.
+type Options struct {
+	// TokenTTL is the lifetime of issued tokens.
+	TokenTTL time.Duration
+	// Now returns the current time; time.Now if nil. Intended for tests.
+	Now func() time.Time
+}
+
+// Issuer signs and verifies session tokens with a set of rotating keys.
+// The most recently rotated-in key signs new tokens; every key still held verifies tokens.
+// It implements otp.TokenIssuer, otp.TokenVerifier and otp.TokenRevoker and is safe for concurrent use.
+type Issuer struct {
+	mu      sync.RWMutex
+	active  string
+	keys    map[string][]byte
+	revoked map[string]time.Time // key: phone number, value: tokens issued up to this time are revoked
+	ttl     time.Duration
+	now     func() time.Time
+}
+
+// NewIssuer creates an Issuer signing with the first key and verifying with all keys.
+func NewIssuer(keys []Key, opts Options) (*Issuer, error) {
+	if opts.TokenTTL <= 0 {
+		opts.TokenTTL = DefaultTokenTTL
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	i := &Issuer{keys: make(map[string][]byte), revoked: make(map[string]time.Time), ttl: opts.TokenTTL, now: opts.Now}
+	for n := len(keys) - 1; n >= 0; n-- {
+		if err := i.RotateKey(keys[n]); err != nil {
+			return nil, err
+		}
+	}
+	return i, nil
+}
+
+// RotateKey makes key the signing key. Previously added keys keep verifying tokens until retired.
+func (i *Issuer) RotateKey(key Key) error {
+	if err := key.validate(); err != nil {
+		return err
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.keys[key.ID] = append([]byte(nil), key.Secret...)
+	i.active = key.ID
+	return nil
+}
+
+// RetireKey removes a key, invalidating every token it signed. Retiring the signing key leaves the
+// Issuer unable to issue tokens until another key is rotated in.
+func (i *Issuer) RetireKey(id string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	delete(i.keys, id)
+	if i.active == id {
+		i.active = ""
+	}
+}
+
+// TokenTTL returns the lifetime of issued tokens.
+func (i *Issuer) TokenTTL() time.Duration {
+	return i.ttl
+}
+
+// IssueToken returns a token whose subject is phoneNumber, signed with the current key.
+func (i *Issuer) IssueToken(ctx context.Context, phoneNumber string) (string, time.Time, error) {
+	if ctx.Err() != nil {
+		return "", time.Time{}, ctx.Err()
+	}
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	if i.active == "" {
+		return "", time.Time{}, ErrNoSigningKey
+	}
+	now := i.now()
+	expiresAt := now.Add(i.ttl).Truncate(time.Second)
+	payload, err := json.Marshal(Claims{Subject: phoneNumber, IssuedAt: now.Unix(), ExpiresAt: expiresAt.Unix()})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	signed := i.active + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return signed + "." + sign(i.keys[i.active], signed), expiresAt, nil
+}
+
+// RevokeTokens invalidates every token issued for phoneNumber up to now, including tokens issued earlier in the
+// current second. Tokens are stateless, so the revocation is kept in memory until those tokens have expired: it
+// does not survive a restart and only applies to tokens verified by this Issuer.
+func (i *Issuer) RevokeTokens(ctx context.Context, phoneNumber string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	now := i.now()
+	for number, at := range i.revoked {
+		if now.Sub(at) > i.ttl {
+			delete(i.revoked, number)
+		}
+	}
+	i.revoked[phoneNumber] = now
+	return nil
+}
+
+// VerifyToken checks token's signature and expiry and returns its subject.
+func (i *Issuer) VerifyToken(ctx context.Context, token string) (string, error) {
+	claims, err := i.ParseToken(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	return claims.Subject, nil
+}
+
+// ParseToken checks token's signature and expiry and returns its claims.
+func (i *Issuer) ParseToken(ctx context.Context, token string) (*Claims, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidToken)
+	}
+	i.mu.RLock()
+	secret, ok := i.keys[parts[0]]
+	i.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown signing key", ErrInvalidToken)
+	}
+	if !hmac.Equal([]byte(sign(secret, parts[0]+"."+parts[1])), []byte(parts[2])) {
+		return nil, fmt.Errorf("%w: bad signature", ErrInvalidToken)
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed payload", ErrInvalidToken)
+	}
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Subject == "" {
+		return nil, fmt.Errorf("%w: malformed claims", ErrInvalidToken)
+	}
+	if i.now().Unix() >= claims.ExpiresAt {
+		return nil, fmt.Errorf("%w: token expired", ErrInvalidToken)
+	}
+	i.mu.RLock()
+	revokedAt, revoked := i.revoked[claims.Subject]
+	i.mu.RUnlock()
+	if revoked && claims.IssuedAt <= revokedAt.Unix() {
+		return nil, fmt.Errorf("%w: token revoked", ErrInvalidToken)
+	}
+	return &claims, nil
+}
+
+// sign returns the base64url-encoded HMAC-SHA256 of data.
+func sign(secret []byte, data string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(data))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// ParseKeys parses a comma-separated list of "id:base64secret" keys, e.g. from an environment variable.
+// The first key is the signing key when passed to NewIssuer.
+func ParseKeys(s string) ([]Key, error) {
+	var keys []Key
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, encoded, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("%w: expected id:base64secret", ErrInvalidKey)
+		}
+		secret, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("%w: key %q: %v", ErrInvalidKey, id, err)
+		}
+		key := Key{ID: id, Secret: secret}
+		if err := key.validate(); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+var (
+	_ otp.TokenIssuer   = (*Issuer)(nil)
+	_ otp.TokenVerifier = (*Issuer)(nil)
+	_ otp.TokenRevoker  = (*Issuer)(nil)
+)
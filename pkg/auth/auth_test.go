@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testKey(id string) Key {
+	return Key{ID: id, Secret: bytes.Repeat([]byte(id[:1]), MinKeySize)}
+}
+
+func TestIssuer_IssueAndVerify(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	issuer, err := NewIssuer([]Key{testKey("k1")}, Options{TokenTTL: time.Hour, Now: func() time.Time { return now }})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	token, expiresAt, err := issuer.IssueToken(ctx, "919876543210")
+	if err != nil || !expiresAt.Equal(now.Add(time.Hour)) {
+		t.Fatalf("unexpected result %v (%v)", expiresAt, err)
+	}
+	if !strings.HasPrefix(token, "k1.") {
+		t.Errorf("expected token to carry its key ID, got %q", token)
+	}
+	if subject, err := issuer.VerifyToken(ctx, token); err != nil || subject != "919876543210" {
+		t.Errorf("expected subject 919876543210, got %q (%v)", subject, err)
+	}
+
+	parts := strings.Split(token, ".")
+	forged := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"919123456789","iat":0,"exp":9999999999}`))
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "empty", token: ""},
+		{name: "malformed", token: "a.b"},
+		{name: "unknown key", token: "k9." + parts[1] + "." + parts[2]},
+		{name: "tampered payload", token: parts[0] + "." + forged + "." + parts[2]},
+		{name: "tampered signature", token: parts[0] + "." + parts[1] + ".AAAA"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := issuer.VerifyToken(ctx, tc.token); !errors.Is(err, ErrInvalidToken) {
+				t.Errorf("expected ErrInvalidToken, got %v", err)
+			}
+		})
+	}
+
+	now = now.Add(time.Hour)
+	if _, err := issuer.VerifyToken(ctx, token); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expected expired token to be rejected, got %v", err)
+	}
+}
+
+func TestIssuer_RevokeTokens(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	issuer, err := NewIssuer([]Key{testKey("k1")}, Options{TokenTTL: time.Hour, Now: func() time.Time { return now }})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	revoked, _, _ := issuer.IssueToken(ctx, "919876543210")
+	other, _, _ := issuer.IssueToken(ctx, "919123456789")
+	now = now.Add(time.Minute)
+	if err := issuer.RevokeTokens(ctx, "919876543210"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := issuer.VerifyToken(ctx, revoked); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expected revoked token to be rejected, got %v", err)
+	}
+	if _, err := issuer.VerifyToken(ctx, other); err != nil {
+		t.Errorf("expected other numbers' tokens to stay valid, got %v", err)
+	}
+	now = now.Add(time.Second)
+	fresh, _, _ := issuer.IssueToken(ctx, "919876543210")
+	if _, err := issuer.VerifyToken(ctx, fresh); err != nil {
+		t.Errorf("expected a token issued after the revocation to be valid, got %v", err)
+	}
+}
+
+func TestIssuer_KeyRotation(t *testing.T) {
+	ctx := context.Background()
+	issuer, err := NewIssuer([]Key{testKey("k1")}, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	oldToken, _, _ := issuer.IssueToken(ctx, "919876543210")
+	if err := issuer.RotateKey(testKey("k2")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	newToken, _, _ := issuer.IssueToken(ctx, "919876543210")
+	if !strings.HasPrefix(newToken, "k2.") {
+		t.Errorf("expected new tokens to be signed with k2, got %q", newToken)
+	}
+	if _, err := issuer.VerifyToken(ctx, oldToken); err != nil {
+		t.Errorf("expected token signed with the previous key to verify, got %v", err)
+	}
+	issuer.RetireKey("k1")
+	if _, err := issuer.VerifyToken(ctx, oldToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expected token signed with a retired key to be rejected, got %v", err)
+	}
+	if _, err := issuer.VerifyToken(ctx, newToken); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	issuer.RetireKey("k2")
+	if _, _, err := issuer.IssueToken(ctx, "919876543210"); !errors.Is(err, ErrNoSigningKey) {
+		t.Errorf("expected ErrNoSigningKey, got %v", err)
+	}
+}
+
+func TestNewIssuer_InvalidKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		key  Key
+	}{
+		{name: "empty ID", key: Key{Secret: make([]byte, MinKeySize)}},
+		{name: "ID with dot", key: Key{ID: "a.b", Secret: make([]byte, MinKeySize)}},
+		{name: "short secret", key: Key{ID: "k1", Secret: []byte("short")}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewIssuer([]Key{tc.key}, Options{}); !errors.Is(err, ErrInvalidKey) {
+				t.Errorf("expected ErrInvalidKey, got %v", err)
+			}
+		})
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	secret := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte("s"), MinKeySize))
+	keys, err := ParseKeys("k2:" + secret + ", k1:" + secret)
+	if err != nil || len(keys) != 2 || keys[0].ID != "k2" || keys[1].ID != "k1" {
+		t.Fatalf("unexpected result %+v (%v)", keys, err)
+	}
+	for _, bad := range []string{"k1", "k1:not-base64!", "k1:c2hvcnQ="} {
+		if _, err := ParseKeys(bad); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("ParseKeys(%q): expected ErrInvalidKey, got %v", bad, err)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+// Package client is a typed Go client for the TrueCaller-Lite HTTP API. *Client implements service.UserService,
+// so code written against the service layer can call a remote server instead of a local service.
+//
+// Calls honour their context's deadline and cancellation. Idempotent calls (lookups, display name updates and
+// phone book replacement) are retried on network errors, 429 Too Many Requests and 502/503/504 responses.
+// Error responses are returned as *Error, which unwraps to the matching service-layer error, so
+// errors.Is(err, daoerrors.ErrUserNotFound) and errors.As(err, &*models.ValidationError) work as they do locally.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Defaults for New.
+const (
+	DefaultMaxAttempts = 3
+	DefaultBackoff     = 200 * time.Millisecond
+	// maxBackoff caps the wait between attempts, including waits asked for by Retry-After.
+	maxBackoff = 10 * time.Second
+)
+
+// TokenSource returns the bearer token to send for owner-only calls on phoneNumber.
+type TokenSource func(ctx context.Context, phoneNumber string) (string, error)
+
+// Client calls a TrueCaller-Lite server over HTTP. It is safe for concurrent use.
+type Client struct {
+	baseURL     *url.URL
+	httpClient  *http.Client
+	tokens      TokenSource
+	maxAttempts int
+	backoff     time.Duration
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for requests. Defaults to http.DefaultClient.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+// WithToken sends token on owner-only calls, such as uploads, for any phone number.
+func WithToken(token string) Option {
+	return WithTokenSource(func(ctx context.Context, phoneNumber string) (string, error) { return token, nil })
+}
+
+// WithTokenSource sends the token returned by tokens on owner-only calls. Use it when one client acts for
+// several owners.
+func WithTokenSource(tokens TokenSource) Option {
+	return func(c *Client) { c.tokens = tokens }
+}
+
+// WithRetries sets how many times an idempotent call is attempted (1 disables retries) and the wait before the
+// first retry, doubled for each further retry. A Retry-After header from the server overrides the wait.
+func WithRetries(maxAttempts int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxAttempts = maxAttempts
+		c.backoff = backoff
+	}
+}
+
+// New creates a Client for the server at baseURL, e.g. "http://localhost:8080".
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid base URL %q: scheme must be http or https", baseURL)
+	}
+	c := &Client{baseURL: u, httpClient: http.DefaultClient, maxAttempts: DefaultMaxAttempts, backoff: DefaultBackoff}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.maxAttempts < 1 {
+		c.maxAttempts = 1
+	}
+	return c, nil
+}
+
+// call describes one API call.
+type call struct {
+	method      string
+	path        string
+	query       url.Values
+	owner       string // if set, the call is owner-only and carries the owner's bearer token
+	contentType string
+	body        []byte    // request body, resent on retries
+	stream      io.Reader // request body read once; calls with a stream are never retried
+	idempotent  bool
+}
+
+// do performs c and decodes a successful JSON response into out, if out is non-nil.
+// Error responses are returned as *Error.
+func (c *Client) do(ctx context.Context, cl call, out any) error {
+	attempts := 1
+	if cl.idempotent && cl.stream == nil {
+		attempts = c.maxAttempts
+	}
+	var token string
+	if cl.owner != "" && c.tokens != nil {
+		var err error
+		if token, err = c.tokens(ctx, cl.owner); err != nil {
+			return err
+		}
+	}
+	wait := c.backoff
+	for attempt := 1; ; attempt++ {
+		resp, err := c.send(ctx, cl, token)
+		if err == nil && !retryable(resp.StatusCode) || attempt == attempts {
+			if err != nil {
+				return err
+			}
+			return decodeResponse(resp, out)
+		}
+		if err == nil {
+			if d, ok := retryAfter(resp); ok {
+				wait = d
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		timer := time.NewTimer(min(wait, maxBackoff))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		wait *= 2
+	}
+}
+
+// send makes one attempt at cl, with token as its bearer token if set.
+func (c *Client) send(ctx context.Context, cl call, token string) (*http.Response, error) {
+	u := *c.baseURL
+	u.Path += cl.path
+	u.RawQuery = cl.query.Encode()
+	body := cl.stream
+	if body == nil && cl.body != nil {
+		body = bytes.NewReader(cl.body)
+	}
+	req, err := http.NewRequestWithContext(ctx, cl.method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if cl.contentType != "" {
+		req.Header.Set("Content-Type", cl.contentType)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil && ctx.Err() != nil {
+		// Report the caller's cancellation or deadline rather than the transport's wrapping of it
+		return nil, ctx.Err()
+	}
+	return resp, err
+}
+
+// retryable reports whether a response with status may succeed if the call is repeated.
+func retryable(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryAfter returns the wait asked for by the response's Retry-After header, in seconds.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+// decodeResponse decodes a 2xx JSON body into out, or returns the error response as *Error.
+func decodeResponse(resp *http.Response, out any) error {
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return newError(resp)
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding %s response: %w", resp.Request.URL.Path, err)
+	}
+	return nil
+}
+
+// jsonBody encodes v as a JSON request body.
+func jsonBody(v any) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("encoding request: %w", err)
+	}
+	return b, nil
+}
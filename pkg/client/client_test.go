@@ -0,0 +1,188 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/yourusername/truecaller-lite/pkg/apierrors"
+	"github.com/yourusername/truecaller-lite/pkg/auth"
+	"github.com/yourusername/truecaller-lite/pkg/dao/daoerrors"
+	"github.com/yourusername/truecaller-lite/pkg/dao/mem"
+	"github.com/yourusername/truecaller-lite/pkg/handler"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+	"github.com/yourusername/truecaller-lite/pkg/service"
+)
+
+const (
+	owner = "919876543210"
+	bob   = "919123456789"
+)
+
+// newServer starts a server backed by in-memory storage and returns a client for it that holds tokens for
+// every number, and the user and privacy DAOs used by the server.
+func newServer(t *testing.T) (*Client, *mem.UserMemDAO, *mem.PrivacyMemDAO, *auth.Issuer) {
+	t.Helper()
+	key, _ := auth.GenerateKey()
+	issuer, err := auth.NewIssuer([]auth.Key{key}, auth.Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	userDAO, privacyDAO := mem.NewUserMemDAO(), mem.NewPrivacyMemDAO()
+	userService := service.NewUserService(userDAO, mem.NewPhoneBookMemDAO(), service.WithPrivacyDAO(privacyDAO))
+	srv := httptest.NewServer(handler.NewHandler(userService, handler.WithOwnerVerifier(handler.NewTokenOwnerVerifier(issuer))))
+	t.Cleanup(srv.Close)
+	c, err := New(srv.URL, WithTokenSource(func(ctx context.Context, phoneNumber string) (string, error) {
+		token, _, err := issuer.IssueToken(ctx, phoneNumber)
+		return token, err
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return c, userDAO, privacyDAO, issuer
+}
+
+func TestClient_UserService(t *testing.T) {
+	ctx := context.Background()
+	c, userDAO, privacyDAO, _ := newServer(t)
+
+	if err := c.UploadContacts(ctx, owner, []models.Contact{{PhoneNumber: bob, Name: "Dr. Bob Rao - Apollo"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	name, isSpam, err := c.LookupUser(ctx, bob)
+	if err != nil || name != "Dr. Bob Rao - Apollo" || isSpam {
+		t.Errorf("expected Dr. Bob Rao - Apollo, got %q %v (%v)", name, isSpam, err)
+	}
+	result, err := c.LookupUserDetailed(ctx, bob, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.GetPerson().FullName() != "Bob Rao" || result.GetPerson().GetOrganization() != "Apollo" || len(result.GetAlternatives()) != 1 {
+		t.Errorf("unexpected detailed result %+v", result)
+	}
+
+	report, err := c.UploadContactsPartial(ctx, owner, []models.Contact{{PhoneNumber: bob, Name: "Bob"}, {PhoneNumber: "123", Name: "X"}})
+	if err != nil || report.Accepted != 1 || len(report.Rejected) != 1 {
+		t.Errorf("expected one accepted and one rejected contact, got %+v (%v)", report, err)
+	}
+
+	var progress []models.UploadProgress
+	report, err = c.UploadContactsStream(ctx, owner, strings.NewReader(`{"phone_number":"919000000001","name":"Carol"}`+"\n"), service.StreamUploadOptions{
+		OnProgress: func(p models.UploadProgress) { progress = append(progress, p) },
+	})
+	if err != nil || report.Accepted != 1 || len(progress) != 1 || progress[0].Accepted != 1 {
+		t.Errorf("expected a streamed contact with progress, got %+v %+v (%v)", report, progress, err)
+	}
+
+	if err := c.SetDisplayName(ctx, bob, "Robert"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name, _, _ := c.LookupUser(ctx, bob); name != "Robert" {
+		t.Errorf("expected the display name, got %q", name)
+	}
+
+	_ = privacyDAO.SetUnlisted(ctx, bob, true)
+	if _, _, err := c.LookupUser(ctx, bob); !errors.Is(err, service.ErrUnlisted) {
+		t.Errorf("expected ErrUnlisted, got %v", err)
+	}
+	_ = userDAO.UpdateSpamStatus(ctx, bob, true)
+	if name, spam, err := c.LookupUser(ctx, bob); !errors.Is(err, service.ErrUnlisted) || name != "" || !spam {
+		t.Errorf("expected ErrUnlisted with the spam status, got %q %v (%v)", name, spam, err)
+	}
+}
+
+func TestClient_Errors(t *testing.T) {
+	ctx := context.Background()
+	c, _, _, issuer := newServer(t)
+	bobsToken, _, _ := issuer.IssueToken(ctx, bob)
+	withBobsToken, _ := New(c.baseURL.String(), WithToken(bobsToken))
+	noToken, _ := New(c.baseURL.String())
+
+	tests := []struct {
+		name    string
+		call    func() error
+		wantErr error
+		status  int
+	}{
+		{name: "unknown number", call: func() error { _, _, err := c.LookupUser(ctx, "919000000000"); return err }, wantErr: daoerrors.ErrUserNotFound, status: http.StatusNotFound},
+		{name: "another owner's token", call: func() error { return withBobsToken.UploadContacts(ctx, owner, nil) }, wantErr: apierrors.ErrOwnerNotVerified, status: http.StatusForbidden},
+		{name: "no token", call: func() error { return noToken.SetDisplayName(ctx, owner, "Alice") }, wantErr: apierrors.ErrOwnerNotVerified, status: http.StatusForbidden},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.call()
+			var apiErr *Error
+			if !errors.Is(err, tc.wantErr) || !errors.As(err, &apiErr) || apiErr.StatusCode != tc.status {
+				t.Errorf("expected %v with status %d, got %v", tc.wantErr, tc.status, err)
+			}
+		})
+	}
+
+	err := c.UploadContacts(ctx, owner, []models.Contact{{PhoneNumber: bob, Name: "Bob"}, {PhoneNumber: "123", Name: "X"}})
+	var ve *models.ValidationError
+	if !errors.As(err, &ve) || ve.Field != "contacts.phone_number" || ve.Index != 1 {
+		t.Errorf("expected a validation error for contact 1, got %v", err)
+	}
+}
+
+func TestClient_Retries(t *testing.T) {
+	tests := []struct {
+		name         string
+		call         func(c *Client) error
+		failures     int32
+		wantAttempts int32
+		wantErr      bool
+	}{
+		{name: "lookup retried", call: func(c *Client) error { _, _, err := c.LookupUser(context.Background(), bob); return err }, failures: 2, wantAttempts: 3},
+		{name: "lookup gives up", call: func(c *Client) error { _, _, err := c.LookupUser(context.Background(), bob); return err }, failures: 5, wantAttempts: 3, wantErr: true},
+		{name: "stream not retried", call: func(c *Client) error {
+			_, err := c.UploadContactsStream(context.Background(), owner, strings.NewReader("{}"), service.StreamUploadOptions{})
+			return err
+		}, failures: 1, wantAttempts: 1, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if attempts.Add(1) <= tc.failures {
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				_, _ = w.Write([]byte(`{"phone_number":"919123456789","name":"Bob","is_spam":false,"total":0,"accepted":0,"rejected":[]}`))
+			}))
+			defer srv.Close()
+			c, _ := New(srv.URL, WithRetries(3, time.Millisecond))
+			err := tc.call(c)
+			if (err != nil) != tc.wantErr || attempts.Load() != tc.wantAttempts {
+				t.Errorf("expected %d attempts (error %v), got %d (%v)", tc.wantAttempts, tc.wantErr, attempts.Load(), err)
+			}
+		})
+	}
+}
+
+func TestClient_Deadline(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { <-release }))
+	defer srv.Close()
+	defer close(release)
+	c, _ := New(srv.URL)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, _, err := c.LookupUser(ctx, bob); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestNew(t *testing.T) {
+	for _, baseURL := range []string{"localhost:8080", "ftp://example.com", "://"} {
+		if _, err := New(baseURL); err == nil {
+			t.Errorf("expected New(%q) to fail", baseURL)
+		}
+	}
+}
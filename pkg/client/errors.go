@@ -0,0 +1,98 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/yourusername/truecaller-lite/pkg/apierrors"
+	"github.com/yourusername/truecaller-lite/pkg/dao/daoerrors"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+	"github.com/yourusername/truecaller-lite/pkg/service"
+)
+
+// Error is an error response from the server.
+// It unwraps to the service-layer error the server reported, if known: e.g. daoerrors.ErrUserNotFound for
+// code "not_found", or the models.ValidationErrors listed in Details for code "validation_failed".
+type Error struct {
+	// StatusCode is the HTTP status of the response.
+	StatusCode int
+	// Code is the machine-readable error code, e.g. "not_found".
+	Code string
+	// Message is the human-readable error message.
+	Message string
+	// Details lists individual field violations, if any.
+	Details []models.ErrorDetail
+	// RetryAfter is how long the server asked the client to wait before retrying, if it did.
+	RetryAfter time.Duration
+	err        error
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return fmt.Sprintf("truecaller-lite: %d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// Unwrap returns the matching service-layer error, or nil if there is none.
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// knownErrors lists, per error code, the service-layer errors the server reports with it. The first error whose
+// message appears in the response message is matched; otherwise the first error of the code is.
+var knownErrors = map[string][]error{
+	"not_found":      {daoerrors.ErrUserNotFound, daoerrors.ErrPhoneBookNotFound},
+	"limit_exceeded": {service.ErrRequestLimitExceeded, service.ErrOwnerLimitExceeded},
+	"forbidden":      {apierrors.ErrOwnerNotVerified},
+	"unauthorized":   {apierrors.ErrInvalidToken},
+}
+
+// newError builds an *Error from an error response. Bodies that are not error JSON keep the status text.
+func newError(resp *http.Response) *Error {
+	e := &Error{StatusCode: resp.StatusCode, Code: strings.ToLower(strings.ReplaceAll(http.StatusText(resp.StatusCode), " ", "_"))}
+	if d, ok := retryAfter(resp); ok {
+		e.RetryAfter = d
+	}
+	var body models.ErrorResponse
+	if raw, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20)); err == nil && json.Unmarshal(raw, &body) == nil && body.Code != "" {
+		e.Code, e.Message, e.Details = body.Code, body.Message, body.Details
+	}
+	if e.Message == "" {
+		e.Message = http.StatusText(resp.StatusCode)
+	}
+	if e.Code == models.ErrCodeValidationFailed {
+		e.err = validationErrors(e.Details)
+		return e
+	}
+	candidates := knownErrors[e.Code]
+	if len(candidates) > 0 {
+		e.err = candidates[0]
+	}
+	for _, known := range candidates {
+		if strings.Contains(e.Message, known.Error()) {
+			e.err = known
+			break
+		}
+	}
+	return e
+}
+
+// validationErrors rebuilds the validation errors listed in an error response.
+func validationErrors(details []models.ErrorDetail) error {
+	errs := make(models.ValidationErrors, 0, len(details))
+	for _, d := range details {
+		index := models.NoIndex
+		if d.Index != nil {
+			index = *d.Index
+		}
+		errs = append(errs, &models.ValidationError{Field: d.Field, Index: index, Code: d.Code, Message: d.Message})
+	}
+	if len(errs) == 0 {
+		return errors.New("request validation failed")
+	}
+	return errs
+}
@@ -0,0 +1,125 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/yourusername/truecaller-lite/pkg/models"
+	"github.com/yourusername/truecaller-lite/pkg/service"
+)
+
+// lookupResponse is the JSON body of a lookup: the caller ID, or a privacy response for unlisted numbers.
+type lookupResponse struct {
+	models.LookupResult
+	Unlisted bool `json:"unlisted"`
+}
+
+// UploadContacts replaces the owner's phone book with contacts (POST /v1/users/{owner}/contacts).
+// Owner only: the client's token source must provide a token for ownerPhoneNumber.
+func (c *Client) UploadContacts(ctx context.Context, ownerPhoneNumber string, contacts []models.Contact) error {
+	return c.uploadContacts(ctx, ownerPhoneNumber, contacts, nil, nil)
+}
+
+// UploadContactsPartial replaces the owner's phone book with the valid contacts and reports the rejected ones
+// (POST /v1/users/{owner}/contacts?mode=partial). Owner only.
+func (c *Client) UploadContactsPartial(ctx context.Context, ownerPhoneNumber string, contacts []models.Contact) (*models.UploadReport, error) {
+	var report models.UploadReport
+	if err := c.uploadContacts(ctx, ownerPhoneNumber, contacts, url.Values{"mode": {"partial"}}, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// uploadContacts replaces the owner's phone book, decoding the upload report into out if it is non-nil.
+// Replacing a phone book is idempotent, so the upload is retried.
+func (c *Client) uploadContacts(ctx context.Context, owner string, contacts []models.Contact, query url.Values, out any) error {
+	if contacts == nil {
+		contacts = []models.Contact{}
+	}
+	body, err := jsonBody(struct {
+		Contacts []models.Contact `json:"contacts"`
+	}{contacts})
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, call{
+		method: http.MethodPost, path: "/v1/users/" + url.PathEscape(owner) + "/contacts", query: query, owner: owner,
+		contentType: "application/json", body: body, idempotent: true,
+	}, out)
+}
+
+// UploadContactsStream sends newline-delimited JSON contacts read from r, merged into the owner's phone book
+// (POST /v1/users/{owner}/contacts as application/x-ndjson). Owner only. The upload is never retried, since r
+// can only be read once. Chunk size and contact limits are the server's: opts.ChunkSize, MaxContactsPerRequest
+// and MaxContactsPerOwner are ignored. opts.OnProgress is called once, when the server has stored the upload.
+func (c *Client) UploadContactsStream(ctx context.Context, ownerPhoneNumber string, r io.Reader, opts service.StreamUploadOptions) (*models.UploadReport, error) {
+	var report models.UploadReport
+	err := c.do(ctx, call{
+		method: http.MethodPost, path: "/v1/users/" + url.PathEscape(ownerPhoneNumber) + "/contacts", owner: ownerPhoneNumber,
+		contentType: "application/x-ndjson", stream: r,
+	}, &report)
+	if err != nil {
+		return nil, err
+	}
+	if opts.OnProgress != nil {
+		opts.OnProgress(models.UploadProgress{Processed: report.Total, Accepted: report.Accepted, Rejected: len(report.Rejected)})
+	}
+	return &report, nil
+}
+
+// LookupUser returns the name and spam status of phoneNumber (GET /v1/users/{phone}).
+// Returns service.ErrUnlisted, with the spam status, if the owner opted out of caller ID and
+// daoerrors.ErrUserNotFound if the number is not known.
+func (c *Client) LookupUser(ctx context.Context, phoneNumber string) (string, bool, error) {
+	result, err := c.LookupUserDetailed(ctx, phoneNumber, 0)
+	if errors.Is(err, service.ErrUnlisted) {
+		return "", result.GetIsSpam(), err
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return result.Name, result.IsSpam, nil
+}
+
+// LookupUserDetailed looks up phoneNumber like LookupUser and also returns the name split into its parts and up
+// to alternatives candidate names (GET /v1/users/{phone}?alternatives=N). For unlisted numbers it returns
+// service.ErrUnlisted with a result holding only the phone number and spam status.
+func (c *Client) LookupUserDetailed(ctx context.Context, phoneNumber string, alternatives int) (*models.LookupResult, error) {
+	var query url.Values
+	if alternatives > 0 {
+		query = url.Values{"alternatives": {strconv.Itoa(alternatives)}}
+	}
+	var resp lookupResponse
+	if err := c.do(ctx, call{method: http.MethodGet, path: "/v1/users/" + url.PathEscape(phoneNumber), query: query, idempotent: true}, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Unlisted {
+		return &models.LookupResult{PhoneNumber: resp.PhoneNumber, IsSpam: resp.IsSpam, Alternatives: []models.NameCandidate{}}, service.ErrUnlisted
+	}
+	if resp.Alternatives == nil {
+		resp.Alternatives = []models.NameCandidate{}
+	}
+	return &resp.LookupResult, nil
+}
+
+// SetDisplayName sets the name shown for the owner's number; an empty displayName clears it
+// (PUT /v1/users/{phone}/display-name). Owner only.
+func (c *Client) SetDisplayName(ctx context.Context, phoneNumber, displayName string) error {
+	body, err := jsonBody(struct {
+		DisplayName string `json:"display_name"`
+	}{displayName})
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, call{
+		method: http.MethodPut, path: "/v1/users/" + url.PathEscape(phoneNumber) + "/display-name", owner: phoneNumber,
+		contentType: "application/json", body: body, idempotent: true,
+	}, nil)
+}
+
+// Ensure Client implements service.UserService
+var _ service.UserService = (*Client)(nil)
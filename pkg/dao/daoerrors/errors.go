@@ -7,3 +7,6 @@ var ErrUserNotFound = errors.New("user not found")
 
 // ErrPhoneBookNotFound is returned when a phone book is not found in the DAO.
 var ErrPhoneBookNotFound = errors.New("phone book not found")
+
+// ErrOTPChallengeNotFound is returned when no pending OTP challenge exists for a phone number.
+var ErrOTPChallengeNotFound = errors.New("otp challenge not found")
@@ -0,0 +1,78 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+// LookupLogDAO defines the data access contract for the log of authenticated lookups.
+// All methods accept a context for timeouts and cancellations, and return errors for data access or validation failures.
+type LookupLogDAO interface {
+	// AddLookup stores a lookup record.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   record: the lookup (both phone numbers must be 12 digits, start with 91)
+	// Returns:
+	//   error: if validation fails or storage error occurs
+	// Example:
+	//   err := dao.AddLookup(ctx, &models.LookupRecord{RequesterPhoneNumber: "919123456789", PhoneNumber: "919876543210", LookedUpAt: time.Now()})
+	AddLookup(ctx context.Context, record *models.LookupRecord) error
+
+	// GetLookupsByPhoneNumber returns the lookups of a phone number, most recent first.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   phoneNumber: the looked-up phone number
+	// Returns:
+	//   records: the lookups (empty if none)
+	//   error: if storage error occurs
+	// Example:
+	//   records, err := dao.GetLookupsByPhoneNumber(ctx, "919876543210")
+	GetLookupsByPhoneNumber(ctx context.Context, phoneNumber string) ([]*models.LookupRecord, error)
+
+	// GetLookupsByRequester returns the lookups made by a phone number, most recent first.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   requesterPhoneNumber: the requester's phone number
+	// Returns:
+	//   records: the lookups (empty if none)
+	//   error: if storage error occurs
+	// Example:
+	//   records, err := dao.GetLookupsByRequester(ctx, "919123456789")
+	GetLookupsByRequester(ctx context.Context, requesterPhoneNumber string) ([]*models.LookupRecord, error)
+
+	// TrimLookups keeps only the keep most recent lookups of a phone number.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   phoneNumber: the looked-up phone number
+	//   keep: the number of records to keep
+	// Returns:
+	//   error: if storage error occurs
+	// Example:
+	//   err := dao.TrimLookups(ctx, "919876543210", 1000)
+	TrimLookups(ctx context.Context, phoneNumber string, keep int) error
+
+	// DeleteLookupsBefore removes all lookups made before cutoff.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   cutoff: lookups strictly before this time are removed
+	// Returns:
+	//   deleted: the number of records removed
+	//   error: if storage error occurs
+	// Example:
+	//   deleted, err := dao.DeleteLookupsBefore(ctx, time.Now().Add(-30*24*time.Hour))
+	DeleteLookupsBefore(ctx context.Context, cutoff time.Time) (int, error)
+
+	// DeleteLookupsByPhoneNumber removes every lookup made by or of a phone number.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   phoneNumber: the phone number
+	// Returns:
+	//   error: if storage error occurs
+	// Example:
+	//   err := dao.DeleteLookupsByPhoneNumber(ctx, "919876543210")
+	DeleteLookupsByPhoneNumber(ctx context.Context, phoneNumber string) error
+}
+
+// Error handling pattern: All methods return error for not found, validation, or storage errors. Use errors.Is for type checks.
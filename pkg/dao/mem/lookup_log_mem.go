@@ -0,0 +1,177 @@
+package mem
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+// LookupLogMemDAO is a thread-safe in-memory implementation of LookupLogDAO.
+type LookupLogMemDAO struct {
+	mu          sync.RWMutex
+	byTarget    map[string][]*models.LookupRecord // key: looked-up phone number, oldest first
+	byRequester map[string][]*models.LookupRecord // key: requester phone number, oldest first
+}
+
+// NewLookupLogMemDAO creates a new LookupLogMemDAO instance.
+func NewLookupLogMemDAO() *LookupLogMemDAO {
+	return &LookupLogMemDAO{
+		byTarget:    make(map[string][]*models.LookupRecord),
+		byRequester: make(map[string][]*models.LookupRecord),
+	}
+}
+
+// AddLookup stores a lookup record.
+func (dao *LookupLogMemDAO) AddLookup(ctx context.Context, record *models.LookupRecord) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err := (&models.PhoneBook{PhoneNumber: record.RequesterPhoneNumber}).Validate(); err != nil {
+		return err
+	}
+	if err := (&models.PhoneBook{PhoneNumber: record.PhoneNumber}).Validate(); err != nil {
+		return err
+	}
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+	// Copy to avoid external mutation
+	r := *record
+	dao.byTarget[r.PhoneNumber] = insertByTime(dao.byTarget[r.PhoneNumber], &r)
+	dao.byRequester[r.RequesterPhoneNumber] = insertByTime(dao.byRequester[r.RequesterPhoneNumber], &r)
+	return nil
+}
+
+// GetLookupsByPhoneNumber returns the lookups of a phone number, most recent first.
+func (dao *LookupLogMemDAO) GetLookupsByPhoneNumber(ctx context.Context, phoneNumber string) ([]*models.LookupRecord, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	dao.mu.RLock()
+	defer dao.mu.RUnlock()
+	return newestFirst(dao.byTarget[phoneNumber]), nil
+}
+
+// GetLookupsByRequester returns the lookups made by a phone number, most recent first.
+func (dao *LookupLogMemDAO) GetLookupsByRequester(ctx context.Context, requesterPhoneNumber string) ([]*models.LookupRecord, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	dao.mu.RLock()
+	defer dao.mu.RUnlock()
+	return newestFirst(dao.byRequester[requesterPhoneNumber]), nil
+}
+
+// TrimLookups keeps only the keep most recent lookups of a phone number.
+func (dao *LookupLogMemDAO) TrimLookups(ctx context.Context, phoneNumber string, keep int) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+	records := dao.byTarget[phoneNumber]
+	if len(records) <= keep {
+		return nil
+	}
+	drop := make(map[*models.LookupRecord]struct{}, len(records)-keep)
+	for _, r := range records[:len(records)-max(keep, 0)] { // oldest first
+		drop[r] = struct{}{}
+	}
+	dao.removeRecords(drop)
+	return nil
+}
+
+// DeleteLookupsBefore removes all lookups made before cutoff.
+func (dao *LookupLogMemDAO) DeleteLookupsBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+	drop := make(map[*models.LookupRecord]struct{})
+	for _, records := range dao.byTarget {
+		for _, r := range records {
+			if !r.LookedUpAt.Before(cutoff) {
+				break
+			}
+			drop[r] = struct{}{}
+		}
+	}
+	dao.removeRecords(drop)
+	return len(drop), nil
+}
+
+// DeleteLookupsByPhoneNumber removes every lookup made by or of a phone number.
+func (dao *LookupLogMemDAO) DeleteLookupsByPhoneNumber(ctx context.Context, phoneNumber string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+	drop := make(map[*models.LookupRecord]struct{})
+	for _, r := range dao.byTarget[phoneNumber] {
+		drop[r] = struct{}{}
+	}
+	for _, r := range dao.byRequester[phoneNumber] {
+		drop[r] = struct{}{}
+	}
+	dao.removeRecords(drop)
+	return nil
+}
+
+// removeRecords removes the given records from both indexes, touching only the affected keys.
+// The caller must hold dao.mu.
+func (dao *LookupLogMemDAO) removeRecords(drop map[*models.LookupRecord]struct{}) {
+	targets := make(map[string]struct{})
+	requesters := make(map[string]struct{})
+	for r := range drop {
+		targets[r.PhoneNumber] = struct{}{}
+		requesters[r.RequesterPhoneNumber] = struct{}{}
+	}
+	filterRecords(dao.byTarget, targets, drop)
+	filterRecords(dao.byRequester, requesters, drop)
+}
+
+// filterRecords removes the dropped records from index under the given keys.
+func filterRecords(index map[string][]*models.LookupRecord, keys map[string]struct{}, drop map[*models.LookupRecord]struct{}) {
+	for key := range keys {
+		var kept []*models.LookupRecord
+		for _, r := range index[key] {
+			if _, ok := drop[r]; !ok {
+				kept = append(kept, r)
+			}
+		}
+		if len(kept) == 0 {
+			delete(index, key)
+		} else {
+			index[key] = kept
+		}
+	}
+}
+
+// insertByTime inserts r into records, keeping them ordered oldest first.
+func insertByTime(records []*models.LookupRecord, r *models.LookupRecord) []*models.LookupRecord {
+	i := len(records)
+	for i > 0 && records[i-1].LookedUpAt.After(r.LookedUpAt) {
+		i--
+	}
+	records = append(records, nil)
+	copy(records[i+1:], records[i:])
+	records[i] = r
+	return records
+}
+
+// newestFirst returns copies of records in reverse order.
+func newestFirst(records []*models.LookupRecord) []*models.LookupRecord {
+	result := make([]*models.LookupRecord, 0, len(records))
+	for i := len(records) - 1; i >= 0; i-- {
+		r := *records[i]
+		result = append(result, &r)
+	}
+	return result
+}
+
+// Ensure LookupLogMemDAO implements dao.LookupLogDAO
+var _ dao.LookupLogDAO = (*LookupLogMemDAO)(nil)
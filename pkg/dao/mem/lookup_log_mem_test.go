@@ -0,0 +1,58 @@
+package mem
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+func TestLookupLogMemDAO(t *testing.T) {
+	dao := NewLookupLogMemDAO()
+	ctx := context.Background()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	records := []*models.LookupRecord{
+		{RequesterPhoneNumber: "919123456789", PhoneNumber: "919876543210", LookedUpAt: base.Add(2 * time.Hour)},
+		{RequesterPhoneNumber: "919123456780", PhoneNumber: "919876543210", LookedUpAt: base},
+		{RequesterPhoneNumber: "919123456789", PhoneNumber: "919876543211", LookedUpAt: base.Add(time.Hour)},
+	}
+	for _, r := range records {
+		if err := dao.AddLookup(ctx, r); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	got, _ := dao.GetLookupsByPhoneNumber(ctx, "919876543210")
+	if len(got) != 2 || got[0].RequesterPhoneNumber != "919123456789" {
+		t.Fatalf("expected 2 lookups newest first, got %+v", got)
+	}
+	made, _ := dao.GetLookupsByRequester(ctx, "919123456789")
+	if len(made) != 2 || made[0].PhoneNumber != "919876543210" {
+		t.Errorf("expected 2 lookups made newest first, got %+v", made)
+	}
+
+	if err := dao.TrimLookups(ctx, "919876543210", 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, _ = dao.GetLookupsByPhoneNumber(ctx, "919876543210")
+	if len(got) != 1 || !got[0].LookedUpAt.Equal(base.Add(2*time.Hour)) {
+		t.Errorf("expected only the newest lookup after trim, got %+v", got)
+	}
+	if made, _ := dao.GetLookupsByRequester(ctx, "919123456780"); len(made) != 0 {
+		t.Errorf("expected trimmed lookup removed from requester index, got %+v", made)
+	}
+
+	deleted, err := dao.DeleteLookupsBefore(ctx, base.Add(90*time.Minute))
+	if err != nil || deleted != 1 {
+		t.Errorf("expected 1 expired lookup deleted, got %d (%v)", deleted, err)
+	}
+	if err := dao.DeleteLookupsByPhoneNumber(ctx, "919123456789"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, _ := dao.GetLookupsByPhoneNumber(ctx, "919876543210"); len(got) != 0 {
+		t.Errorf("expected lookups made by the erased number to be removed, got %+v", got)
+	}
+	if err := dao.AddLookup(ctx, &models.LookupRecord{RequesterPhoneNumber: "123", PhoneNumber: "919876543210"}); err == nil {
+		t.Error("expected validation error, got nil")
+	}
+}
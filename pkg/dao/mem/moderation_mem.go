@@ -0,0 +1,91 @@
+package mem
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+// ModerationMemDAO is a thread-safe in-memory implementation of ModerationDAO.
+type ModerationMemDAO struct {
+	mu          sync.RWMutex
+	moderations map[string]*models.NameModeration // key: phone number
+}
+
+// NewModerationMemDAO creates a new ModerationMemDAO instance.
+func NewModerationMemDAO() *ModerationMemDAO {
+	return &ModerationMemDAO{moderations: make(map[string]*models.NameModeration)}
+}
+
+// SaveNameModeration creates or replaces the moderation state of moderation.PhoneNumber.
+func (dao *ModerationMemDAO) SaveNameModeration(ctx context.Context, moderation *models.NameModeration) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if moderation == nil || moderation.PhoneNumber == "" {
+		return errors.New("moderation phone number is required")
+	}
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+	dao.moderations[moderation.PhoneNumber] = copyNameModeration(moderation)
+	return nil
+}
+
+// GetNameModeration retrieves the moderation state of a phone number, or nil if no name is suppressed for it.
+func (dao *ModerationMemDAO) GetNameModeration(ctx context.Context, phoneNumber string) (*models.NameModeration, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	dao.mu.RLock()
+	defer dao.mu.RUnlock()
+	moderation, ok := dao.moderations[phoneNumber]
+	if !ok {
+		return nil, nil
+	}
+	return copyNameModeration(moderation), nil
+}
+
+// ListNameModerations returns every number with suppressed names, most recently updated first.
+func (dao *ModerationMemDAO) ListNameModerations(ctx context.Context) ([]*models.NameModeration, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	dao.mu.RLock()
+	result := make([]*models.NameModeration, 0, len(dao.moderations))
+	for _, moderation := range dao.moderations {
+		result = append(result, copyNameModeration(moderation))
+	}
+	dao.mu.RUnlock()
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].UpdatedAt.Equal(result[j].UpdatedAt) {
+			return result[i].UpdatedAt.After(result[j].UpdatedAt)
+		}
+		return result[i].PhoneNumber < result[j].PhoneNumber
+	})
+	return result, nil
+}
+
+// DeleteNameModeration removes the moderation state of a phone number, if any.
+func (dao *ModerationMemDAO) DeleteNameModeration(ctx context.Context, phoneNumber string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+	delete(dao.moderations, phoneNumber)
+	return nil
+}
+
+// copyNameModeration returns a deep copy of moderation.
+func copyNameModeration(moderation *models.NameModeration) *models.NameModeration {
+	c := *moderation
+	c.Suppressed = append([]models.SuppressedName(nil), moderation.Suppressed...)
+	return &c
+}
+
+// Ensure ModerationMemDAO implements dao.ModerationDAO
+var _ dao.ModerationDAO = (*ModerationMemDAO)(nil)
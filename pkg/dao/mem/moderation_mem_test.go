@@ -0,0 +1,46 @@
+package mem
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+func TestModerationMemDAO(t *testing.T) {
+	dao := NewModerationMemDAO()
+	ctx := context.Background()
+	now := time.Now()
+	if moderation, err := dao.GetNameModeration(ctx, "919876543210"); err != nil || moderation != nil {
+		t.Fatalf("expected no moderation state, got %+v (%v)", moderation, err)
+	}
+	first := &models.NameModeration{PhoneNumber: "919876543210", Suppressed: []models.SuppressedName{{Name: "Idiot", UploaderPhoneNumber: "919123456789"}}, UpdatedAt: now}
+	if err := dao.SaveNameModeration(ctx, first); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	first.Suppressed[0].Name = "mutated"
+	if err := dao.SaveNameModeration(ctx, &models.NameModeration{PhoneNumber: "919876543211", UpdatedAt: now.Add(time.Minute)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := dao.GetNameModeration(ctx, "919876543210")
+	if err != nil || got.Suppressed[0].Name != "Idiot" {
+		t.Errorf("expected stored state to be isolated from caller changes, got %+v (%v)", got, err)
+	}
+	list, err := dao.ListNameModerations(ctx)
+	if err != nil || len(list) != 2 || list[0].PhoneNumber != "919876543211" {
+		t.Errorf("expected most recently updated first, got %+v (%v)", list, err)
+	}
+	if err := dao.DeleteNameModeration(ctx, "919876543210"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, _ := dao.GetNameModeration(ctx, "919876543210"); got != nil {
+		t.Errorf("expected state to be deleted, got %+v", got)
+	}
+	if err := dao.DeleteNameModeration(ctx, "919876543210"); err != nil {
+		t.Errorf("expected deleting a missing state to succeed, got %v", err)
+	}
+	if err := dao.SaveNameModeration(ctx, &models.NameModeration{}); err == nil {
+		t.Error("expected validation error, got nil")
+	}
+}
@@ -0,0 +1,81 @@
+package mem
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+// OffenderMemDAO is a thread-safe in-memory implementation of OffenderDAO.
+type OffenderMemDAO struct {
+	mu        sync.RWMutex
+	offenders map[string]*models.Offender // key: client key
+}
+
+// NewOffenderMemDAO creates a new OffenderMemDAO instance.
+func NewOffenderMemDAO() *OffenderMemDAO {
+	return &OffenderMemDAO{offenders: make(map[string]*models.Offender)}
+}
+
+// SaveOffender creates or replaces the record for offender.ClientKey.
+func (dao *OffenderMemDAO) SaveOffender(ctx context.Context, offender *models.Offender) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if offender == nil || offender.ClientKey == "" {
+		return errors.New("offender client key is required")
+	}
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+	dao.offenders[offender.ClientKey] = copyOffender(offender)
+	return nil
+}
+
+// GetOffender retrieves the record for a client, or nil if it was never flagged.
+func (dao *OffenderMemDAO) GetOffender(ctx context.Context, clientKey string) (*models.Offender, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	dao.mu.RLock()
+	defer dao.mu.RUnlock()
+	offender, ok := dao.offenders[clientKey]
+	if !ok {
+		return nil, nil
+	}
+	return copyOffender(offender), nil
+}
+
+// ListOffenders returns all flagged clients, most recently flagged first.
+func (dao *OffenderMemDAO) ListOffenders(ctx context.Context) ([]*models.Offender, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	dao.mu.RLock()
+	result := make([]*models.Offender, 0, len(dao.offenders))
+	for _, offender := range dao.offenders {
+		result = append(result, copyOffender(offender))
+	}
+	dao.mu.RUnlock()
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].LastSeen.Equal(result[j].LastSeen) {
+			return result[i].LastSeen.After(result[j].LastSeen)
+		}
+		return result[i].ClientKey < result[j].ClientKey
+	})
+	return result, nil
+}
+
+// copyOffender returns a deep copy of offender.
+func copyOffender(offender *models.Offender) *models.Offender {
+	c := *offender
+	c.Signals = append([]string(nil), offender.Signals...)
+	c.SampleNumbers = append([]string(nil), offender.SampleNumbers...)
+	return &c
+}
+
+// Ensure OffenderMemDAO implements dao.OffenderDAO
+var _ dao.OffenderDAO = (*OffenderMemDAO)(nil)
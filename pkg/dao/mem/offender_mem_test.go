@@ -0,0 +1,37 @@
+package mem
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+func TestOffenderMemDAO(t *testing.T) {
+	dao := NewOffenderMemDAO()
+	ctx := context.Background()
+	now := time.Now()
+	if offender, err := dao.GetOffender(ctx, "10.0.0.1"); err != nil || offender != nil {
+		t.Fatalf("expected no offender, got %+v (%v)", offender, err)
+	}
+	first := &models.Offender{ClientKey: "10.0.0.1", Signals: []string{models.SignalSequential}, Action: models.ActionThrottle, LastSeen: now}
+	if err := dao.SaveOffender(ctx, first); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	first.Signals[0] = "mutated"
+	if err := dao.SaveOffender(ctx, &models.Offender{ClientKey: "10.0.0.2", Action: models.ActionBlock, LastSeen: now.Add(time.Minute)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := dao.GetOffender(ctx, "10.0.0.1")
+	if err != nil || got.Signals[0] != models.SignalSequential {
+		t.Errorf("expected stored offender to be isolated from caller changes, got %+v (%v)", got, err)
+	}
+	list, err := dao.ListOffenders(ctx)
+	if err != nil || len(list) != 2 || list[0].ClientKey != "10.0.0.2" {
+		t.Errorf("expected most recently flagged first, got %+v (%v)", list, err)
+	}
+	if err := dao.SaveOffender(ctx, &models.Offender{}); err == nil {
+		t.Error("expected validation error, got nil")
+	}
+}
@@ -0,0 +1,68 @@
+package mem
+
+import (
+	"context"
+	"sync"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao"
+	"github.com/yourusername/truecaller-lite/pkg/dao/daoerrors"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+// OTPMemDAO is a thread-safe in-memory implementation of OTPDAO.
+type OTPMemDAO struct {
+	mu         sync.RWMutex
+	challenges map[string]models.OTPChallenge // key: phone number being verified
+}
+
+// NewOTPMemDAO creates a new OTPMemDAO instance.
+func NewOTPMemDAO() *OTPMemDAO {
+	return &OTPMemDAO{challenges: make(map[string]models.OTPChallenge)}
+}
+
+// SaveChallenge creates or replaces the challenge for challenge.PhoneNumber.
+func (dao *OTPMemDAO) SaveChallenge(ctx context.Context, challenge *models.OTPChallenge) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err := (&models.PhoneBook{PhoneNumber: challenge.PhoneNumber}).Validate(); err != nil {
+		return err
+	}
+	c := *challenge
+	c.CodeHash = append([]byte(nil), challenge.CodeHash...)
+	c.Salt = append([]byte(nil), challenge.Salt...)
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+	dao.challenges[c.PhoneNumber] = c
+	return nil
+}
+
+// GetChallenge retrieves the pending challenge for a phone number.
+func (dao *OTPMemDAO) GetChallenge(ctx context.Context, phoneNumber string) (*models.OTPChallenge, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	dao.mu.RLock()
+	defer dao.mu.RUnlock()
+	c, ok := dao.challenges[phoneNumber]
+	if !ok {
+		return nil, daoerrors.ErrOTPChallengeNotFound
+	}
+	c.CodeHash = append([]byte(nil), c.CodeHash...)
+	c.Salt = append([]byte(nil), c.Salt...)
+	return &c, nil
+}
+
+// DeleteChallenge removes the pending challenge for a phone number.
+func (dao *OTPMemDAO) DeleteChallenge(ctx context.Context, phoneNumber string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+	delete(dao.challenges, phoneNumber)
+	return nil
+}
+
+// Ensure OTPMemDAO implements dao.OTPDAO
+var _ dao.OTPDAO = (*OTPMemDAO)(nil)
@@ -0,0 +1,45 @@
+package mem
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao/daoerrors"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+func TestOTPMemDAO(t *testing.T) {
+	dao := NewOTPMemDAO()
+	ctx := context.Background()
+	if _, err := dao.GetChallenge(ctx, "919876543210"); !errors.Is(err, daoerrors.ErrOTPChallengeNotFound) {
+		t.Fatalf("expected ErrOTPChallengeNotFound, got %v", err)
+	}
+	challenge := &models.OTPChallenge{PhoneNumber: "919876543210", CodeHash: []byte{1, 2}, Salt: []byte{3}, ExpiresAt: time.Now().Add(time.Minute)}
+	if err := dao.SaveChallenge(ctx, challenge); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	challenge.CodeHash[0] = 9
+	got, err := dao.GetChallenge(ctx, "919876543210")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.CodeHash[0] != 1 {
+		t.Error("expected stored challenge to be isolated from caller changes")
+	}
+	if err := dao.DeleteChallenge(ctx, "919876543210"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := dao.GetChallenge(ctx, "919876543210"); !errors.Is(err, daoerrors.ErrOTPChallengeNotFound) {
+		t.Errorf("expected ErrOTPChallengeNotFound after delete, got %v", err)
+	}
+	if err := dao.SaveChallenge(ctx, &models.OTPChallenge{PhoneNumber: "123"}); err == nil {
+		t.Error("expected validation error, got nil")
+	}
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := dao.SaveChallenge(canceled, challenge); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
@@ -3,7 +3,9 @@ package mem
 import (
 	"context"
 	"errors"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/yourusername/truecaller-lite/pkg/dao"
 	"github.com/yourusername/truecaller-lite/pkg/dao/daoerrors"
@@ -13,13 +15,21 @@ import (
 // PhoneBookMemDAO is a thread-safe in-memory implementation of PhoneBookDAO.
 type PhoneBookMemDAO struct {
 	mu        sync.RWMutex
-	phonebook map[string]*models.PhoneBook // key: owner phone number
+	phonebook map[string]*models.PhoneBook       // key: owner phone number
+	byContact map[string]map[string]savedContact // key: contact phone number -> owner phone number
+}
+
+// savedContact is a reverse index entry: the contact as an owner saved it, and when.
+type savedContact struct {
+	contact models.Contact
+	savedAt time.Time
 }
 
 // NewPhoneBookMemDAO creates a new PhoneBookMemDAO instance.
 func NewPhoneBookMemDAO() *PhoneBookMemDAO {
 	return &PhoneBookMemDAO{
 		phonebook: make(map[string]*models.PhoneBook),
+		byContact: make(map[string]map[string]savedContact),
 	}
 }
 
@@ -37,9 +47,14 @@ func (dao *PhoneBookMemDAO) CreateOrUpdatePhoneBook(ctx context.Context, pb *mod
 	}
 	dao.mu.Lock()
 	defer dao.mu.Unlock()
+	if existing, ok := dao.phonebook[owner]; ok {
+		dao.unindex(owner, existing.Contacts)
+	}
 	// Copy to avoid external mutation
 	copyPB := *pb
+	copyPB.Contacts = append([]models.Contact(nil), pb.Contacts...)
 	dao.phonebook[owner] = &copyPB
+	dao.index(owner, copyPB.Contacts)
 	return nil
 }
 
@@ -59,5 +74,115 @@ func (dao *PhoneBookMemDAO) GetPhoneBookByUserPhoneNumber(ctx context.Context, p
 	return &copyPB, nil
 }
 
+// UpsertContacts merges contacts into the owner's phone book, replacing entries with the same phone number.
+func (dao *PhoneBookMemDAO) UpsertContacts(ctx context.Context, ownerPhoneNumber string, contacts []models.Contact) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err := (&models.PhoneBook{PhoneNumber: ownerPhoneNumber, Contacts: contacts}).Validate(); err != nil {
+		return err
+	}
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+	var merged []models.Contact
+	if existing, ok := dao.phonebook[ownerPhoneNumber]; ok {
+		// Build a new slice so previously returned copies are not mutated
+		merged = append(merged, existing.Contacts...)
+	}
+	position := make(map[string]int, len(merged)+len(contacts))
+	for i, c := range merged {
+		position[c.GetPhoneNumber()] = i
+	}
+	for _, c := range contacts {
+		if i, ok := position[c.GetPhoneNumber()]; ok {
+			merged[i] = c
+			continue
+		}
+		position[c.GetPhoneNumber()] = len(merged)
+		merged = append(merged, c)
+	}
+	dao.phonebook[ownerPhoneNumber] = &models.PhoneBook{PhoneNumber: ownerPhoneNumber, Contacts: merged}
+	dao.index(ownerPhoneNumber, contacts)
+	return nil
+}
+
+// GetContactEntriesByPhoneNumber returns every saved copy of a contact across all phone books.
+func (dao *PhoneBookMemDAO) GetContactEntriesByPhoneNumber(ctx context.Context, phoneNumber string) ([]models.ContactEntry, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	dao.mu.RLock()
+	defer dao.mu.RUnlock()
+	owners := dao.byContact[phoneNumber]
+	result := make([]models.ContactEntry, 0, len(owners))
+	for owner, saved := range owners {
+		result = append(result, models.ContactEntry{
+			OwnerPhoneNumber: owner,
+			Contact:          saved.contact,
+			SavedAt:          saved.savedAt,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].OwnerPhoneNumber < result[j].OwnerPhoneNumber })
+	return result, nil
+}
+
+// ListPhoneBooks returns every stored phone book, ordered by owner phone number.
+func (dao *PhoneBookMemDAO) ListPhoneBooks(ctx context.Context) ([]*models.PhoneBook, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	dao.mu.RLock()
+	result := make([]*models.PhoneBook, 0, len(dao.phonebook))
+	for _, pb := range dao.phonebook {
+		// Return copies to avoid external mutation
+		copyPB := *pb
+		copyPB.Contacts = append([]models.Contact(nil), pb.Contacts...)
+		result = append(result, &copyPB)
+	}
+	dao.mu.RUnlock()
+	sort.Slice(result, func(i, j int) bool { return result[i].PhoneNumber < result[j].PhoneNumber })
+	return result, nil
+}
+
+// DeletePhoneBook removes the owner's phone book.
+func (dao *PhoneBookMemDAO) DeletePhoneBook(ctx context.Context, ownerPhoneNumber string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+	pb, ok := dao.phonebook[ownerPhoneNumber]
+	if !ok {
+		return daoerrors.ErrPhoneBookNotFound
+	}
+	dao.unindex(ownerPhoneNumber, pb.Contacts)
+	delete(dao.phonebook, ownerPhoneNumber)
+	return nil
+}
+
+// index adds an owner's contacts to the reverse index, stamped with the current time. Callers must hold the write lock.
+func (dao *PhoneBookMemDAO) index(owner string, contacts []models.Contact) {
+	now := time.Now()
+	for _, c := range contacts {
+		owners, ok := dao.byContact[c.GetPhoneNumber()]
+		if !ok {
+			owners = make(map[string]savedContact)
+			dao.byContact[c.GetPhoneNumber()] = owners
+		}
+		owners[owner] = savedContact{contact: c, savedAt: now}
+	}
+}
+
+// unindex removes an owner's contacts from the reverse index. Callers must hold the write lock.
+func (dao *PhoneBookMemDAO) unindex(owner string, contacts []models.Contact) {
+	for _, c := range contacts {
+		owners := dao.byContact[c.GetPhoneNumber()]
+		delete(owners, owner)
+		if len(owners) == 0 {
+			delete(dao.byContact, c.GetPhoneNumber())
+		}
+	}
+}
+
 // Ensure PhoneBookMemDAO implements dao.PhoneBookDAO
 var _ dao.PhoneBookDAO = (*PhoneBookMemDAO)(nil)
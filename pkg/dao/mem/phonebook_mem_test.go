@@ -70,3 +70,104 @@ func TestPhoneBookMemDAO_ConcurrentAccess(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestPhoneBookMemDAO_UpsertContacts(t *testing.T) {
+	dao := NewPhoneBookMemDAO()
+	ctx := context.Background()
+	owner := "919876543210"
+	if err := dao.UpsertContacts(ctx, owner, []models.Contact{{PhoneNumber: "919123456789", Name: "Bob"}, {PhoneNumber: "919123456780", Name: "Carol"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	before, _ := dao.GetPhoneBookByUserPhoneNumber(ctx, owner)
+	if err := dao.UpsertContacts(ctx, owner, []models.Contact{{PhoneNumber: "919123456789", Name: "Robert"}, {PhoneNumber: "919123456781", Name: "Dave"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := dao.GetPhoneBookByUserPhoneNumber(ctx, owner)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"Robert", "Carol", "Dave"}
+	if len(got.GetContacts()) != len(want) {
+		t.Fatalf("expected %d contacts, got %+v", len(want), got.GetContacts())
+	}
+	for i, name := range want {
+		if got.GetContacts()[i].GetName() != name {
+			t.Errorf("contact %d: expected %s, got %s", i, name, got.GetContacts()[i].GetName())
+		}
+	}
+	if before.GetContacts()[0].GetName() != "Bob" {
+		t.Errorf("expected earlier copy to be unchanged, got %s", before.GetContacts()[0].GetName())
+	}
+	if err := dao.UpsertContacts(ctx, owner, []models.Contact{{PhoneNumber: "123", Name: "Bad"}}); err == nil {
+		t.Error("expected validation error, got nil")
+	}
+}
+
+func TestPhoneBookMemDAO_GetContactEntriesByPhoneNumber(t *testing.T) {
+	dao := NewPhoneBookMemDAO()
+	ctx := context.Background()
+	_ = dao.CreateOrUpdatePhoneBook(ctx, &models.PhoneBook{PhoneNumber: "919876543211", Contacts: []models.Contact{{PhoneNumber: "919123456789", Name: "Bobby"}}})
+	_ = dao.CreateOrUpdatePhoneBook(ctx, &models.PhoneBook{PhoneNumber: "919876543210", Contacts: []models.Contact{{PhoneNumber: "919123456789", Name: "Bob"}}})
+	_ = dao.UpsertContacts(ctx, "919876543212", []models.Contact{{PhoneNumber: "919123456789", Name: "Robert"}})
+
+	entries, err := dao.GetContactEntriesByPhoneNumber(ctx, "919123456789")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"Bob", "Bobby", "Robert"}
+	if len(entries) != len(want) {
+		t.Fatalf("expected %d entries, got %+v", len(want), entries)
+	}
+	for i, name := range want {
+		if entries[i].Contact.GetName() != name {
+			t.Errorf("entry %d: expected %s, got %+v", i, name, entries[i])
+		}
+	}
+
+	// Replacing a phone book removes contacts that are no longer in it
+	_ = dao.CreateOrUpdatePhoneBook(ctx, &models.PhoneBook{PhoneNumber: "919876543210", Contacts: nil})
+	entries, _ = dao.GetContactEntriesByPhoneNumber(ctx, "919123456789")
+	if len(entries) != 2 {
+		t.Errorf("expected 2 entries after replacement, got %+v", entries)
+	}
+	none, _ := dao.GetContactEntriesByPhoneNumber(ctx, "919000000000")
+	if len(none) != 0 {
+		t.Errorf("expected no entries, got %+v", none)
+	}
+}
+
+func TestPhoneBookMemDAO_DeletePhoneBook(t *testing.T) {
+	dao := NewPhoneBookMemDAO()
+	ctx := context.Background()
+	_ = dao.CreateOrUpdatePhoneBook(ctx, &models.PhoneBook{PhoneNumber: "919876543210", Contacts: []models.Contact{{PhoneNumber: "919123456789", Name: "Bob"}}})
+	if err := dao.DeletePhoneBook(ctx, "919876543210"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := dao.GetPhoneBookByUserPhoneNumber(ctx, "919876543210"); !errors.Is(err, daoerrors.ErrPhoneBookNotFound) {
+		t.Errorf("expected phone book not found error, got %v", err)
+	}
+	if entries, _ := dao.GetContactEntriesByPhoneNumber(ctx, "919123456789"); len(entries) != 0 {
+		t.Errorf("expected reverse index entries to be removed, got %+v", entries)
+	}
+	if err := dao.DeletePhoneBook(ctx, "919876543210"); !errors.Is(err, daoerrors.ErrPhoneBookNotFound) {
+		t.Errorf("expected phone book not found error on second delete, got %v", err)
+	}
+}
+
+func TestPhoneBookMemDAO_ListPhoneBooks(t *testing.T) {
+	dao := NewPhoneBookMemDAO()
+	ctx := context.Background()
+	if list, err := dao.ListPhoneBooks(ctx); err != nil || len(list) != 0 {
+		t.Fatalf("expected no phone books, got %+v (%v)", list, err)
+	}
+	_ = dao.CreateOrUpdatePhoneBook(ctx, &models.PhoneBook{PhoneNumber: "919876543211", Contacts: []models.Contact{{PhoneNumber: "919123456789", Name: "Bob"}}})
+	_ = dao.UpsertContacts(ctx, "919876543210", []models.Contact{{PhoneNumber: "919123456780", Name: "Carol"}})
+	list, err := dao.ListPhoneBooks(ctx)
+	if err != nil || len(list) != 2 || list[0].PhoneNumber != "919876543210" || list[1].Contacts[0].Name != "Bob" {
+		t.Fatalf("expected both phone books by owner, got %+v (%v)", list, err)
+	}
+	list[1].Contacts[0].Name = "Mallory"
+	if pb, _ := dao.GetPhoneBookByUserPhoneNumber(ctx, "919876543211"); pb.Contacts[0].Name != "Bob" {
+		t.Errorf("expected stored phone book to be isolated from caller changes, got %+v", pb)
+	}
+}
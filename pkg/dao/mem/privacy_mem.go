@@ -0,0 +1,166 @@
+package mem
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+// PrivacyMemDAO is a thread-safe in-memory implementation of PrivacyDAO.
+type PrivacyMemDAO struct {
+	mu         sync.RWMutex
+	tombstones map[string]time.Time // key: erased phone number, value: erasure time
+	unlisted   map[string]struct{}  // key: unlisted phone number
+	optOuts    map[string]struct{}  // key: phone number hidden from "who viewed me" lists
+}
+
+// NewPrivacyMemDAO creates a new PrivacyMemDAO instance.
+func NewPrivacyMemDAO() *PrivacyMemDAO {
+	return &PrivacyMemDAO{
+		tombstones: make(map[string]time.Time),
+		unlisted:   make(map[string]struct{}),
+		optOuts:    make(map[string]struct{}),
+	}
+}
+
+// CreateTombstone marks a phone number as erased at erasedAt, keeping the later time of an existing tombstone.
+func (dao *PrivacyMemDAO) CreateTombstone(ctx context.Context, phoneNumber string, erasedAt time.Time) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err := (&models.PhoneBook{PhoneNumber: phoneNumber}).Validate(); err != nil {
+		return err
+	}
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+	if existing, ok := dao.tombstones[phoneNumber]; !ok || erasedAt.After(existing) {
+		dao.tombstones[phoneNumber] = erasedAt
+	}
+	return nil
+}
+
+// DeleteTombstonesBefore removes the tombstones of numbers erased before cutoff.
+func (dao *PrivacyMemDAO) DeleteTombstonesBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+	deleted := 0
+	for phoneNumber, erasedAt := range dao.tombstones {
+		if erasedAt.Before(cutoff) {
+			delete(dao.tombstones, phoneNumber)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// IsTombstoned reports whether a phone number has been erased.
+func (dao *PrivacyMemDAO) IsTombstoned(ctx context.Context, phoneNumber string) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+	dao.mu.RLock()
+	defer dao.mu.RUnlock()
+	_, ok := dao.tombstones[phoneNumber]
+	return ok, nil
+}
+
+// SetUnlisted sets whether a phone number has opted out of caller ID lookups.
+func (dao *PrivacyMemDAO) SetUnlisted(ctx context.Context, phoneNumber string, unlisted bool) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err := (&models.PhoneBook{PhoneNumber: phoneNumber}).Validate(); err != nil {
+		return err
+	}
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+	if unlisted {
+		dao.unlisted[phoneNumber] = struct{}{}
+	} else {
+		delete(dao.unlisted, phoneNumber)
+	}
+	return nil
+}
+
+// IsUnlisted reports whether a phone number has opted out of caller ID lookups.
+func (dao *PrivacyMemDAO) IsUnlisted(ctx context.Context, phoneNumber string) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+	dao.mu.RLock()
+	defer dao.mu.RUnlock()
+	_, ok := dao.unlisted[phoneNumber]
+	return ok, nil
+}
+
+// SetLookupLogOptOut sets whether a phone number's lookups are hidden from "who viewed me" lists.
+func (dao *PrivacyMemDAO) SetLookupLogOptOut(ctx context.Context, phoneNumber string, optOut bool) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err := (&models.PhoneBook{PhoneNumber: phoneNumber}).Validate(); err != nil {
+		return err
+	}
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+	if optOut {
+		dao.optOuts[phoneNumber] = struct{}{}
+	} else {
+		delete(dao.optOuts, phoneNumber)
+	}
+	return nil
+}
+
+// IsLookupLogOptOut reports whether a phone number's lookups are hidden from "who viewed me" lists.
+func (dao *PrivacyMemDAO) IsLookupLogOptOut(ctx context.Context, phoneNumber string) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+	dao.mu.RLock()
+	defer dao.mu.RUnlock()
+	_, ok := dao.optOuts[phoneNumber]
+	return ok, nil
+}
+
+// ListPrivacySettings returns the privacy state of every phone number that has any, ordered by phone number.
+func (dao *PrivacyMemDAO) ListPrivacySettings(ctx context.Context) ([]*models.PrivacySettings, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	dao.mu.RLock()
+	byNumber := make(map[string]*models.PrivacySettings)
+	settingsFor := func(phoneNumber string) *models.PrivacySettings {
+		if _, ok := byNumber[phoneNumber]; !ok {
+			byNumber[phoneNumber] = &models.PrivacySettings{PhoneNumber: phoneNumber}
+		}
+		return byNumber[phoneNumber]
+	}
+	for phoneNumber, erasedAt := range dao.tombstones {
+		settings := settingsFor(phoneNumber)
+		settings.Erased = true
+		settings.ErasedAt = &erasedAt
+	}
+	for phoneNumber := range dao.unlisted {
+		settingsFor(phoneNumber).Unlisted = true
+	}
+	for phoneNumber := range dao.optOuts {
+		settingsFor(phoneNumber).LookupLogOptOut = true
+	}
+	dao.mu.RUnlock()
+	result := make([]*models.PrivacySettings, 0, len(byNumber))
+	for _, settings := range byNumber {
+		result = append(result, settings)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].PhoneNumber < result[j].PhoneNumber })
+	return result, nil
+}
+
+// Ensure PrivacyMemDAO implements dao.PrivacyDAO
+var _ dao.PrivacyDAO = (*PrivacyMemDAO)(nil)
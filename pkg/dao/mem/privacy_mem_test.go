@@ -0,0 +1,121 @@
+package mem
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+func TestPrivacyMemDAO_Tombstones(t *testing.T) {
+	dao := NewPrivacyMemDAO()
+	ctx := context.Background()
+	erasedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if erased, err := dao.IsTombstoned(ctx, "919876543210"); err != nil || erased {
+		t.Fatalf("expected no tombstone, got %v (%v)", erased, err)
+	}
+	if err := dao.CreateTombstone(ctx, "919876543210", erasedAt); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := dao.CreateTombstone(ctx, "919876543210", erasedAt.Add(time.Hour)); err != nil {
+		t.Fatalf("expected repeated tombstone to succeed, got %v", err)
+	}
+	if erased, err := dao.IsTombstoned(ctx, "919876543210"); err != nil || !erased {
+		t.Errorf("expected tombstone, got %v (%v)", erased, err)
+	}
+	if err := dao.CreateTombstone(ctx, "123", erasedAt); err == nil {
+		t.Error("expected validation error, got nil")
+	}
+
+	_ = dao.CreateTombstone(ctx, "919123456789", erasedAt)
+	if deleted, err := dao.DeleteTombstonesBefore(ctx, erasedAt.Add(time.Minute)); err != nil || deleted != 1 {
+		t.Fatalf("expected 1 tombstone deleted, got %d (%v)", deleted, err)
+	}
+	if erased, _ := dao.IsTombstoned(ctx, "919123456789"); erased {
+		t.Error("expected the old tombstone to be deleted")
+	}
+	if erased, _ := dao.IsTombstoned(ctx, "919876543210"); !erased {
+		t.Error("expected a repeated erasure to keep the later time")
+	}
+}
+
+func TestPrivacyMemDAO_Unlisted(t *testing.T) {
+	dao := NewPrivacyMemDAO()
+	ctx := context.Background()
+	if err := dao.SetUnlisted(ctx, "919876543210", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if unlisted, err := dao.IsUnlisted(ctx, "919876543210"); err != nil || !unlisted {
+		t.Errorf("expected unlisted, got %v (%v)", unlisted, err)
+	}
+	if err := dao.SetUnlisted(ctx, "919876543210", false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if unlisted, _ := dao.IsUnlisted(ctx, "919876543210"); unlisted {
+		t.Error("expected relisted number, got unlisted")
+	}
+	if err := dao.SetUnlisted(ctx, "123", true); err == nil {
+		t.Error("expected validation error, got nil")
+	}
+}
+
+func TestPrivacyMemDAO_LookupLogOptOut(t *testing.T) {
+	dao := NewPrivacyMemDAO()
+	ctx := context.Background()
+	if err := dao.SetLookupLogOptOut(ctx, "919876543210", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if optOut, err := dao.IsLookupLogOptOut(ctx, "919876543210"); err != nil || !optOut {
+		t.Errorf("expected opt-out, got %v (%v)", optOut, err)
+	}
+	if err := dao.SetLookupLogOptOut(ctx, "919876543210", false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if optOut, _ := dao.IsLookupLogOptOut(ctx, "919876543210"); optOut {
+		t.Error("expected opt-in, got opt-out")
+	}
+	if err := dao.SetLookupLogOptOut(ctx, "123", true); err == nil {
+		t.Error("expected validation error, got nil")
+	}
+}
+
+func TestPrivacyMemDAO_ListPrivacySettings(t *testing.T) {
+	dao := NewPrivacyMemDAO()
+	ctx := context.Background()
+	if settings, err := dao.ListPrivacySettings(ctx); err != nil || len(settings) != 0 {
+		t.Fatalf("expected no settings, got %+v (%v)", settings, err)
+	}
+	_ = dao.SetUnlisted(ctx, "919876543211", true)
+	_ = dao.SetLookupLogOptOut(ctx, "919876543211", true)
+	erasedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	_ = dao.CreateTombstone(ctx, "919876543210", erasedAt)
+	_ = dao.SetUnlisted(ctx, "919876543212", true)
+	_ = dao.SetUnlisted(ctx, "919876543212", false)
+	settings, err := dao.ListPrivacySettings(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []models.PrivacySettings{
+		{PhoneNumber: "919876543210", Erased: true, ErasedAt: &erasedAt},
+		{PhoneNumber: "919876543211", Unlisted: true, LookupLogOptOut: true},
+	}
+	if len(settings) != len(want) {
+		t.Fatalf("expected %+v, got %+v", want, settings)
+	}
+	for i := range want {
+		if !reflect.DeepEqual(*settings[i], want[i]) {
+			t.Errorf("expected %+v, got %+v", want[i], *settings[i])
+		}
+	}
+}
+
+func TestPrivacyMemDAO_ContextCanceled(t *testing.T) {
+	dao := NewPrivacyMemDAO()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := dao.CreateTombstone(ctx, "919876543210", time.Now()); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
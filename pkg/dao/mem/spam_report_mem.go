@@ -0,0 +1,171 @@
+package mem
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+// SpamReportMemDAO is a thread-safe in-memory implementation of SpamReportDAO.
+type SpamReportMemDAO struct {
+	mu         sync.RWMutex
+	byReporter map[string][]*models.SpamReport       // key: reporter phone number
+	byTarget   map[string][]*models.SpamReport       // key: reported phone number
+	history    map[string][]*models.SpamStatusChange // key: phone number
+}
+
+// NewSpamReportMemDAO creates a new SpamReportMemDAO instance.
+func NewSpamReportMemDAO() *SpamReportMemDAO {
+	return &SpamReportMemDAO{
+		byReporter: make(map[string][]*models.SpamReport),
+		byTarget:   make(map[string][]*models.SpamReport),
+		history:    make(map[string][]*models.SpamStatusChange),
+	}
+}
+
+// CreateSpamReport stores a spam report.
+func (dao *SpamReportMemDAO) CreateSpamReport(ctx context.Context, report *models.SpamReport) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err := report.Validate(); err != nil {
+		return err
+	}
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+	// Copy to avoid external mutation
+	copyReport := *report
+	dao.byReporter[report.GetReporterPhoneNumber()] = append(dao.byReporter[report.GetReporterPhoneNumber()], &copyReport)
+	dao.byTarget[report.GetPhoneNumber()] = append(dao.byTarget[report.GetPhoneNumber()], &copyReport)
+	return nil
+}
+
+// GetSpamReportsByReporter returns the reports filed by a phone number.
+func (dao *SpamReportMemDAO) GetSpamReportsByReporter(ctx context.Context, reporterPhoneNumber string) ([]*models.SpamReport, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	dao.mu.RLock()
+	defer dao.mu.RUnlock()
+	return copyReports(dao.byReporter[reporterPhoneNumber]), nil
+}
+
+// GetSpamReportsByPhoneNumber returns the reports filed against a phone number.
+func (dao *SpamReportMemDAO) GetSpamReportsByPhoneNumber(ctx context.Context, phoneNumber string) ([]*models.SpamReport, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	dao.mu.RLock()
+	defer dao.mu.RUnlock()
+	return copyReports(dao.byTarget[phoneNumber]), nil
+}
+
+// ListSpamReports returns every stored spam report, ordered by reporter phone number, then in filing order.
+func (dao *SpamReportMemDAO) ListSpamReports(ctx context.Context) ([]*models.SpamReport, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	dao.mu.RLock()
+	defer dao.mu.RUnlock()
+	result := []*models.SpamReport{}
+	for _, reporter := range sortedKeys(dao.byReporter) {
+		result = append(result, copyReports(dao.byReporter[reporter])...)
+	}
+	return result, nil
+}
+
+// AddSpamStatusChange appends an entry to a phone number's spam status history.
+func (dao *SpamReportMemDAO) AddSpamStatusChange(ctx context.Context, change *models.SpamStatusChange) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+	copyChange := *change
+	dao.history[change.PhoneNumber] = append(dao.history[change.PhoneNumber], &copyChange)
+	return nil
+}
+
+// GetSpamStatusHistory returns a phone number's spam status changes.
+func (dao *SpamReportMemDAO) GetSpamStatusHistory(ctx context.Context, phoneNumber string) ([]*models.SpamStatusChange, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	dao.mu.RLock()
+	defer dao.mu.RUnlock()
+	result := make([]*models.SpamStatusChange, 0, len(dao.history[phoneNumber]))
+	for _, change := range dao.history[phoneNumber] {
+		copyChange := *change
+		result = append(result, &copyChange)
+	}
+	return result, nil
+}
+
+// ListSpamStatusChanges returns the spam status history of every phone number, ordered by phone number, then
+// oldest first.
+func (dao *SpamReportMemDAO) ListSpamStatusChanges(ctx context.Context) ([]*models.SpamStatusChange, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	dao.mu.RLock()
+	defer dao.mu.RUnlock()
+	result := []*models.SpamStatusChange{}
+	for _, phoneNumber := range sortedKeys(dao.history) {
+		for _, change := range dao.history[phoneNumber] {
+			copyChange := *change
+			result = append(result, &copyChange)
+		}
+	}
+	return result, nil
+}
+
+// DeleteSpamReportsByReporter removes every report filed by a phone number.
+func (dao *SpamReportMemDAO) DeleteSpamReportsByReporter(ctx context.Context, reporterPhoneNumber string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+	for _, report := range dao.byReporter[reporterPhoneNumber] {
+		target := report.GetPhoneNumber()
+		kept := dao.byTarget[target][:0]
+		for _, r := range dao.byTarget[target] {
+			if r.GetReporterPhoneNumber() != reporterPhoneNumber {
+				kept = append(kept, r)
+			}
+		}
+		if len(kept) == 0 {
+			delete(dao.byTarget, target)
+		} else {
+			dao.byTarget[target] = kept
+		}
+	}
+	delete(dao.byReporter, reporterPhoneNumber)
+	return nil
+}
+
+// copyReports returns copies of reports to avoid external mutation.
+func copyReports(reports []*models.SpamReport) []*models.SpamReport {
+	result := make([]*models.SpamReport, 0, len(reports))
+	for _, report := range reports {
+		copyReport := *report
+		result = append(result, &copyReport)
+	}
+	return result
+}
+
+// sortedKeys returns the keys of m in ascending order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Ensure SpamReportMemDAO implements dao.SpamReportDAO
+var _ dao.SpamReportDAO = (*SpamReportMemDAO)(nil)
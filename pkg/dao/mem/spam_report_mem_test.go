@@ -0,0 +1,89 @@
+package mem
+
+import (
+	"context"
+	"testing"
+
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+func TestSpamReportMemDAO_HappyPath(t *testing.T) {
+	dao := NewSpamReportMemDAO()
+	ctx := context.Background()
+	reports := []*models.SpamReport{
+		{ReporterPhoneNumber: "919876543210", PhoneNumber: "919123456789", Reason: "telemarketing"},
+		{ReporterPhoneNumber: "919876543210", PhoneNumber: "919123456780"},
+		{ReporterPhoneNumber: "919876543211", PhoneNumber: "919123456789"},
+	}
+	for _, r := range reports {
+		if err := dao.CreateSpamReport(ctx, r); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	byReporter, _ := dao.GetSpamReportsByReporter(ctx, "919876543210")
+	if len(byReporter) != 2 || byReporter[0].GetReason() != "telemarketing" {
+		t.Errorf("expected 2 reports by reporter, got %+v", byReporter)
+	}
+	byTarget, _ := dao.GetSpamReportsByPhoneNumber(ctx, "919123456789")
+	if len(byTarget) != 2 {
+		t.Errorf("expected 2 reports against target, got %+v", byTarget)
+	}
+	none, err := dao.GetSpamReportsByReporter(ctx, "919999999999")
+	if err != nil || none == nil || len(none) != 0 {
+		t.Errorf("expected empty result, got %+v (%v)", none, err)
+	}
+	all, err := dao.ListSpamReports(ctx)
+	if err != nil || len(all) != 3 || all[0].GetReason() != "telemarketing" || all[2].GetReporterPhoneNumber() != "919876543211" {
+		t.Errorf("expected every report by reporter in filing order, got %+v (%v)", all, err)
+	}
+}
+
+func TestSpamReportMemDAO_ValidationError(t *testing.T) {
+	dao := NewSpamReportMemDAO()
+	ctx := context.Background()
+	if err := dao.CreateSpamReport(ctx, &models.SpamReport{ReporterPhoneNumber: "919876543210", PhoneNumber: "919876543210"}); err == nil {
+		t.Error("expected validation error for self report, got nil")
+	}
+}
+
+func TestSpamReportMemDAO_StatusHistory(t *testing.T) {
+	dao := NewSpamReportMemDAO()
+	ctx := context.Background()
+	_ = dao.AddSpamStatusChange(ctx, &models.SpamStatusChange{PhoneNumber: "919123456789", IsSpam: true})
+	_ = dao.AddSpamStatusChange(ctx, &models.SpamStatusChange{PhoneNumber: "919123456789", IsSpam: false})
+	history, err := dao.GetSpamStatusHistory(ctx, "919123456789")
+	if err != nil || len(history) != 2 || !history[0].IsSpam || history[1].IsSpam {
+		t.Errorf("unexpected history %+v (%v)", history, err)
+	}
+	_ = dao.AddSpamStatusChange(ctx, &models.SpamStatusChange{PhoneNumber: "919123456780", IsSpam: true})
+	all, err := dao.ListSpamStatusChanges(ctx)
+	if err != nil || len(all) != 3 || all[0].PhoneNumber != "919123456780" || !all[1].IsSpam || all[2].IsSpam {
+		t.Errorf("expected every change by phone number, oldest first, got %+v (%v)", all, err)
+	}
+}
+
+func TestSpamReportMemDAO_ContextCanceled(t *testing.T) {
+	dao := NewSpamReportMemDAO()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := dao.CreateSpamReport(ctx, &models.SpamReport{ReporterPhoneNumber: "919876543210", PhoneNumber: "919123456789"}); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestSpamReportMemDAO_DeleteSpamReportsByReporter(t *testing.T) {
+	dao := NewSpamReportMemDAO()
+	ctx := context.Background()
+	_ = dao.CreateSpamReport(ctx, &models.SpamReport{ReporterPhoneNumber: "919876543210", PhoneNumber: "919123456789"})
+	_ = dao.CreateSpamReport(ctx, &models.SpamReport{ReporterPhoneNumber: "919876543211", PhoneNumber: "919123456789"})
+	if err := dao.DeleteSpamReportsByReporter(ctx, "919876543210"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reports, _ := dao.GetSpamReportsByReporter(ctx, "919876543210"); len(reports) != 0 {
+		t.Errorf("expected no reports by deleted reporter, got %+v", reports)
+	}
+	reports, _ := dao.GetSpamReportsByPhoneNumber(ctx, "919123456789")
+	if len(reports) != 1 || reports[0].GetReporterPhoneNumber() != "919876543211" {
+		t.Errorf("expected only the other reporter's report to remain, got %+v", reports)
+	}
+}
@@ -89,5 +89,53 @@ func (dao *UserMemDAO) UpdateSpamStatus(ctx context.Context, phoneNumber string,
 	return nil
 }
 
+// UpdateName sets the crowd-sourced name of a user, keeping their display name and spam status.
+func (dao *UserMemDAO) UpdateName(ctx context.Context, phoneNumber, name string) error {
+	return dao.updateNames(ctx, phoneNumber, func(u *models.User) { u.Name = name })
+}
+
+// UpdateDisplayName sets the display name of a user, keeping their crowd-sourced name and spam status.
+func (dao *UserMemDAO) UpdateDisplayName(ctx context.Context, phoneNumber, displayName string) error {
+	return dao.updateNames(ctx, phoneNumber, func(u *models.User) { u.DisplayName = displayName })
+}
+
+// updateNames applies update to a copy of the user (a new one if none is stored) under the write lock, then
+// stores it, or removes the user if it has neither a name nor a display name and is not flagged as spam.
+func (dao *UserMemDAO) updateNames(ctx context.Context, phoneNumber string, update func(u *models.User)) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+	user := models.User{PhoneNumber: phoneNumber}
+	if existing, ok := dao.users[phoneNumber]; ok {
+		user = *existing
+	}
+	update(&user)
+	if user.Name == "" && user.DisplayName == "" && !user.IsSpam {
+		delete(dao.users, phoneNumber)
+		return nil
+	}
+	if err := user.Validate(); err != nil {
+		return err
+	}
+	dao.users[phoneNumber] = &user
+	return nil
+}
+
+// DeleteUser removes a user by phone number.
+func (dao *UserMemDAO) DeleteUser(ctx context.Context, phoneNumber string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+	if _, ok := dao.users[phoneNumber]; !ok {
+		return daoerrors.ErrUserNotFound
+	}
+	delete(dao.users, phoneNumber)
+	return nil
+}
+
 // Ensure UserMemDAO implements dao.UserDAO
 var _ dao.UserDAO = (*UserMemDAO)(nil)
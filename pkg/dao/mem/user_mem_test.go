@@ -84,3 +84,83 @@ func TestUserMemDAO_UpdateSpamStatus(t *testing.T) {
 		t.Error("expected IsSpam true, got false")
 	}
 }
+
+func TestUserMemDAO_DeleteUser(t *testing.T) {
+	dao := NewUserMemDAO()
+	ctx := context.Background()
+	_ = dao.CreateOrUpdateUser(ctx, &models.User{PhoneNumber: "919876543210", Name: "Alice"})
+	if err := dao.DeleteUser(ctx, "919876543210"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := dao.GetUserByPhoneNumber(ctx, "919876543210"); !errors.Is(err, daoerrors.ErrUserNotFound) {
+		t.Errorf("expected user not found error, got %v", err)
+	}
+	if err := dao.DeleteUser(ctx, "919876543210"); !errors.Is(err, daoerrors.ErrUserNotFound) {
+		t.Errorf("expected user not found error on second delete, got %v", err)
+	}
+}
+
+func TestUserMemDAO_UpdateNames(t *testing.T) {
+	dao := NewUserMemDAO()
+	ctx := context.Background()
+	phone := "919876543210"
+	if err := dao.UpdateName(ctx, phone, "Alice"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = dao.UpdateSpamStatus(ctx, phone, true)
+	if err := dao.UpdateDisplayName(ctx, phone, "Alice R."); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, _ := dao.GetUserByPhoneNumber(ctx, phone); got.GetName() != "Alice" || got.GetDisplayName() != "Alice R." || !got.GetIsSpam() {
+		t.Errorf("expected every field to be kept, got %+v", got)
+	}
+	if err := dao.UpdateDisplayName(ctx, phone, "   "); err == nil {
+		t.Error("expected validation error, got nil")
+	}
+	_ = dao.UpdateName(ctx, phone, "")
+	if got, _ := dao.GetUserByPhoneNumber(ctx, phone); got.GetName() != "" || got.GetDisplayName() != "Alice R." {
+		t.Errorf("expected a display-name-only user, got %+v", got)
+	}
+	_ = dao.UpdateSpamStatus(ctx, phone, false)
+	_ = dao.UpdateDisplayName(ctx, phone, "")
+	if _, err := dao.GetUserByPhoneNumber(ctx, phone); !errors.Is(err, daoerrors.ErrUserNotFound) {
+		t.Errorf("expected a user without names to be removed, got %v", err)
+	}
+}
+
+func TestUserMemDAO_UpdateNamesKeepsSpam(t *testing.T) {
+	dao := NewUserMemDAO()
+	ctx := context.Background()
+	phone := "919876543210"
+	_ = dao.UpdateName(ctx, phone, "Loan Offers")
+	_ = dao.UpdateSpamStatus(ctx, phone, true)
+	if err := dao.UpdateName(ctx, phone, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := dao.GetUserByPhoneNumber(ctx, phone)
+	if err != nil || !got.GetIsSpam() || got.GetName() != "" {
+		t.Fatalf("expected a nameless spam record, got %+v (%v)", got, err)
+	}
+	if err := dao.UpdateDisplayName(ctx, phone, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, _ := dao.GetUserByPhoneNumber(ctx, phone); !got.GetIsSpam() {
+		t.Errorf("expected clearing the display name to keep the spam status, got %+v", got)
+	}
+}
+
+func TestUserMemDAO_ConcurrentNameUpdates(t *testing.T) {
+	dao := NewUserMemDAO()
+	ctx := context.Background()
+	phone := "919876543210"
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() { defer wg.Done(); _ = dao.UpdateName(ctx, phone, "Alice") }()
+		go func() { defer wg.Done(); _ = dao.UpdateDisplayName(ctx, phone, "Alice R.") }()
+	}
+	wg.Wait()
+	if got, _ := dao.GetUserByPhoneNumber(ctx, phone); got.GetName() != "Alice" || got.GetDisplayName() != "Alice R." {
+		t.Errorf("expected both names to survive concurrent updates, got %+v", got)
+	}
+}
@@ -0,0 +1,60 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+// LookupLogDAOMock is a mock implementation of LookupLogDAO for testing.
+type LookupLogDAOMock struct {
+	OnAddLookup                  func(ctx context.Context, record *models.LookupRecord) error
+	OnGetLookupsByPhoneNumber    func(ctx context.Context, phoneNumber string) ([]*models.LookupRecord, error)
+	OnGetLookupsByRequester      func(ctx context.Context, requesterPhoneNumber string) ([]*models.LookupRecord, error)
+	OnTrimLookups                func(ctx context.Context, phoneNumber string, keep int) error
+	OnDeleteLookupsBefore        func(ctx context.Context, cutoff time.Time) (int, error)
+	OnDeleteLookupsByPhoneNumber func(ctx context.Context, phoneNumber string) error
+}
+
+func (m *LookupLogDAOMock) AddLookup(ctx context.Context, record *models.LookupRecord) error {
+	if m.OnAddLookup != nil {
+		return m.OnAddLookup(ctx, record)
+	}
+	return nil
+}
+
+func (m *LookupLogDAOMock) GetLookupsByPhoneNumber(ctx context.Context, phoneNumber string) ([]*models.LookupRecord, error) {
+	if m.OnGetLookupsByPhoneNumber != nil {
+		return m.OnGetLookupsByPhoneNumber(ctx, phoneNumber)
+	}
+	return nil, nil
+}
+
+func (m *LookupLogDAOMock) GetLookupsByRequester(ctx context.Context, requesterPhoneNumber string) ([]*models.LookupRecord, error) {
+	if m.OnGetLookupsByRequester != nil {
+		return m.OnGetLookupsByRequester(ctx, requesterPhoneNumber)
+	}
+	return nil, nil
+}
+
+func (m *LookupLogDAOMock) TrimLookups(ctx context.Context, phoneNumber string, keep int) error {
+	if m.OnTrimLookups != nil {
+		return m.OnTrimLookups(ctx, phoneNumber, keep)
+	}
+	return nil
+}
+
+func (m *LookupLogDAOMock) DeleteLookupsBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	if m.OnDeleteLookupsBefore != nil {
+		return m.OnDeleteLookupsBefore(ctx, cutoff)
+	}
+	return 0, nil
+}
+
+func (m *LookupLogDAOMock) DeleteLookupsByPhoneNumber(ctx context.Context, phoneNumber string) error {
+	if m.OnDeleteLookupsByPhoneNumber != nil {
+		return m.OnDeleteLookupsByPhoneNumber(ctx, phoneNumber)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+// ModerationDAOMock is a mock implementation of ModerationDAO for testing.
+type ModerationDAOMock struct {
+	OnSaveNameModeration   func(ctx context.Context, moderation *models.NameModeration) error
+	OnGetNameModeration    func(ctx context.Context, phoneNumber string) (*models.NameModeration, error)
+	OnListNameModerations  func(ctx context.Context) ([]*models.NameModeration, error)
+	OnDeleteNameModeration func(ctx context.Context, phoneNumber string) error
+}
+
+func (m *ModerationDAOMock) SaveNameModeration(ctx context.Context, moderation *models.NameModeration) error {
+	if m.OnSaveNameModeration != nil {
+		return m.OnSaveNameModeration(ctx, moderation)
+	}
+	return nil
+}
+
+func (m *ModerationDAOMock) GetNameModeration(ctx context.Context, phoneNumber string) (*models.NameModeration, error) {
+	if m.OnGetNameModeration != nil {
+		return m.OnGetNameModeration(ctx, phoneNumber)
+	}
+	return nil, nil
+}
+
+func (m *ModerationDAOMock) ListNameModerations(ctx context.Context) ([]*models.NameModeration, error) {
+	if m.OnListNameModerations != nil {
+		return m.OnListNameModerations(ctx)
+	}
+	return nil, nil
+}
+
+func (m *ModerationDAOMock) DeleteNameModeration(ctx context.Context, phoneNumber string) error {
+	if m.OnDeleteNameModeration != nil {
+		return m.OnDeleteNameModeration(ctx, phoneNumber)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+// OffenderDAOMock is a mock implementation of OffenderDAO for testing.
+type OffenderDAOMock struct {
+	OnSaveOffender  func(ctx context.Context, offender *models.Offender) error
+	OnGetOffender   func(ctx context.Context, clientKey string) (*models.Offender, error)
+	OnListOffenders func(ctx context.Context) ([]*models.Offender, error)
+}
+
+func (m *OffenderDAOMock) SaveOffender(ctx context.Context, offender *models.Offender) error {
+	if m.OnSaveOffender != nil {
+		return m.OnSaveOffender(ctx, offender)
+	}
+	return nil
+}
+
+func (m *OffenderDAOMock) GetOffender(ctx context.Context, clientKey string) (*models.Offender, error) {
+	if m.OnGetOffender != nil {
+		return m.OnGetOffender(ctx, clientKey)
+	}
+	return nil, nil
+}
+
+func (m *OffenderDAOMock) ListOffenders(ctx context.Context) ([]*models.Offender, error) {
+	if m.OnListOffenders != nil {
+		return m.OnListOffenders(ctx)
+	}
+	return nil, nil
+}
@@ -0,0 +1,35 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+// OTPDAOMock is a mock implementation of OTPDAO for testing.
+type OTPDAOMock struct {
+	OnSaveChallenge   func(ctx context.Context, challenge *models.OTPChallenge) error
+	OnGetChallenge    func(ctx context.Context, phoneNumber string) (*models.OTPChallenge, error)
+	OnDeleteChallenge func(ctx context.Context, phoneNumber string) error
+}
+
+func (m *OTPDAOMock) SaveChallenge(ctx context.Context, challenge *models.OTPChallenge) error {
+	if m.OnSaveChallenge != nil {
+		return m.OnSaveChallenge(ctx, challenge)
+	}
+	return nil
+}
+
+func (m *OTPDAOMock) GetChallenge(ctx context.Context, phoneNumber string) (*models.OTPChallenge, error) {
+	if m.OnGetChallenge != nil {
+		return m.OnGetChallenge(ctx, phoneNumber)
+	}
+	return nil, nil
+}
+
+func (m *OTPDAOMock) DeleteChallenge(ctx context.Context, phoneNumber string) error {
+	if m.OnDeleteChallenge != nil {
+		return m.OnDeleteChallenge(ctx, phoneNumber)
+	}
+	return nil
+}
@@ -8,8 +8,12 @@ import (
 
 // PhoneBookDAOMock is a mock implementation of PhoneBookDAO for testing.
 type PhoneBookDAOMock struct {
-	OnCreateOrUpdatePhoneBook       func(ctx context.Context, phoneBook *models.PhoneBook) error
-	OnGetPhoneBookByUserPhoneNumber func(ctx context.Context, phoneNumber string) (*models.PhoneBook, error)
+	OnCreateOrUpdatePhoneBook        func(ctx context.Context, phoneBook *models.PhoneBook) error
+	OnGetPhoneBookByUserPhoneNumber  func(ctx context.Context, phoneNumber string) (*models.PhoneBook, error)
+	OnUpsertContacts                 func(ctx context.Context, ownerPhoneNumber string, contacts []models.Contact) error
+	OnGetContactEntriesByPhoneNumber func(ctx context.Context, phoneNumber string) ([]models.ContactEntry, error)
+	OnListPhoneBooks                 func(ctx context.Context) ([]*models.PhoneBook, error)
+	OnDeletePhoneBook                func(ctx context.Context, ownerPhoneNumber string) error
 }
 
 func (m *PhoneBookDAOMock) CreateOrUpdatePhoneBook(ctx context.Context, phoneBook *models.PhoneBook) error {
@@ -25,3 +29,31 @@ func (m *PhoneBookDAOMock) GetPhoneBookByUserPhoneNumber(ctx context.Context, ph
 	}
 	return nil, nil
 }
+
+func (m *PhoneBookDAOMock) UpsertContacts(ctx context.Context, ownerPhoneNumber string, contacts []models.Contact) error {
+	if m.OnUpsertContacts != nil {
+		return m.OnUpsertContacts(ctx, ownerPhoneNumber, contacts)
+	}
+	return nil
+}
+
+func (m *PhoneBookDAOMock) GetContactEntriesByPhoneNumber(ctx context.Context, phoneNumber string) ([]models.ContactEntry, error) {
+	if m.OnGetContactEntriesByPhoneNumber != nil {
+		return m.OnGetContactEntriesByPhoneNumber(ctx, phoneNumber)
+	}
+	return nil, nil
+}
+
+func (m *PhoneBookDAOMock) ListPhoneBooks(ctx context.Context) ([]*models.PhoneBook, error) {
+	if m.OnListPhoneBooks != nil {
+		return m.OnListPhoneBooks(ctx)
+	}
+	return nil, nil
+}
+
+func (m *PhoneBookDAOMock) DeletePhoneBook(ctx context.Context, ownerPhoneNumber string) error {
+	if m.OnDeletePhoneBook != nil {
+		return m.OnDeletePhoneBook(ctx, ownerPhoneNumber)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+// PrivacyDAOMock is a mock implementation of PrivacyDAO for testing.
+type PrivacyDAOMock struct {
+	OnCreateTombstone        func(ctx context.Context, phoneNumber string, erasedAt time.Time) error
+	OnDeleteTombstonesBefore func(ctx context.Context, cutoff time.Time) (int, error)
+	OnIsTombstoned           func(ctx context.Context, phoneNumber string) (bool, error)
+	OnSetUnlisted            func(ctx context.Context, phoneNumber string, unlisted bool) error
+	OnIsUnlisted             func(ctx context.Context, phoneNumber string) (bool, error)
+
+	OnSetLookupLogOptOut func(ctx context.Context, phoneNumber string, optOut bool) error
+	OnIsLookupLogOptOut  func(ctx context.Context, phoneNumber string) (bool, error)
+
+	OnListPrivacySettings func(ctx context.Context) ([]*models.PrivacySettings, error)
+}
+
+func (m *PrivacyDAOMock) CreateTombstone(ctx context.Context, phoneNumber string, erasedAt time.Time) error {
+	if m.OnCreateTombstone != nil {
+		return m.OnCreateTombstone(ctx, phoneNumber, erasedAt)
+	}
+	return nil
+}
+
+func (m *PrivacyDAOMock) DeleteTombstonesBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	if m.OnDeleteTombstonesBefore != nil {
+		return m.OnDeleteTombstonesBefore(ctx, cutoff)
+	}
+	return 0, nil
+}
+
+func (m *PrivacyDAOMock) IsTombstoned(ctx context.Context, phoneNumber string) (bool, error) {
+	if m.OnIsTombstoned != nil {
+		return m.OnIsTombstoned(ctx, phoneNumber)
+	}
+	return false, nil
+}
+
+func (m *PrivacyDAOMock) SetUnlisted(ctx context.Context, phoneNumber string, unlisted bool) error {
+	if m.OnSetUnlisted != nil {
+		return m.OnSetUnlisted(ctx, phoneNumber, unlisted)
+	}
+	return nil
+}
+
+func (m *PrivacyDAOMock) IsUnlisted(ctx context.Context, phoneNumber string) (bool, error) {
+	if m.OnIsUnlisted != nil {
+		return m.OnIsUnlisted(ctx, phoneNumber)
+	}
+	return false, nil
+}
+
+func (m *PrivacyDAOMock) SetLookupLogOptOut(ctx context.Context, phoneNumber string, optOut bool) error {
+	if m.OnSetLookupLogOptOut != nil {
+		return m.OnSetLookupLogOptOut(ctx, phoneNumber, optOut)
+	}
+	return nil
+}
+
+func (m *PrivacyDAOMock) IsLookupLogOptOut(ctx context.Context, phoneNumber string) (bool, error) {
+	if m.OnIsLookupLogOptOut != nil {
+		return m.OnIsLookupLogOptOut(ctx, phoneNumber)
+	}
+	return false, nil
+}
+
+func (m *PrivacyDAOMock) ListPrivacySettings(ctx context.Context) ([]*models.PrivacySettings, error) {
+	if m.OnListPrivacySettings != nil {
+		return m.OnListPrivacySettings(ctx)
+	}
+	return nil, nil
+}
@@ -33,3 +33,12 @@ func (m *SpamUserDAOMock) CreateOrUpdateUser(ctx context.Context, user *models.U
 func (m *SpamUserDAOMock) GetUserByPhoneNumber(ctx context.Context, phoneNumber string) (*models.User, error) {
 	return nil, nil
 }
+func (m *SpamUserDAOMock) UpdateName(ctx context.Context, phoneNumber, name string) error {
+	return nil
+}
+func (m *SpamUserDAOMock) UpdateDisplayName(ctx context.Context, phoneNumber, displayName string) error {
+	return nil
+}
+func (m *SpamUserDAOMock) DeleteUser(ctx context.Context, phoneNumber string) error {
+	return nil
+}
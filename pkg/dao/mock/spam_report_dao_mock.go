@@ -0,0 +1,75 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+// SpamReportDAOMock is a mock implementation of SpamReportDAO for testing.
+type SpamReportDAOMock struct {
+	OnCreateSpamReport            func(ctx context.Context, report *models.SpamReport) error
+	OnGetSpamReportsByReporter    func(ctx context.Context, reporterPhoneNumber string) ([]*models.SpamReport, error)
+	OnGetSpamReportsByPhoneNumber func(ctx context.Context, phoneNumber string) ([]*models.SpamReport, error)
+	OnListSpamReports             func(ctx context.Context) ([]*models.SpamReport, error)
+	OnAddSpamStatusChange         func(ctx context.Context, change *models.SpamStatusChange) error
+	OnGetSpamStatusHistory        func(ctx context.Context, phoneNumber string) ([]*models.SpamStatusChange, error)
+	OnListSpamStatusChanges       func(ctx context.Context) ([]*models.SpamStatusChange, error)
+	OnDeleteSpamReportsByReporter func(ctx context.Context, reporterPhoneNumber string) error
+}
+
+func (m *SpamReportDAOMock) CreateSpamReport(ctx context.Context, report *models.SpamReport) error {
+	if m.OnCreateSpamReport != nil {
+		return m.OnCreateSpamReport(ctx, report)
+	}
+	return nil
+}
+
+func (m *SpamReportDAOMock) GetSpamReportsByReporter(ctx context.Context, reporterPhoneNumber string) ([]*models.SpamReport, error) {
+	if m.OnGetSpamReportsByReporter != nil {
+		return m.OnGetSpamReportsByReporter(ctx, reporterPhoneNumber)
+	}
+	return nil, nil
+}
+
+func (m *SpamReportDAOMock) GetSpamReportsByPhoneNumber(ctx context.Context, phoneNumber string) ([]*models.SpamReport, error) {
+	if m.OnGetSpamReportsByPhoneNumber != nil {
+		return m.OnGetSpamReportsByPhoneNumber(ctx, phoneNumber)
+	}
+	return nil, nil
+}
+
+func (m *SpamReportDAOMock) ListSpamReports(ctx context.Context) ([]*models.SpamReport, error) {
+	if m.OnListSpamReports != nil {
+		return m.OnListSpamReports(ctx)
+	}
+	return nil, nil
+}
+
+func (m *SpamReportDAOMock) AddSpamStatusChange(ctx context.Context, change *models.SpamStatusChange) error {
+	if m.OnAddSpamStatusChange != nil {
+		return m.OnAddSpamStatusChange(ctx, change)
+	}
+	return nil
+}
+
+func (m *SpamReportDAOMock) GetSpamStatusHistory(ctx context.Context, phoneNumber string) ([]*models.SpamStatusChange, error) {
+	if m.OnGetSpamStatusHistory != nil {
+		return m.OnGetSpamStatusHistory(ctx, phoneNumber)
+	}
+	return nil, nil
+}
+
+func (m *SpamReportDAOMock) ListSpamStatusChanges(ctx context.Context) ([]*models.SpamStatusChange, error) {
+	if m.OnListSpamStatusChanges != nil {
+		return m.OnListSpamStatusChanges(ctx)
+	}
+	return nil, nil
+}
+
+func (m *SpamReportDAOMock) DeleteSpamReportsByReporter(ctx context.Context, reporterPhoneNumber string) error {
+	if m.OnDeleteSpamReportsByReporter != nil {
+		return m.OnDeleteSpamReportsByReporter(ctx, reporterPhoneNumber)
+	}
+	return nil
+}
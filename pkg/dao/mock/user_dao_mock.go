@@ -12,6 +12,9 @@ type UserDAOMock struct {
 	OnGetUserByPhoneNumber func(ctx context.Context, phoneNumber string) (*models.User, error)
 	OnGetAllUsers          func(ctx context.Context) ([]*models.User, error)
 	OnUpdateSpamStatus     func(ctx context.Context, phoneNumber string, isSpam bool) error
+	OnUpdateName           func(ctx context.Context, phoneNumber, name string) error
+	OnUpdateDisplayName    func(ctx context.Context, phoneNumber, displayName string) error
+	OnDeleteUser           func(ctx context.Context, phoneNumber string) error
 }
 
 func (m *UserDAOMock) CreateOrUpdateUser(ctx context.Context, user *models.User) error {
@@ -41,3 +44,24 @@ func (m *UserDAOMock) UpdateSpamStatus(ctx context.Context, phoneNumber string,
 	}
 	return nil
 }
+
+func (m *UserDAOMock) UpdateName(ctx context.Context, phoneNumber, name string) error {
+	if m.OnUpdateName != nil {
+		return m.OnUpdateName(ctx, phoneNumber, name)
+	}
+	return nil
+}
+
+func (m *UserDAOMock) UpdateDisplayName(ctx context.Context, phoneNumber, displayName string) error {
+	if m.OnUpdateDisplayName != nil {
+		return m.OnUpdateDisplayName(ctx, phoneNumber, displayName)
+	}
+	return nil
+}
+
+func (m *UserDAOMock) DeleteUser(ctx context.Context, phoneNumber string) error {
+	if m.OnDeleteUser != nil {
+		return m.OnDeleteUser(ctx, phoneNumber)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+// ModerationDAO defines the data access contract for numbers whose crowd-sourced names were suppressed by moderation.
+// All methods accept a context for timeouts and cancellations, and return errors for data access or validation failures.
+type ModerationDAO interface {
+	// SaveNameModeration creates or replaces the moderation state of moderation.PhoneNumber.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   moderation: the state to store (PhoneNumber must not be empty)
+	// Returns:
+	//   error: if validation fails or storage error occurs
+	// Example:
+	//   err := dao.SaveNameModeration(ctx, &models.NameModeration{PhoneNumber: "919876543210", Suppressed: suppressed})
+	SaveNameModeration(ctx context.Context, moderation *models.NameModeration) error
+
+	// GetNameModeration retrieves the moderation state of a phone number.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   phoneNumber: the phone number
+	// Returns:
+	//   *models.NameModeration: the state, or nil if no name is suppressed for the number
+	//   error: if storage error occurs
+	// Example:
+	//   moderation, err := dao.GetNameModeration(ctx, "919876543210")
+	GetNameModeration(ctx context.Context, phoneNumber string) (*models.NameModeration, error)
+
+	// ListNameModerations returns every number with suppressed names, most recently updated first.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	// Returns:
+	//   moderations: all states (empty if none)
+	//   error: if storage error occurs
+	// Example:
+	//   moderations, err := dao.ListNameModerations(ctx)
+	ListNameModerations(ctx context.Context) ([]*models.NameModeration, error)
+
+	// DeleteNameModeration removes the moderation state of a phone number. Deleting a missing state is not an error.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   phoneNumber: the phone number
+	// Returns:
+	//   error: if storage error occurs
+	// Example:
+	//   err := dao.DeleteNameModeration(ctx, "919876543210")
+	DeleteNameModeration(ctx context.Context, phoneNumber string) error
+}
+
+// Error handling pattern: All methods return error for not found, validation, or storage errors. Use errors.Is for type checks.
@@ -0,0 +1,44 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+// OffenderDAO defines the data access contract for lookup clients flagged for number enumeration.
+// All methods accept a context for timeouts and cancellations, and return errors for data access or validation failures.
+type OffenderDAO interface {
+	// SaveOffender creates or replaces the record for offender.ClientKey.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   offender: the offender to store (ClientKey must not be empty)
+	// Returns:
+	//   error: if validation fails or storage error occurs
+	// Example:
+	//   err := dao.SaveOffender(ctx, &models.Offender{ClientKey: "203.0.113.7", Action: models.ActionThrottle})
+	SaveOffender(ctx context.Context, offender *models.Offender) error
+
+	// GetOffender retrieves the record for a client.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   clientKey: the client key
+	// Returns:
+	//   *models.Offender: the offender, or nil if the client was never flagged
+	//   error: if storage error occurs
+	// Example:
+	//   offender, err := dao.GetOffender(ctx, "203.0.113.7")
+	GetOffender(ctx context.Context, clientKey string) (*models.Offender, error)
+
+	// ListOffenders returns all flagged clients, most recently flagged first.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	// Returns:
+	//   offenders: all offenders (empty if none)
+	//   error: if storage error occurs
+	// Example:
+	//   offenders, err := dao.ListOffenders(ctx)
+	ListOffenders(ctx context.Context) ([]*models.Offender, error)
+}
+
+// Error handling pattern: All methods return error for not found, validation, or storage errors. Use errors.Is for type checks.
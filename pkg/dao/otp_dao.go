@@ -0,0 +1,44 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+// OTPDAO defines the data access contract for pending one-time password challenges, one per phone number.
+// All methods accept a context for timeouts and cancellations, and return errors for data access or validation failures.
+type OTPDAO interface {
+	// SaveChallenge creates or replaces the challenge for challenge.PhoneNumber.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   challenge: the challenge to store (phone number must be 12 digits, starts with 91)
+	// Returns:
+	//   error: if validation fails or storage error occurs
+	// Example:
+	//   err := dao.SaveChallenge(ctx, &models.OTPChallenge{PhoneNumber: "919876543210", ...})
+	SaveChallenge(ctx context.Context, challenge *models.OTPChallenge) error
+
+	// GetChallenge retrieves the pending challenge for a phone number.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   phoneNumber: the phone number being verified
+	// Returns:
+	//   *models.OTPChallenge: the challenge if found
+	//   error: daoerrors.ErrOTPChallengeNotFound if there is none, or storage error
+	// Example:
+	//   challenge, err := dao.GetChallenge(ctx, "919876543210")
+	GetChallenge(ctx context.Context, phoneNumber string) (*models.OTPChallenge, error)
+
+	// DeleteChallenge removes the pending challenge for a phone number. Deleting a missing challenge is not an error.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   phoneNumber: the phone number being verified
+	// Returns:
+	//   error: if storage error occurs
+	// Example:
+	//   err := dao.DeleteChallenge(ctx, "919876543210")
+	DeleteChallenge(ctx context.Context, phoneNumber string) error
+}
+
+// Error handling pattern: All methods return error for not found, validation, or storage errors. Use errors.Is for type checks.
@@ -29,6 +29,51 @@ type PhoneBookDAO interface {
 	// Example:
 	//   pb, err := dao.GetPhoneBookByUserPhoneNumber(ctx, "919876543210")
 	GetPhoneBookByUserPhoneNumber(ctx context.Context, phoneNumber string) (*models.PhoneBook, error)
+
+	// UpsertContacts merges contacts into the owner's phone book, creating it if needed.
+	// Contacts with a phone number already in the phone book replace the existing entry (latest write wins);
+	// other contacts are appended.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   ownerPhoneNumber: the owner's phone number (must be 12 digits, starts with 91)
+	//   contacts: the contacts to merge
+	// Returns:
+	//   error: if validation fails or storage error occurs
+	// Example:
+	//   err := dao.UpsertContacts(ctx, "919876543210", []models.Contact{{PhoneNumber: "919123456789", Name: "Bob"}})
+	UpsertContacts(ctx context.Context, ownerPhoneNumber string, contacts []models.Contact) error
+
+	// GetContactEntriesByPhoneNumber returns every saved copy of a contact across all phone books (reverse lookup),
+	// ordered by owner phone number. Each entry records when the owner last saved the contact.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   phoneNumber: the contact's phone number
+	// Returns:
+	//   entries: one entry per phone book holding the contact (empty if none)
+	//   error: if storage error occurs
+	// Example:
+	//   entries, err := dao.GetContactEntriesByPhoneNumber(ctx, "919123456789")
+	GetContactEntriesByPhoneNumber(ctx context.Context, phoneNumber string) ([]models.ContactEntry, error)
+
+	// ListPhoneBooks returns every stored phone book, ordered by owner phone number.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	// Returns:
+	//   phoneBooks: all phone books (empty if none)
+	//   error: if storage error occurs
+	// Example:
+	//   phoneBooks, err := dao.ListPhoneBooks(ctx)
+	ListPhoneBooks(ctx context.Context) ([]*models.PhoneBook, error)
+
+	// DeletePhoneBook removes the owner's phone book, including it from reverse lookups.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   ownerPhoneNumber: the owner's phone number
+	// Returns:
+	//   error: if not found or storage error occurs
+	// Example:
+	//   err := dao.DeletePhoneBook(ctx, "919876543210")
+	DeletePhoneBook(ctx context.Context, ownerPhoneNumber string) error
 }
 
 // Error handling pattern: All methods return error for not found, validation, or storage errors. Use errors.Is for type checks.
@@ -0,0 +1,103 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+// PrivacyDAO defines the data access contract for per-number privacy state, such as erasure tombstones and unlisting.
+// All methods accept a context for timeouts and cancellations, and return errors for data access or validation failures.
+type PrivacyDAO interface {
+	// CreateTombstone marks a phone number as erased at erasedAt. Creating an existing tombstone is not an error;
+	// it keeps the later of both erasure times.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   phoneNumber: the erased phone number (must be 12 digits, starts with 91)
+	//   erasedAt: when the number was erased
+	// Returns:
+	//   error: if validation fails or storage error occurs
+	// Example:
+	//   err := dao.CreateTombstone(ctx, "919876543210", time.Now())
+	CreateTombstone(ctx context.Context, phoneNumber string, erasedAt time.Time) error
+
+	// DeleteTombstonesBefore removes the tombstones of numbers erased before cutoff.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   cutoff: tombstones created earlier than this are removed
+	// Returns:
+	//   deleted: the number of tombstones removed
+	//   error: if storage error occurs
+	// Example:
+	//   deleted, err := dao.DeleteTombstonesBefore(ctx, time.Now().Add(-365*24*time.Hour))
+	DeleteTombstonesBefore(ctx context.Context, cutoff time.Time) (int, error)
+
+	// IsTombstoned reports whether a phone number has been erased.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   phoneNumber: the phone number
+	// Returns:
+	//   tombstoned: true if the number has been erased
+	//   error: if storage error occurs
+	// Example:
+	//   erased, err := dao.IsTombstoned(ctx, "919876543210")
+	IsTombstoned(ctx context.Context, phoneNumber string) (bool, error)
+
+	// SetUnlisted sets whether a phone number has opted out of caller ID lookups.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   phoneNumber: the phone number (must be 12 digits, starts with 91)
+	//   unlisted: true to opt out, false to opt back in
+	// Returns:
+	//   error: if validation fails or storage error occurs
+	// Example:
+	//   err := dao.SetUnlisted(ctx, "919876543210", true)
+	SetUnlisted(ctx context.Context, phoneNumber string, unlisted bool) error
+
+	// IsUnlisted reports whether a phone number has opted out of caller ID lookups.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   phoneNumber: the phone number
+	// Returns:
+	//   unlisted: true if the number is unlisted
+	//   error: if storage error occurs
+	// Example:
+	//   unlisted, err := dao.IsUnlisted(ctx, "919876543210")
+	IsUnlisted(ctx context.Context, phoneNumber string) (bool, error)
+
+	// SetLookupLogOptOut sets whether a phone number's lookups are hidden from the "who viewed me" lists of
+	// the numbers it looks up.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   phoneNumber: the requester's phone number (must be 12 digits, starts with 91)
+	//   optOut: true to hide, false to show
+	// Returns:
+	//   error: if validation fails or storage error occurs
+	// Example:
+	//   err := dao.SetLookupLogOptOut(ctx, "919876543210", true)
+	SetLookupLogOptOut(ctx context.Context, phoneNumber string, optOut bool) error
+
+	// IsLookupLogOptOut reports whether a phone number's lookups are hidden from "who viewed me" lists.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   phoneNumber: the requester's phone number
+	// Returns:
+	//   optOut: true if hidden
+	//   error: if storage error occurs
+	// Example:
+	//   hidden, err := dao.IsLookupLogOptOut(ctx, "919876543210")
+	IsLookupLogOptOut(ctx context.Context, phoneNumber string) (bool, error)
+
+	// ListPrivacySettings returns the privacy state of every phone number that has any, ordered by phone number.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	// Returns:
+	//   settings: one entry per number that is erased, unlisted or opted out of lookup logs (empty if none)
+	//   error: if storage error occurs
+	// Example:
+	//   settings, err := dao.ListPrivacySettings(ctx)
+	ListPrivacySettings(ctx context.Context) ([]*models.PrivacySettings, error)
+}
+
+// Error handling pattern: All methods return error for not found, validation, or storage errors. Use errors.Is for type checks.
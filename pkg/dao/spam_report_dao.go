@@ -0,0 +1,97 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+// SpamReportDAO defines the data access contract for spam reports and spam status history.
+// All methods accept a context for timeouts and cancellations, and return errors for data access or validation failures.
+type SpamReportDAO interface {
+	// CreateSpamReport stores a spam report.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   report: the report to store
+	// Returns:
+	//   error: if validation fails or storage error occurs
+	// Example:
+	//   err := dao.CreateSpamReport(ctx, &models.SpamReport{ReporterPhoneNumber: "919876543210", PhoneNumber: "919123456789"})
+	CreateSpamReport(ctx context.Context, report *models.SpamReport) error
+
+	// GetSpamReportsByReporter returns the reports filed by a phone number, oldest first.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   reporterPhoneNumber: the reporter's phone number
+	// Returns:
+	//   reports: the reports filed (empty if none)
+	//   error: if storage error occurs
+	// Example:
+	//   reports, err := dao.GetSpamReportsByReporter(ctx, "919876543210")
+	GetSpamReportsByReporter(ctx context.Context, reporterPhoneNumber string) ([]*models.SpamReport, error)
+
+	// GetSpamReportsByPhoneNumber returns the reports filed against a phone number, oldest first.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   phoneNumber: the reported phone number
+	// Returns:
+	//   reports: the reports filed against the number (empty if none)
+	//   error: if storage error occurs
+	// Example:
+	//   reports, err := dao.GetSpamReportsByPhoneNumber(ctx, "919123456789")
+	GetSpamReportsByPhoneNumber(ctx context.Context, phoneNumber string) ([]*models.SpamReport, error)
+
+	// ListSpamReports returns every stored spam report, ordered by reporter phone number, then in filing order.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	// Returns:
+	//   reports: all reports (empty if none)
+	//   error: if storage error occurs
+	// Example:
+	//   reports, err := dao.ListSpamReports(ctx)
+	ListSpamReports(ctx context.Context) ([]*models.SpamReport, error)
+
+	// AddSpamStatusChange appends an entry to a phone number's spam status history.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   change: the status change to record
+	// Returns:
+	//   error: if storage error occurs
+	// Example:
+	//   err := dao.AddSpamStatusChange(ctx, &models.SpamStatusChange{PhoneNumber: "919123456789", IsSpam: true})
+	AddSpamStatusChange(ctx context.Context, change *models.SpamStatusChange) error
+
+	// GetSpamStatusHistory returns a phone number's spam status changes, oldest first.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   phoneNumber: the phone number
+	// Returns:
+	//   changes: the recorded changes (empty if none)
+	//   error: if storage error occurs
+	// Example:
+	//   changes, err := dao.GetSpamStatusHistory(ctx, "919123456789")
+	GetSpamStatusHistory(ctx context.Context, phoneNumber string) ([]*models.SpamStatusChange, error)
+
+	// ListSpamStatusChanges returns the spam status history of every phone number, ordered by phone number, then
+	// oldest first.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	// Returns:
+	//   changes: all recorded changes (empty if none)
+	//   error: if storage error occurs
+	// Example:
+	//   changes, err := dao.ListSpamStatusChanges(ctx)
+	ListSpamStatusChanges(ctx context.Context) ([]*models.SpamStatusChange, error)
+
+	// DeleteSpamReportsByReporter removes every report filed by a phone number.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   reporterPhoneNumber: the reporter's phone number
+	// Returns:
+	//   error: if storage error occurs (no reports is not an error)
+	// Example:
+	//   err := dao.DeleteSpamReportsByReporter(ctx, "919876543210")
+	DeleteSpamReportsByReporter(ctx context.Context, reporterPhoneNumber string) error
+}
+
+// Error handling pattern: All methods return error for not found, validation, or storage errors. Use errors.Is for type checks.
@@ -50,6 +50,42 @@ type UserDAO interface {
 	// Example:
 	//   err := dao.UpdateSpamStatus(ctx, "919876543210", true)
 	UpdateSpamStatus(ctx context.Context, phoneNumber string, isSpam bool) error
+
+	// UpdateName sets the crowd-sourced name of a user in one step, keeping their display name and spam status.
+	// The user is created if needed and removed if it ends up with neither a name nor a display name, unless it is
+	// flagged as spam, so concurrent updates of other fields are never lost and a spam flag outlives the names.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   phoneNumber: the user's phone number
+	//   name: new crowd-sourced name ("" clears it)
+	// Returns:
+	//   error: if validation fails or storage error occurs
+	// Example:
+	//   err := dao.UpdateName(ctx, "919876543210", "Alice")
+	UpdateName(ctx context.Context, phoneNumber, name string) error
+
+	// UpdateDisplayName sets the display name of a user in one step, keeping their crowd-sourced name and spam
+	// status. The user is created if needed and removed if it ends up with neither a name nor a display name,
+	// unless it is flagged as spam.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   phoneNumber: the user's phone number
+	//   displayName: new display name ("" clears it)
+	// Returns:
+	//   error: if validation fails or storage error occurs
+	// Example:
+	//   err := dao.UpdateDisplayName(ctx, "919876543210", "Alice R.")
+	UpdateDisplayName(ctx context.Context, phoneNumber, displayName string) error
+
+	// DeleteUser removes a user by phone number.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   phoneNumber: the user's phone number
+	// Returns:
+	//   error: if user not found or storage error occurs
+	// Example:
+	//   err := dao.DeleteUser(ctx, "919876543210")
+	DeleteUser(ctx context.Context, phoneNumber string) error
 }
 
 // Error handling pattern: All methods return error for not found, validation, or storage errors. Use errors.Is for type checks.
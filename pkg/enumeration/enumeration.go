@@ -0,0 +1,370 @@
+// Package enumeration detects clients that scrape lookups by walking phone number ranges.
+//
+// A Detector keeps a sliding window of each client's recent lookups and flags the client when the window shows
+// a sequential scan, a dense scan of one number prefix, or mostly unknown numbers. A flagged client is first
+// throttled; repeated detections while throttled block it for a while. Every detection is recorded in an
+// OffenderDAO for admin review.
+package enumeration
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+// Defaults applied to zero Options fields.
+const (
+	DefaultWindow           = 10 * time.Minute
+	DefaultMinLookups       = 20
+	DefaultMaxLookups       = 500
+	DefaultSequentialGap    = 10
+	DefaultSequentialRatio  = 0.6
+	DefaultPrefixLength     = 9
+	DefaultDensePrefixCount = 20
+	DefaultNotFoundRatio    = 0.8
+	DefaultThrottleInterval = 10 * time.Second
+	DefaultCooldown         = time.Hour
+	DefaultBlockAfter       = 3
+	DefaultBlockDuration    = 24 * time.Hour
+	DefaultMaxClients       = 100000
+)
+
+// sampleSize is the number of looked-up numbers kept in an offender record.
+const sampleSize = 10
+
+var (
+	// ErrThrottled is matched by a *DeniedError for a throttled client.
+	ErrThrottled = errors.New("lookups throttled: suspected number enumeration")
+	// ErrBlocked is matched by a *DeniedError for a blocked client.
+	ErrBlocked = errors.New("lookups blocked: suspected number enumeration")
+)
+
+// DeniedError reports that a client's lookup was refused and when it may retry.
+// It matches ErrThrottled or ErrBlocked with errors.Is, depending on Action.
+type DeniedError struct {
+	// Action is models.ActionThrottle or models.ActionBlock.
+	Action string
+	// RetryAfter is how long the client must wait before its next lookup is allowed.
+	RetryAfter time.Duration
+}
+
+// Error implements the error interface.
+func (e *DeniedError) Error() string {
+	return fmt.Sprintf("%s: retry after %s", e.sentinel(), e.RetryAfter.Round(time.Second))
+}
+
+// Is reports whether target is the sentinel error for e.Action.
+func (e *DeniedError) Is(target error) bool { return target == e.sentinel() }
+
+func (e *DeniedError) sentinel() error {
+	if e.Action == models.ActionBlock {
+		return ErrBlocked
+	}
+	return ErrThrottled
+}
+
+// Options configures a Detector. Zero fields use the package defaults.
+type Options struct {
+	// Window is how long lookups count towards detection.
+	Window time.Duration
+	// MinLookups is the number of lookups in the window before any signal is evaluated.
+	MinLookups int
+	// MaxLookups bounds the lookups kept per client; older ones are dropped first.
+	MaxLookups int
+	// SequentialGap is the largest numeric distance between consecutive lookups counted as sequential.
+	SequentialGap int64
+	// SequentialRatio is the fraction of consecutive lookup pairs that must be sequential to flag a scan.
+	SequentialRatio float64
+	// PrefixLength is the number of leading digits compared for dense prefix scans.
+	PrefixLength int
+	// DensePrefixCount is the number of distinct numbers with one prefix in the window that flags a scan.
+	DensePrefixCount int
+	// NotFoundRatio is the fraction of lookups for unknown numbers that flags a client.
+	NotFoundRatio float64
+	// ThrottleInterval is the minimum time between lookups of a throttled client.
+	ThrottleInterval time.Duration
+	// Cooldown is how long after its last detection, or the end of its block, a flagged client is released.
+	Cooldown time.Duration
+	// BlockAfter is the number of detections after which a client is blocked rather than throttled.
+	BlockAfter int
+	// BlockDuration is how long a block lasts. The client is then throttled until Cooldown passes, and blocked
+	// again if detected in the meantime.
+	BlockDuration time.Duration
+	// MaxClients bounds the clients tracked; the least recently seen client is forgotten first.
+	MaxClients int
+	// OnError is called when recording an offender fails; log.Printf if nil.
+	OnError func(error)
+	// Now returns the current time; time.Now if nil. Intended for tests.
+	Now func() time.Time
+}
+
+// withDefaults returns a copy of o with zero fields replaced by defaults.
+func (o Options) withDefaults() Options {
+	if o.Window <= 0 {
+		o.Window = DefaultWindow
+	}
+	if o.MinLookups <= 0 {
+		o.MinLookups = DefaultMinLookups
+	}
+	if o.MaxLookups <= 0 {
+		o.MaxLookups = DefaultMaxLookups
+	}
+	if o.SequentialGap <= 0 {
+		o.SequentialGap = DefaultSequentialGap
+	}
+	if o.SequentialRatio <= 0 {
+		o.SequentialRatio = DefaultSequentialRatio
+	}
+	if o.PrefixLength <= 0 {
+		o.PrefixLength = DefaultPrefixLength
+	}
+	if o.DensePrefixCount <= 0 {
+		o.DensePrefixCount = DefaultDensePrefixCount
+	}
+	if o.NotFoundRatio <= 0 {
+		o.NotFoundRatio = DefaultNotFoundRatio
+	}
+	if o.ThrottleInterval <= 0 {
+		o.ThrottleInterval = DefaultThrottleInterval
+	}
+	if o.Cooldown <= 0 {
+		o.Cooldown = DefaultCooldown
+	}
+	if o.BlockAfter <= 0 {
+		o.BlockAfter = DefaultBlockAfter
+	}
+	if o.BlockDuration <= 0 {
+		o.BlockDuration = DefaultBlockDuration
+	}
+	if o.MaxClients <= 0 {
+		o.MaxClients = DefaultMaxClients
+	}
+	if o.OnError == nil {
+		o.OnError = func(err error) { log.Printf("enumeration detector: %v", err) }
+	}
+	if o.Now == nil {
+		o.Now = time.Now
+	}
+	return o
+}
+
+// Detector tracks per-client lookup patterns. It is safe for concurrent use.
+type Detector struct {
+	mu          sync.Mutex
+	opts        Options
+	offenderDAO dao.OffenderDAO
+	clients     map[string]*list.Element // values are *client
+	lru         *list.List               // front: most recently seen
+}
+
+// client is the detection state of one client.
+type client struct {
+	key           string
+	lookups       []lookup
+	strikes       int
+	lastDetection time.Time
+	nextAllowed   time.Time
+	blockedUntil  time.Time
+}
+
+// lookup is one observed lookup.
+type lookup struct {
+	number string
+	found  bool
+	at     time.Time
+}
+
+// NewDetector creates a Detector recording offenders in offenderDAO.
+func NewDetector(offenderDAO dao.OffenderDAO, opts Options) *Detector {
+	return &Detector{opts: opts.withDefaults(), offenderDAO: offenderDAO, clients: make(map[string]*list.Element), lru: list.New()}
+}
+
+// Check reports whether clientKey may perform a lookup now. It returns nil, or a *DeniedError if the client is
+// throttled or blocked. An allowed lookup of a throttled client consumes its throttle slot.
+func (d *Detector) Check(clientKey string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	elem, ok := d.clients[clientKey]
+	if !ok {
+		return nil
+	}
+	c := elem.Value.(*client)
+	now := d.opts.Now()
+	if now.Before(c.blockedUntil) {
+		return &DeniedError{Action: models.ActionBlock, RetryAfter: c.blockedUntil.Sub(now)}
+	}
+	if c.strikes == 0 {
+		return nil
+	}
+	if now.Sub(laterOf(c.lastDetection, c.blockedUntil)) >= d.opts.Cooldown {
+		c.strikes = 0
+		c.blockedUntil = time.Time{}
+		return nil
+	}
+	if now.Before(c.nextAllowed) {
+		return &DeniedError{Action: models.ActionThrottle, RetryAfter: c.nextAllowed.Sub(now)}
+	}
+	c.nextAllowed = now.Add(d.opts.ThrottleInterval)
+	return nil
+}
+
+// Record observes a lookup of phoneNumber by clientKey and whether the number was found, flagging the client
+// if its recent lookups look like enumeration.
+func (d *Detector) Record(ctx context.Context, clientKey, phoneNumber string, found bool) {
+	d.mu.Lock()
+	now := d.opts.Now()
+	c := d.client(clientKey)
+	c.lookups = append(c.lookups, lookup{number: phoneNumber, found: found, at: now})
+	c.lookups = d.prune(c.lookups, now)
+	signals := d.signals(c.lookups)
+	if len(signals) == 0 {
+		d.mu.Unlock()
+		return
+	}
+	samples := make([]string, 0, sampleSize)
+	for i := len(c.lookups) - 1; i >= 0 && len(samples) < sampleSize; i-- {
+		samples = append(samples, c.lookups[i].number)
+	}
+	c.lookups = nil
+	c.strikes++
+	c.lastDetection = now
+	c.nextAllowed = now.Add(d.opts.ThrottleInterval)
+	action := models.ActionThrottle
+	if c.strikes >= d.opts.BlockAfter {
+		action = models.ActionBlock
+		c.blockedUntil = now.Add(d.opts.BlockDuration)
+	}
+	d.mu.Unlock()
+
+	if err := d.recordOffender(ctx, clientKey, signals, action, samples, now); err != nil {
+		d.opts.OnError(err)
+	}
+}
+
+// client returns the state of clientKey, creating it and evicting the least recently seen client if needed.
+// The caller must hold d.mu.
+func (d *Detector) client(clientKey string) *client {
+	if elem, ok := d.clients[clientKey]; ok {
+		d.lru.MoveToFront(elem)
+		return elem.Value.(*client)
+	}
+	c := &client{key: clientKey}
+	d.clients[clientKey] = d.lru.PushFront(c)
+	for d.lru.Len() > d.opts.MaxClients {
+		oldest := d.lru.Back()
+		d.lru.Remove(oldest)
+		delete(d.clients, oldest.Value.(*client).key)
+	}
+	return c
+}
+
+// prune drops lookups outside the window and beyond MaxLookups.
+func (d *Detector) prune(lookups []lookup, now time.Time) []lookup {
+	start := 0
+	for start < len(lookups) && now.Sub(lookups[start].at) >= d.opts.Window {
+		start++
+	}
+	if len(lookups)-start > d.opts.MaxLookups {
+		start = len(lookups) - d.opts.MaxLookups
+	}
+	return lookups[start:]
+}
+
+// signals returns the enumeration signals shown by lookups.
+func (d *Detector) signals(lookups []lookup) []string {
+	if len(lookups) < d.opts.MinLookups {
+		return nil
+	}
+	var signals []string
+	sequential, notFound := 0, 0
+	prefixes := make(map[string]map[string]struct{})
+	densest := 0
+	for i, l := range lookups {
+		if !l.found {
+			notFound++
+		}
+		if i > 0 && isSequential(lookups[i-1].number, l.number, d.opts.SequentialGap) {
+			sequential++
+		}
+		if len(l.number) > d.opts.PrefixLength {
+			prefix := l.number[:d.opts.PrefixLength]
+			if prefixes[prefix] == nil {
+				prefixes[prefix] = make(map[string]struct{})
+			}
+			prefixes[prefix][l.number] = struct{}{}
+			densest = max(densest, len(prefixes[prefix]))
+		}
+	}
+	if float64(sequential) >= d.opts.SequentialRatio*float64(len(lookups)-1) {
+		signals = append(signals, models.SignalSequential)
+	}
+	if densest >= d.opts.DensePrefixCount {
+		signals = append(signals, models.SignalDensePrefix)
+	}
+	if float64(notFound) >= d.opts.NotFoundRatio*float64(len(lookups)) {
+		signals = append(signals, models.SignalNotFound)
+	}
+	return signals
+}
+
+// laterOf returns the later of two times.
+func laterOf(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+// isSequential reports whether two numbers differ by at least 1 and at most gap.
+func isSequential(a, b string, gap int64) bool {
+	x, errA := strconv.ParseInt(a, 10, 64)
+	y, errB := strconv.ParseInt(b, 10, 64)
+	if errA != nil || errB != nil || x == y {
+		return false
+	}
+	diff := y - x
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff <= gap
+}
+
+// recordOffender merges a detection into the client's offender record.
+func (d *Detector) recordOffender(ctx context.Context, clientKey string, signals []string, action string, samples []string, now time.Time) error {
+	offender, err := d.offenderDAO.GetOffender(ctx, clientKey)
+	if err != nil {
+		return err
+	}
+	if offender == nil {
+		offender = &models.Offender{ClientKey: clientKey, FirstSeen: now}
+	}
+	for _, s := range signals {
+		offender.Signals = appendUnique(offender.Signals, s)
+	}
+	if offender.Action != models.ActionBlock {
+		offender.Action = action
+	}
+	offender.Detections++
+	offender.LastSeen = now
+	offender.SampleNumbers = samples
+	return d.offenderDAO.SaveOffender(ctx, offender)
+}
+
+// appendUnique appends s to list, moving it to the end if already present.
+func appendUnique(list []string, s string) []string {
+	for i, existing := range list {
+		if existing == s {
+			list = append(list[:i], list[i+1:]...)
+			break
+		}
+	}
+	return append(list, s)
+}
@@ -0,0 +1,152 @@
+package enumeration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao/mem"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+// testClock is a manually advanced clock.
+type testClock struct{ now time.Time }
+
+func (c *testClock) Now() time.Time          { return c.now }
+func (c *testClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestDetector(opts Options) (*Detector, *mem.OffenderMemDAO, *testClock) {
+	clock := &testClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	opts.Now = clock.Now
+	offenders := mem.NewOffenderMemDAO()
+	return NewDetector(offenders, opts), offenders, clock
+}
+
+func TestDetector_Signals(t *testing.T) {
+	tests := []struct {
+		name       string
+		number     func(i int) string
+		found      func(i int) bool
+		wantSignal string
+	}{
+		{
+			name:       "sequential scan",
+			number:     func(i int) string { return fmt.Sprintf("9198765%05d", 3*i) },
+			found:      func(i int) bool { return true },
+			wantSignal: models.SignalSequential,
+		},
+		{
+			name:       "dense prefix scan",
+			number:     func(i int) string { return fmt.Sprintf("919876543%03d", (i*137)%1000) },
+			found:      func(i int) bool { return true },
+			wantSignal: models.SignalDensePrefix,
+		},
+		{
+			name:       "mostly unknown numbers",
+			number:     func(i int) string { return fmt.Sprintf("91%010d", int64(i)*7919001) },
+			found:      func(i int) bool { return i%10 == 0 },
+			wantSignal: models.SignalNotFound,
+		},
+		{
+			name:   "normal usage",
+			number: func(i int) string { return fmt.Sprintf("91%010d", int64(i%5)*7919001) },
+			found:  func(i int) bool { return true },
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d, offenders, clock := newTestDetector(Options{})
+			ctx := context.Background()
+			for i := 0; i < DefaultMinLookups; i++ {
+				if err := d.Check("client"); err != nil {
+					t.Fatalf("lookup %d: unexpected error: %v", i, err)
+				}
+				d.Record(ctx, "client", tc.number(i), tc.found(i))
+				clock.Advance(time.Second)
+			}
+			offender, _ := offenders.GetOffender(ctx, "client")
+			err := d.Check("client")
+			if tc.wantSignal == "" {
+				if offender != nil || err != nil {
+					t.Errorf("expected client not to be flagged, got %+v (%v)", offender, err)
+				}
+				return
+			}
+			if offender == nil || offender.Action != models.ActionThrottle || !contains(offender.Signals, tc.wantSignal) {
+				t.Fatalf("expected throttled offender with %s, got %+v", tc.wantSignal, offender)
+			}
+			if !errors.Is(err, ErrThrottled) {
+				t.Errorf("expected ErrThrottled, got %v", err)
+			}
+		})
+	}
+}
+
+func TestDetector_Escalation(t *testing.T) {
+	d, offenders, clock := newTestDetector(Options{MinLookups: 5, BlockAfter: 2, ThrottleInterval: time.Second, BlockDuration: time.Hour, Cooldown: 2 * time.Hour})
+	ctx := context.Background()
+	next := 0
+	scan := func() {
+		for i := 0; i < 5; i++ {
+			clock.Advance(time.Second)
+			if err := d.Check("client"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			d.Record(ctx, "client", fmt.Sprintf("9198765%05d", next), false)
+			next++
+		}
+	}
+
+	scan()
+	err := d.Check("client")
+	var denied *DeniedError
+	if !errors.As(err, &denied) || denied.Action != models.ActionThrottle || denied.RetryAfter != time.Second {
+		t.Fatalf("expected throttle for 1s, got %v", err)
+	}
+	scan()
+	if err := d.Check("client"); !errors.Is(err, ErrBlocked) {
+		t.Fatalf("expected ErrBlocked after repeated detection, got %v", err)
+	}
+	offender, _ := offenders.GetOffender(ctx, "client")
+	if offender == nil || offender.Action != models.ActionBlock || offender.Detections != 2 || len(offender.SampleNumbers) != 5 {
+		t.Errorf("unexpected offender record %+v", offender)
+	}
+
+	clock.Advance(time.Hour)
+	if err := d.Check("client"); err != nil {
+		t.Errorf("expected a throttled lookup to pass after the block, got %v", err)
+	}
+	if err := d.Check("client"); !errors.Is(err, ErrThrottled) {
+		t.Errorf("expected client to stay throttled after the block, got %v", err)
+	}
+	clock.Advance(2 * time.Hour)
+	for i := 0; i < 3; i++ {
+		if err := d.Check("client"); err != nil {
+			t.Errorf("expected client to be released after cooldown, got %v", err)
+		}
+	}
+}
+
+func TestDetector_MaxClients(t *testing.T) {
+	d, _, _ := newTestDetector(Options{MaxClients: 2})
+	for _, key := range []string{"a", "b", "c"} {
+		d.Record(context.Background(), key, "919876543210", true)
+	}
+	if len(d.clients) != 2 {
+		t.Errorf("expected 2 tracked clients, got %d", len(d.clients))
+	}
+	if _, ok := d.clients["a"]; ok {
+		t.Error("expected least recently seen client to be evicted")
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package grpcapi
+
+import (
+	"github.com/yourusername/truecaller-lite/pkg/grpcapi/truecallerv1"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+// contactsFromProto converts uploaded contacts to models.
+func contactsFromProto(contacts []*truecallerv1.Contact) []models.Contact {
+	out := make([]models.Contact, 0, len(contacts))
+	for _, c := range contacts {
+		out = append(out, models.Contact{PhoneNumber: c.GetPhoneNumber(), Name: c.GetName()})
+	}
+	return out
+}
+
+// uploadReportToProto converts an upload report, including the reasons each rejected contact was skipped.
+func uploadReportToProto(report *models.UploadReport) *truecallerv1.UploadContactsResponse {
+	resp := &truecallerv1.UploadContactsResponse{Total: int32(report.Total), Accepted: int32(report.Accepted)}
+	for _, r := range report.Rejected {
+		rejected := &truecallerv1.RejectedContact{
+			Index:   int32(r.Index),
+			Contact: &truecallerv1.Contact{PhoneNumber: r.Contact.PhoneNumber, Name: r.Contact.Name},
+		}
+		for _, reason := range r.Reasons {
+			rejected.Reasons = append(rejected.Reasons, &truecallerv1.FieldViolation{Field: reason.Field, Code: reason.Code, Message: reason.Message})
+		}
+		resp.Rejected = append(resp.Rejected, rejected)
+	}
+	return resp
+}
+
+// lookupResultToProto converts a detailed lookup result.
+func lookupResultToProto(result *models.LookupResult) *truecallerv1.LookupResult {
+	out := &truecallerv1.LookupResult{PhoneNumber: result.PhoneNumber, Name: result.Name, IsSpam: result.IsSpam}
+	if p := result.GetPerson(); p != nil {
+		out.Person = &truecallerv1.PersonName{Honorific: p.Honorific, GivenName: p.GivenName, FamilyName: p.FamilyName, Organization: p.Organization}
+	}
+	for _, c := range result.GetAlternatives() {
+		out.Alternatives = append(out.Alternatives, &truecallerv1.NameCandidate{Name: c.Name, Uploaders: int32(c.Uploaders)})
+	}
+	return out
+}
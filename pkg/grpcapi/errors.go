@@ -0,0 +1,89 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/yourusername/truecaller-lite/pkg/auth"
+	"github.com/yourusername/truecaller-lite/pkg/dao/daoerrors"
+	"github.com/yourusername/truecaller-lite/pkg/enumeration"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+	"github.com/yourusername/truecaller-lite/pkg/otp"
+	"github.com/yourusername/truecaller-lite/pkg/ratelimit"
+	"github.com/yourusername/truecaller-lite/pkg/service"
+)
+
+// codeNotFound is the LookupError code of batch entries for unknown numbers, as in the HTTP API.
+const codeNotFound = "not_found"
+
+// toStatus maps err to a gRPC status error. Validation errors become INVALID_ARGUMENT with a BadRequest detail
+// listing each field violation; rate limited and throttled calls become RESOURCE_EXHAUSTED and blocked ones
+// PERMISSION_DENIED, with a RetryInfo detail; unexpected errors become INTERNAL without revealing their message.
+func toStatus(err error) error {
+	if resp, ok := models.NewValidationErrorResponse(err); ok {
+		return invalidArgument(resp)
+	}
+	var limited *ratelimit.LimitedError
+	var denied *enumeration.DeniedError
+	switch {
+	case errors.As(err, &denied):
+		if errors.Is(err, enumeration.ErrBlocked) {
+			return retryAfter(codes.PermissionDenied, err, denied.RetryAfter)
+		}
+		return retryAfter(codes.ResourceExhausted, err, denied.RetryAfter)
+	case errors.As(err, &limited):
+		return retryAfter(codes.ResourceExhausted, err, limited.RetryAfter)
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, errMissingToken), errors.Is(err, otp.ErrInvalidToken), errors.Is(err, auth.ErrInvalidToken):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, ErrOwnerNotVerified):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrUnlisted):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, daoerrors.ErrUserNotFound), errors.Is(err, daoerrors.ErrPhoneBookNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrRequestLimitExceeded), errors.Is(err, service.ErrOwnerLimitExceeded):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	default:
+		return status.Error(codes.Internal, "internal error")
+	}
+}
+
+// retryAfter returns a status with code and err's message, carrying a RetryInfo detail asking to retry after d.
+func retryAfter(code codes.Code, err error, d time.Duration) error {
+	st := status.New(code, err.Error())
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(d)}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// invalidArgument returns an INVALID_ARGUMENT status carrying resp's field violations. Fields of list elements
+// are written with their index, e.g. "contacts[3].name".
+func invalidArgument(resp *models.ErrorResponse) error {
+	st := status.New(codes.InvalidArgument, resp.Message)
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(resp.Details))
+	for _, d := range resp.Details {
+		field := d.Field
+		if d.Index != nil {
+			prefix, rest, _ := strings.Cut(field, ".")
+			field = fmt.Sprintf("%s[%d].%s", prefix, *d.Index, rest)
+		}
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: field, Description: d.Message, Reason: d.Code})
+	}
+	if detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
@@ -0,0 +1,176 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/yourusername/truecaller-lite/pkg/enumeration"
+	"github.com/yourusername/truecaller-lite/pkg/grpcapi/truecallerv1"
+	"github.com/yourusername/truecaller-lite/pkg/otp"
+	"github.com/yourusername/truecaller-lite/pkg/ratelimit"
+)
+
+// CallKey extracts the key a call is rate limited (or screened for enumeration) by from its context and request.
+// It returns false if the call has no such key (e.g. no bearer token), in which case the limit does not apply.
+// The keys have the same form as those of the HTTP API (see handler.RateLimitKey), so limiters and enumeration
+// detectors can be shared between both APIs.
+type CallKey func(ctx context.Context, req any) (string, bool)
+
+// KeyByPeer limits by the client IP address of the call's connection.
+func KeyByPeer(ctx context.Context, _ any) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "", false
+	}
+	addr := p.Addr.String()
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr, addr != ""
+	}
+	return host, true
+}
+
+// KeyByToken returns a key limiting by the phone number of the call's bearer token, once verifier accepts the
+// token. Calls without a valid token have no such key; pair it with KeyByPeer to limit those.
+func KeyByToken(verifier otp.TokenVerifier) CallKey {
+	return func(ctx context.Context, _ any) (string, bool) {
+		token, ok := bearerToken(ctx)
+		if !ok {
+			return "", false
+		}
+		phoneNumber, err := verifier.VerifyToken(ctx, token)
+		if err != nil {
+			return "", false
+		}
+		return phoneNumber, true
+	}
+}
+
+// KeyByTokenOrPeer returns a key for calls with a token verifier accepts by the token's phone number, and for all
+// other calls, including those with an invalid token, by client IP.
+func KeyByTokenOrPeer(verifier otp.TokenVerifier) CallKey {
+	byToken := KeyByToken(verifier)
+	return func(ctx context.Context, req any) (string, bool) {
+		if key, ok := byToken(ctx, req); ok {
+			return "owner:" + key, true
+		}
+		key, ok := KeyByPeer(ctx, req)
+		return "ip:" + key, ok
+	}
+}
+
+// KeyByOwner returns a key limiting by the owner number a call acts for, i.e. the owner_phone_number of an upload,
+// once verifier accepts the call as made by that owner. Calls that do not prove ownership have no such key, so
+// others cannot use up an owner's budget; pair it with KeyByPeer to limit those.
+func KeyByOwner(verifier OwnerVerifier) CallKey {
+	return func(ctx context.Context, req any) (string, bool) {
+		r, ok := req.(interface{ GetOwnerPhoneNumber() string })
+		if !ok || r.GetOwnerPhoneNumber() == "" || verifier.VerifyOwner(ctx, r.GetOwnerPhoneNumber()) != nil {
+			return "", false
+		}
+		return r.GetOwnerPhoneNumber(), true
+	}
+}
+
+// RateLimit is one limiter applied to calls under a key.
+type RateLimit struct {
+	Key     CallKey
+	Limiter *ratelimit.Limiter
+}
+
+// RateLimitInterceptor returns a unary interceptor applying limits to the methods they are listed under, by full
+// method name (e.g. truecallerv1.UserService_LookupUser_FullMethodName). A call must pass every limit of its
+// method, and a call one of them refuses takes no tokens from the others. A batch lookup takes one token per
+// number, so it costs as much as looking the numbers up one by one. Limited calls fail with RESOURCE_EXHAUSTED
+// and a RetryInfo detail.
+func RateLimitInterceptor(limits map[string][]RateLimit) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		var charges []ratelimit.Charge
+		for _, limit := range limits[info.FullMethod] {
+			if key, ok := limit.Key(ctx, req); ok {
+				charges = append(charges, ratelimit.Charge{Limiter: limit.Limiter, Key: key, Tokens: max(1, len(lookedUp(req)))})
+			}
+		}
+		if err := ratelimit.AllowAll(charges...); err != nil {
+			return nil, toStatus(err)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// EnumerationInterceptor returns a unary interceptor screening LookupUser and BatchLookupUsers calls with
+// detector, identifying clients by key, like the HTTP API's lookups. Each number of a batch counts as one lookup:
+// the client is checked once per number before the call and every number found or not found is recorded after it.
+// Throttled clients fail with RESOURCE_EXHAUSTED and blocked ones with PERMISSION_DENIED.
+func EnumerationInterceptor(detector *enumeration.Detector, key CallKey) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		phones := lookedUp(req)
+		if len(phones) == 0 {
+			return handler(ctx, req)
+		}
+		client, ok := key(ctx, req)
+		if !ok {
+			return handler(ctx, req)
+		}
+		for range phones {
+			if err := detector.Check(client); err != nil {
+				return nil, toStatus(err)
+			}
+		}
+		resp, err := handler(ctx, req)
+		for _, o := range lookupOutcomes(req, resp, err) {
+			detector.Record(ctx, client, o.phoneNumber, o.found)
+		}
+		return resp, err
+	}
+}
+
+// lookedUp returns the phone numbers a call looks up, or none if it is not a lookup. Batches beyond
+// MaxBatchLookupSize count as MaxBatchLookupSize numbers, since they are rejected without any lookup.
+func lookedUp(req any) []string {
+	switch r := req.(type) {
+	case *truecallerv1.LookupUserRequest:
+		return []string{r.GetPhoneNumber()}
+	case *truecallerv1.BatchLookupUsersRequest:
+		phones := r.GetPhoneNumbers()
+		return phones[:min(len(phones), MaxBatchLookupSize)]
+	}
+	return nil
+}
+
+// lookupOutcome is whether a looked-up number was found.
+type lookupOutcome struct {
+	phoneNumber string
+	found       bool
+}
+
+// lookupOutcomes returns whether each number a lookup call resolved was found, in request order; unlisted numbers
+// count as found. Invalid numbers and failed calls are left out.
+func lookupOutcomes(req, resp any, err error) []lookupOutcome {
+	var outcomes []lookupOutcome
+	switch r := req.(type) {
+	case *truecallerv1.LookupUserRequest:
+		switch {
+		case err == nil:
+			outcomes = append(outcomes, lookupOutcome{phoneNumber: r.GetPhoneNumber(), found: true})
+		case status.Code(err) == codes.NotFound:
+			outcomes = append(outcomes, lookupOutcome{phoneNumber: r.GetPhoneNumber()})
+		}
+	case *truecallerv1.BatchLookupUsersRequest:
+		batch, _ := resp.(*truecallerv1.BatchLookupUsersResponse)
+		for _, entry := range batch.GetEntries() {
+			switch {
+			case entry.GetResult() != nil, entry.GetUnlisted():
+				outcomes = append(outcomes, lookupOutcome{phoneNumber: entry.GetPhoneNumber(), found: true})
+			case entry.GetError().GetCode() == codeNotFound:
+				outcomes = append(outcomes, lookupOutcome{phoneNumber: entry.GetPhoneNumber()})
+			}
+		}
+	}
+	return outcomes
+}
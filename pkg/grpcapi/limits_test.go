@@ -0,0 +1,111 @@
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao/mem"
+	"github.com/yourusername/truecaller-lite/pkg/enumeration"
+	"github.com/yourusername/truecaller-lite/pkg/grpcapi/truecallerv1"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+	"github.com/yourusername/truecaller-lite/pkg/ratelimit"
+	"github.com/yourusername/truecaller-lite/pkg/service"
+)
+
+func TestRateLimitInterceptor(t *testing.T) {
+	ctx := context.Background()
+	issuer := newIssuer(t)
+	userService := service.NewUserService(mem.NewUserMemDAO(), mem.NewPhoneBookMemDAO())
+	limiter := ratelimit.NewLimiter(ratelimit.Every(3, time.Minute), ratelimit.Options{})
+	limits := map[string][]RateLimit{
+		truecallerv1.UserService_LookupUser_FullMethodName:       {{Key: KeyByPeer, Limiter: limiter}},
+		truecallerv1.UserService_BatchLookupUsers_FullMethodName: {{Key: KeyByPeer, Limiter: limiter}},
+	}
+	conn := dial(t, userService, nil, []Option{WithTokenVerifier(issuer)}, grpc.UnaryInterceptor(RateLimitInterceptor(limits)))
+	client := truecallerv1.NewUserServiceClient(conn)
+
+	if _, err := client.LookupUser(ctx, &truecallerv1.LookupUserRequest{PhoneNumber: bob}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound, got %v", err)
+	}
+	batch := &truecallerv1.BatchLookupUsersRequest{PhoneNumbers: []string{bob, owner}}
+	if _, err := client.BatchLookupUsers(withToken(t, ctx, issuer, carol), batch); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err := client.LookupUser(ctx, &truecallerv1.LookupUserRequest{PhoneNumber: bob})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected the batch to count each number, got %v", err)
+	}
+	var retry *errdetails.RetryInfo
+	for _, d := range status.Convert(err).Details() {
+		if r, ok := d.(*errdetails.RetryInfo); ok {
+			retry = r
+		}
+	}
+	if retry.GetRetryDelay().AsDuration() <= 0 {
+		t.Errorf("expected a RetryInfo detail, got %v", status.Convert(err).Details())
+	}
+}
+
+func TestRateLimitInterceptor_Owner(t *testing.T) {
+	ctx := context.Background()
+	issuer := newIssuer(t)
+	userService := service.NewUserService(mem.NewUserMemDAO(), mem.NewPhoneBookMemDAO())
+	verifier := NewTokenOwnerVerifier(issuer)
+	limits := map[string][]RateLimit{
+		truecallerv1.UserService_UploadContacts_FullMethodName: {
+			{Key: KeyByOwner(verifier), Limiter: ratelimit.NewLimiter(ratelimit.Every(1, time.Minute), ratelimit.Options{})},
+		},
+	}
+	conn := dial(t, userService, nil, []Option{WithOwnerVerifier(verifier)}, grpc.UnaryInterceptor(RateLimitInterceptor(limits)))
+	client := truecallerv1.NewUserServiceClient(conn)
+
+	upload := &truecallerv1.UploadContactsRequest{OwnerPhoneNumber: owner}
+	for i := 0; i < 3; i++ {
+		if _, err := client.UploadContacts(withToken(t, ctx, issuer, bob), upload); status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("upload %d by another number: expected PermissionDenied, got %v", i, err)
+		}
+	}
+	if _, err := client.UploadContacts(withToken(t, ctx, issuer, owner), upload); err != nil {
+		t.Fatalf("expected the owner's upload to pass after the flood, got %v", err)
+	}
+	if _, err := client.UploadContacts(withToken(t, ctx, issuer, owner), upload); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("expected the owner limit to apply, got %v", err)
+	}
+}
+
+func TestEnumerationInterceptor(t *testing.T) {
+	ctx := context.Background()
+	issuer := newIssuer(t)
+	userDAO := mem.NewUserMemDAO()
+	_ = userDAO.CreateOrUpdateUser(ctx, &models.User{PhoneNumber: bob, Name: "Bob"})
+	userService := service.NewUserService(userDAO, mem.NewPhoneBookMemDAO())
+	offenders := mem.NewOffenderMemDAO()
+	detector := enumeration.NewDetector(offenders, enumeration.Options{MinLookups: 5})
+	conn := dial(t, userService, nil, []Option{WithTokenVerifier(issuer)}, grpc.UnaryInterceptor(EnumerationInterceptor(detector, KeyByTokenOrPeer(issuer))))
+	client := truecallerv1.NewUserServiceClient(conn)
+
+	scanner := withToken(t, ctx, issuer, carol)
+	batch := &truecallerv1.BatchLookupUsersRequest{}
+	for i := 0; i < 5; i++ {
+		batch.PhoneNumbers = append(batch.PhoneNumbers, fmt.Sprintf("9198765%05d", i))
+	}
+	if _, err := client.BatchLookupUsers(scanner, batch); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.LookupUser(scanner, &truecallerv1.LookupUserRequest{PhoneNumber: bob}); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("expected the scanning caller to be throttled, got %v", err)
+	}
+	if _, err := client.LookupUser(withToken(t, ctx, issuer, owner), &truecallerv1.LookupUserRequest{PhoneNumber: bob}); err != nil {
+		t.Errorf("expected other callers to be unaffected, got %v", err)
+	}
+	if offender, _ := offenders.GetOffender(ctx, "owner:"+carol); offender == nil {
+		t.Error("expected the scanning caller to be recorded as an offender")
+	}
+}
@@ -0,0 +1,73 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/yourusername/truecaller-lite/pkg/apierrors"
+	"github.com/yourusername/truecaller-lite/pkg/otp"
+)
+
+var (
+	// ErrOwnerNotVerified is returned by an OwnerVerifier when a call does not prove ownership of a phone number.
+	// It is the same error as handler.ErrOwnerNotVerified (apierrors.ErrOwnerNotVerified).
+	ErrOwnerNotVerified = apierrors.ErrOwnerNotVerified
+	// errMissingToken is returned when a call has no bearer token at all.
+	errMissingToken = errors.New("missing bearer token")
+)
+
+// OwnerVerifier checks that a call is made by the verified owner of a phone number.
+// It guards owner-only calls such as contact upload and spam reporting.
+type OwnerVerifier interface {
+	// VerifyOwner returns nil if the call with context ctx proves ownership of phoneNumber, or an error.
+	VerifyOwner(ctx context.Context, phoneNumber string) error
+}
+
+// OwnerVerifierFunc adapts a function to the OwnerVerifier interface.
+type OwnerVerifierFunc func(ctx context.Context, phoneNumber string) error
+
+// VerifyOwner calls f(ctx, phoneNumber).
+func (f OwnerVerifierFunc) VerifyOwner(ctx context.Context, phoneNumber string) error {
+	return f(ctx, phoneNumber)
+}
+
+// denyAllOwners is the default OwnerVerifier: without a configured verifier no call can act as an owner.
+var denyAllOwners = OwnerVerifierFunc(func(ctx context.Context, phoneNumber string) error {
+	return ErrOwnerNotVerified
+})
+
+// NewTokenOwnerVerifier returns an OwnerVerifier accepting calls with an "authorization: Bearer <token>" metadata
+// entry whose token verifier resolves to the phone number being accessed, like the HTTP API's bearer tokens.
+// Calls without a token fail with UNAUTHENTICATED, calls for another number with PERMISSION_DENIED.
+func NewTokenOwnerVerifier(verifier otp.TokenVerifier) OwnerVerifier {
+	return OwnerVerifierFunc(func(ctx context.Context, phoneNumber string) error {
+		token, ok := bearerToken(ctx)
+		if !ok {
+			return errMissingToken
+		}
+		subject, err := verifier.VerifyToken(ctx, token)
+		if err != nil {
+			return err
+		}
+		if subject != phoneNumber {
+			return fmt.Errorf("%w: token is for another number", ErrOwnerNotVerified)
+		}
+		return nil
+	})
+}
+
+// bearerToken returns the token from the call's authorization metadata, if any.
+func bearerToken(ctx context.Context) (string, bool) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		scheme, token, ok := strings.Cut(value, " ")
+		if ok && strings.EqualFold(scheme, "Bearer") && token != "" {
+			return token, true
+		}
+	}
+	return "", false
+}
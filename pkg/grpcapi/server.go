@@ -0,0 +1,207 @@
+// Package grpcapi exposes the service layer over gRPC, mirroring the contact upload, lookup and spam reporting
+// parts of the HTTP API. The protobuf definitions are in proto/truecaller/v1; the generated code is in the
+// truecallerv1 subpackage.
+//
+// Servers only convert messages, call services and map errors to gRPC status codes; business logic stays in the
+// service layer. The call's context, including the client's deadline, is passed to the services unchanged.
+// Rate limits and enumeration screening are applied by interceptors (see RateLimitInterceptor and
+// EnumerationInterceptor), so they can share limiters and detectors with the HTTP API.
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"google.golang.org/grpc"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao/daoerrors"
+	"github.com/yourusername/truecaller-lite/pkg/grpcapi/truecallerv1"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+	"github.com/yourusername/truecaller-lite/pkg/otp"
+	"github.com/yourusername/truecaller-lite/pkg/service"
+)
+
+// MaxBatchLookupSize caps the number of phone numbers in one BatchLookupUsers call.
+const MaxBatchLookupSize = 20
+
+// options configures the servers.
+type options struct {
+	ownerVerifier OwnerVerifier
+	tokens        otp.TokenVerifier // nil rejects every call that needs a verified caller
+	lookupLog     service.LookupLogService
+}
+
+// Option configures the servers created by Register, NewUserServer and NewSpamServer.
+type Option func(*options)
+
+// WithOwnerVerifier sets how owner-only calls verify the caller. By default every such call is rejected.
+func WithOwnerVerifier(verifier OwnerVerifier) Option {
+	return func(o *options) { o.ownerVerifier = verifier }
+}
+
+// WithTokenVerifier sets how calls that need a verified caller but no particular number, such as batch lookups,
+// verify the caller's bearer token. By default every such call is rejected.
+func WithTokenVerifier(tokens otp.TokenVerifier) Option {
+	return func(o *options) { o.tokens = tokens }
+}
+
+// WithLookupLog records lookups made with a bearer token accepted by the WithTokenVerifier verifier in lookupLog,
+// for the owners' "who viewed me" lists, like the HTTP API's lookups.
+func WithLookupLog(lookupLog service.LookupLogService) Option {
+	return func(o *options) { o.lookupLog = lookupLog }
+}
+
+// newOptions applies opts over the defaults.
+func newOptions(opts []Option) options {
+	o := options{ownerVerifier: denyAllOwners}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Register registers the UserService and SpamService servers on s.
+func Register(s grpc.ServiceRegistrar, userService service.UserService, spamService service.SpamService, opts ...Option) {
+	truecallerv1.RegisterUserServiceServer(s, NewUserServer(userService, opts...))
+	truecallerv1.RegisterSpamServiceServer(s, NewSpamServer(spamService, opts...))
+}
+
+// userServer implements truecallerv1.UserServiceServer on top of service.UserService.
+type userServer struct {
+	truecallerv1.UnimplementedUserServiceServer
+	userService service.UserService
+	options
+}
+
+// NewUserServer creates a gRPC UserService server calling userService.
+func NewUserServer(userService service.UserService, opts ...Option) truecallerv1.UserServiceServer {
+	return &userServer{userService: userService, options: newOptions(opts)}
+}
+
+// UploadContacts replaces the owner's phone book, or stores only its valid contacts if req.Partial is set.
+func (s *userServer) UploadContacts(ctx context.Context, req *truecallerv1.UploadContactsRequest) (*truecallerv1.UploadContactsResponse, error) {
+	owner := req.GetOwnerPhoneNumber()
+	if err := s.ownerVerifier.VerifyOwner(ctx, owner); err != nil {
+		return nil, toStatus(err)
+	}
+	contacts := contactsFromProto(req.GetContacts())
+	if req.GetPartial() {
+		report, err := s.userService.UploadContactsPartial(ctx, owner, contacts)
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		return uploadReportToProto(report), nil
+	}
+	if err := s.userService.UploadContacts(ctx, owner, contacts); err != nil {
+		return nil, toStatus(err)
+	}
+	return &truecallerv1.UploadContactsResponse{Total: int32(len(contacts)), Accepted: int32(len(contacts))}, nil
+}
+
+// LookupUser looks up one number. Unlisted numbers get a response with their spam status but without a result,
+// rather than an error.
+func (s *userServer) LookupUser(ctx context.Context, req *truecallerv1.LookupUserRequest) (*truecallerv1.LookupUserResponse, error) {
+	result, err := s.userService.LookupUserDetailed(ctx, req.GetPhoneNumber(), int(req.GetAlternatives()))
+	if errors.Is(err, service.ErrUnlisted) {
+		return &truecallerv1.LookupUserResponse{Unlisted: true, IsSpam: result.GetIsSpam()}, nil
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	s.recordLookup(ctx, req.GetPhoneNumber())
+	return &truecallerv1.LookupUserResponse{Result: lookupResultToProto(result)}, nil
+}
+
+// BatchLookupUsers looks up each number in turn. The call must carry a bearer token of a verified number.
+// Unknown and invalid numbers get an error entry; any other error, such as the call's deadline passing, fails the
+// whole call.
+func (s *userServer) BatchLookupUsers(ctx context.Context, req *truecallerv1.BatchLookupUsersRequest) (*truecallerv1.BatchLookupUsersResponse, error) {
+	requester, err := s.caller(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	phones := req.GetPhoneNumbers()
+	if len(phones) > MaxBatchLookupSize {
+		return nil, toStatus(&models.ValidationError{Field: "phone_numbers", Index: models.NoIndex, Code: models.CodeTooLong, Message: "at most " + strconv.Itoa(MaxBatchLookupSize) + " phone numbers can be looked up at once"})
+	}
+	resp := &truecallerv1.BatchLookupUsersResponse{Entries: make([]*truecallerv1.BatchLookupEntry, 0, len(phones))}
+	for _, phone := range phones {
+		entry := &truecallerv1.BatchLookupEntry{PhoneNumber: phone}
+		result, err := s.userService.LookupUserDetailed(ctx, phone, int(req.GetAlternatives()))
+		var ve *models.ValidationError
+		switch {
+		case err == nil:
+			entry.Outcome = &truecallerv1.BatchLookupEntry_Result{Result: lookupResultToProto(result)}
+			s.record(ctx, requester, phone)
+		case errors.Is(err, service.ErrUnlisted):
+			entry.Outcome = &truecallerv1.BatchLookupEntry_Unlisted{Unlisted: true}
+			entry.IsSpam = result.GetIsSpam()
+		case errors.Is(err, daoerrors.ErrUserNotFound):
+			entry.Outcome = &truecallerv1.BatchLookupEntry_Error{Error: &truecallerv1.LookupError{Code: codeNotFound, Message: err.Error()}}
+		case errors.As(err, &ve):
+			entry.Outcome = &truecallerv1.BatchLookupEntry_Error{Error: &truecallerv1.LookupError{Code: models.ErrCodeValidationFailed, Message: ve.Message}}
+		default:
+			return nil, toStatus(err)
+		}
+		resp.Entries = append(resp.Entries, entry)
+	}
+	return resp, nil
+}
+
+// caller returns the phone number of the call's bearer token, once the token verifier accepts it.
+func (o options) caller(ctx context.Context) (string, error) {
+	token, ok := bearerToken(ctx)
+	if !ok {
+		return "", errMissingToken
+	}
+	if o.tokens == nil {
+		return "", ErrOwnerNotVerified
+	}
+	return o.tokens.VerifyToken(ctx, token)
+}
+
+// recordLookup logs a successful lookup of phoneNumber if the call carries a valid bearer token.
+func (o options) recordLookup(ctx context.Context, phoneNumber string) {
+	if o.lookupLog == nil {
+		return
+	}
+	if requester, err := o.caller(ctx); err == nil {
+		o.record(ctx, requester, phoneNumber)
+	}
+}
+
+// record logs that requester looked up phoneNumber. Logging is best effort: a failure must not fail the lookup.
+func (o options) record(ctx context.Context, requester, phoneNumber string) {
+	if o.lookupLog != nil {
+		_ = o.lookupLog.RecordLookup(ctx, requester, phoneNumber)
+	}
+}
+
+// spamServer implements truecallerv1.SpamServiceServer on top of service.SpamService.
+type spamServer struct {
+	truecallerv1.UnimplementedSpamServiceServer
+	spamService service.SpamService
+	options
+}
+
+// NewSpamServer creates a gRPC SpamService server calling spamService.
+func NewSpamServer(spamService service.SpamService, opts ...Option) truecallerv1.SpamServiceServer {
+	return &spamServer{spamService: spamService, options: newOptions(opts)}
+}
+
+// ReportSpam records a spam report filed by the verified owner of the reporter number.
+func (s *spamServer) ReportSpam(ctx context.Context, req *truecallerv1.ReportSpamRequest) (*truecallerv1.ReportSpamResponse, error) {
+	if err := s.ownerVerifier.VerifyOwner(ctx, req.GetReporterPhoneNumber()); err != nil {
+		return nil, toStatus(err)
+	}
+	if err := s.spamService.ReportSpam(ctx, req.GetReporterPhoneNumber(), req.GetPhoneNumber(), req.GetReason()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &truecallerv1.ReportSpamResponse{}, nil
+}
+
+var (
+	_ truecallerv1.UserServiceServer = (*userServer)(nil)
+	_ truecallerv1.SpamServiceServer = (*spamServer)(nil)
+)
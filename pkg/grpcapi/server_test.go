@@ -0,0 +1,275 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/yourusername/truecaller-lite/pkg/auth"
+	"github.com/yourusername/truecaller-lite/pkg/dao/daoerrors"
+	"github.com/yourusername/truecaller-lite/pkg/dao/mem"
+	"github.com/yourusername/truecaller-lite/pkg/dao/mock"
+	"github.com/yourusername/truecaller-lite/pkg/enumeration"
+	"github.com/yourusername/truecaller-lite/pkg/grpcapi/truecallerv1"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+	"github.com/yourusername/truecaller-lite/pkg/ratelimit"
+	"github.com/yourusername/truecaller-lite/pkg/service"
+)
+
+const (
+	owner = "919876543210"
+	bob   = "919123456789"
+	carol = "919555555555"
+)
+
+// dial serves the given services over an in-memory connection and returns a client connection to them.
+func dial(t *testing.T, userService service.UserService, spamService service.SpamService, opts []Option, serverOpts ...grpc.ServerOption) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(serverOpts...)
+	Register(srv, userService, spamService, opts...)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+// newIssuer returns a token issuer with a random key.
+func newIssuer(t *testing.T) *auth.Issuer {
+	t.Helper()
+	key, err := auth.GenerateKey()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	issuer, err := auth.NewIssuer([]auth.Key{key}, auth.Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return issuer
+}
+
+// withToken returns ctx carrying a bearer token for phoneNumber.
+func withToken(t *testing.T, ctx context.Context, issuer *auth.Issuer, phoneNumber string) context.Context {
+	t.Helper()
+	token, _, err := issuer.IssueToken(ctx, phoneNumber)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+}
+
+func TestUserServer_UploadAndLookup(t *testing.T) {
+	ctx := context.Background()
+	issuer := newIssuer(t)
+	userDAO, privacyDAO := mem.NewUserMemDAO(), mem.NewPrivacyMemDAO()
+	userService := service.NewUserService(userDAO, mem.NewPhoneBookMemDAO(), service.WithPrivacyDAO(privacyDAO))
+	spamService := service.NewSpamService(userDAO, mem.NewSpamReportMemDAO())
+	client := truecallerv1.NewUserServiceClient(dial(t, userService, spamService, []Option{WithOwnerVerifier(NewTokenOwnerVerifier(issuer))}))
+
+	upload := &truecallerv1.UploadContactsRequest{OwnerPhoneNumber: owner, Contacts: []*truecallerv1.Contact{{PhoneNumber: bob, Name: "Dr. Bob Rao - Apollo"}}}
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		req      *truecallerv1.UploadContactsRequest
+		wantCode codes.Code
+	}{
+		{name: "no token", ctx: ctx, req: upload, wantCode: codes.Unauthenticated},
+		{name: "another owner's token", ctx: withToken(t, ctx, issuer, bob), req: upload, wantCode: codes.PermissionDenied},
+		{name: "invalid contact", ctx: withToken(t, ctx, issuer, owner), req: &truecallerv1.UploadContactsRequest{OwnerPhoneNumber: owner, Contacts: []*truecallerv1.Contact{{PhoneNumber: "123", Name: "X"}}}, wantCode: codes.InvalidArgument},
+		{name: "owner", ctx: withToken(t, ctx, issuer, owner), req: upload, wantCode: codes.OK},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := client.UploadContacts(tc.ctx, tc.req)
+			if got := status.Code(err); got != tc.wantCode {
+				t.Fatalf("expected %v, got %v (%v)", tc.wantCode, got, err)
+			}
+		})
+	}
+
+	resp, err := client.LookupUser(ctx, &truecallerv1.LookupUserRequest{PhoneNumber: bob, Alternatives: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result := resp.GetResult()
+	if result.GetName() != "Dr. Bob Rao - Apollo" || result.GetPerson().GetOrganization() != "Apollo" || len(result.GetAlternatives()) != 1 {
+		t.Errorf("unexpected lookup result %v", result)
+	}
+	if _, err := client.LookupUser(ctx, &truecallerv1.LookupUserRequest{PhoneNumber: "919000000000"}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+
+	_ = privacyDAO.SetUnlisted(ctx, bob, true)
+	resp, err = client.LookupUser(ctx, &truecallerv1.LookupUserRequest{PhoneNumber: bob})
+	if err != nil || !resp.GetUnlisted() || resp.GetResult() != nil || resp.GetIsSpam() {
+		t.Errorf("expected an unlisted response, got %v (%v)", resp, err)
+	}
+}
+
+func TestUserServer_PartialUpload(t *testing.T) {
+	userService := service.NewUserService(mem.NewUserMemDAO(), mem.NewPhoneBookMemDAO())
+	trustAll := OwnerVerifierFunc(func(ctx context.Context, phoneNumber string) error { return nil })
+	client := truecallerv1.NewUserServiceClient(dial(t, userService, nil, []Option{WithOwnerVerifier(trustAll)}))
+
+	contacts := []*truecallerv1.Contact{{PhoneNumber: bob, Name: "Bob"}, {PhoneNumber: "123", Name: "X"}}
+	_, err := client.UploadContacts(context.Background(), &truecallerv1.UploadContactsRequest{OwnerPhoneNumber: owner, Contacts: contacts})
+	var badRequest *errdetails.BadRequest
+	for _, d := range status.Convert(err).Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			badRequest = br
+		}
+	}
+	if status.Code(err) != codes.InvalidArgument || len(badRequest.GetFieldViolations()) == 0 || badRequest.GetFieldViolations()[0].GetField() != "contacts[1].phone_number" {
+		t.Fatalf("expected field violations for contacts[1], got %v (%v)", badRequest, err)
+	}
+
+	resp, err := client.UploadContacts(context.Background(), &truecallerv1.UploadContactsRequest{OwnerPhoneNumber: owner, Contacts: contacts, Partial: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.GetTotal() != 2 || resp.GetAccepted() != 1 || len(resp.GetRejected()) != 1 || resp.GetRejected()[0].GetIndex() != 1 {
+		t.Errorf("unexpected report %v", resp)
+	}
+}
+
+func TestUserServer_BatchLookupUsers(t *testing.T) {
+	ctx := context.Background()
+	userDAO, privacyDAO := mem.NewUserMemDAO(), mem.NewPrivacyMemDAO()
+	_ = userDAO.CreateOrUpdateUser(ctx, &models.User{PhoneNumber: bob, Name: "Bob", IsSpam: true})
+	_ = userDAO.CreateOrUpdateUser(ctx, &models.User{PhoneNumber: owner, Name: "Alice", IsSpam: true})
+	_ = privacyDAO.SetUnlisted(ctx, owner, true)
+	userService := service.NewUserService(userDAO, mem.NewPhoneBookMemDAO(), service.WithPrivacyDAO(privacyDAO))
+	issuer, lookupLogDAO := newIssuer(t), mem.NewLookupLogMemDAO()
+	lookupLog := service.NewLookupLogService(lookupLogDAO, userDAO, privacyDAO, service.LookupLogOptions{})
+	client := truecallerv1.NewUserServiceClient(dial(t, userService, nil, []Option{WithTokenVerifier(issuer), WithLookupLog(lookupLog)}))
+
+	req := &truecallerv1.BatchLookupUsersRequest{PhoneNumbers: []string{bob, owner, "919000000000", "123"}}
+	if _, err := client.BatchLookupUsers(ctx, req); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected Unauthenticated without a token, got %v", err)
+	}
+	ctx = withToken(t, ctx, issuer, carol)
+	resp, err := client.BatchLookupUsers(ctx, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	entries := resp.GetEntries()
+	if len(entries) != 4 {
+		t.Fatalf("expected 4 entries, got %v", entries)
+	}
+	if r := entries[0].GetResult(); r.GetName() != "Bob" || !r.GetIsSpam() {
+		t.Errorf("expected Bob, got %v", entries[0])
+	}
+	if !entries[1].GetUnlisted() || !entries[1].GetIsSpam() {
+		t.Errorf("expected unlisted with the spam status, got %v", entries[1])
+	}
+	if entries[2].GetError().GetCode() != "not_found" || entries[3].GetError().GetCode() != models.ErrCodeValidationFailed {
+		t.Errorf("expected not_found and validation_failed, got %v and %v", entries[2], entries[3])
+	}
+	if viewers, _ := lookupLogDAO.GetLookupsByPhoneNumber(ctx, bob); len(viewers) != 1 || viewers[0].RequesterPhoneNumber != carol {
+		t.Errorf("expected the caller's lookup of Bob to be logged, got %+v", viewers)
+	}
+	if viewers, _ := lookupLogDAO.GetLookupsByPhoneNumber(ctx, owner); len(viewers) != 0 {
+		t.Errorf("expected the unlisted number's lookup not to be logged, got %+v", viewers)
+	}
+
+	_, err = client.BatchLookupUsers(ctx, &truecallerv1.BatchLookupUsersRequest{PhoneNumbers: make([]string, MaxBatchLookupSize+1)})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for an oversized batch, got %v", err)
+	}
+}
+
+func TestSpamServer_ReportSpam(t *testing.T) {
+	ctx := context.Background()
+	issuer := newIssuer(t)
+	userDAO, reportDAO := mem.NewUserMemDAO(), mem.NewSpamReportMemDAO()
+	spamService := service.NewSpamService(userDAO, reportDAO)
+	client := truecallerv1.NewSpamServiceClient(dial(t, nil, spamService, []Option{WithOwnerVerifier(NewTokenOwnerVerifier(issuer))}))
+
+	req := &truecallerv1.ReportSpamRequest{ReporterPhoneNumber: owner, PhoneNumber: bob, Reason: "loan scam"}
+	if _, err := client.ReportSpam(ctx, req); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected Unauthenticated without a token, got %v", err)
+	}
+	if _, err := client.ReportSpam(withToken(t, ctx, issuer, owner), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	reports, _ := reportDAO.GetSpamReportsByReporter(ctx, owner)
+	if len(reports) != 1 || reports[0].PhoneNumber != bob {
+		t.Errorf("expected the report to be stored, got %+v", reports)
+	}
+}
+
+func TestDeadlinePropagation(t *testing.T) {
+	tests := []struct {
+		name          string
+		clientTimeout time.Duration
+		serverTimeout time.Duration
+	}{
+		{name: "client deadline", clientTimeout: 50 * time.Millisecond},
+		{name: "server timeout", clientTimeout: time.Minute, serverTimeout: 50 * time.Millisecond},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deadlines := make(chan bool, 1)
+			userDAO := &mock.UserDAOMock{OnGetUserByPhoneNumber: func(ctx context.Context, phoneNumber string) (*models.User, error) {
+				_, ok := ctx.Deadline()
+				deadlines <- ok
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}}
+			userService := service.NewUserService(userDAO, &mock.PhoneBookDAOMock{})
+			conn := dial(t, userService, nil, nil, grpc.UnaryInterceptor(TimeoutInterceptor(tc.serverTimeout)))
+			ctx, cancel := context.WithTimeout(context.Background(), tc.clientTimeout)
+			defer cancel()
+			_, err := truecallerv1.NewUserServiceClient(conn).LookupUser(ctx, &truecallerv1.LookupUserRequest{PhoneNumber: bob})
+			if status.Code(err) != codes.DeadlineExceeded {
+				t.Errorf("expected DeadlineExceeded, got %v", err)
+			}
+			if !<-deadlines {
+				t.Error("expected the service context to carry a deadline")
+			}
+		})
+	}
+}
+
+func TestToStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{name: "validation", err: &models.ValidationError{Field: "name", Index: models.NoIndex, Code: models.CodeRequired, Message: "name is required"}, want: codes.InvalidArgument},
+		{name: "wrapped not found", err: fmt.Errorf("lookup: %w", daoerrors.ErrUserNotFound), want: codes.NotFound},
+		{name: "unexpected", err: errors.New("disk on fire"), want: codes.Internal},
+		{name: "upload limit", err: service.ErrOwnerLimitExceeded, want: codes.ResourceExhausted},
+		{name: "owner not verified", err: ErrOwnerNotVerified, want: codes.PermissionDenied},
+		{name: "invalid token", err: auth.ErrInvalidToken, want: codes.Unauthenticated},
+		{name: "canceled", err: context.Canceled, want: codes.Canceled},
+		{name: "rate limited", err: &ratelimit.LimitedError{RetryAfter: time.Second}, want: codes.ResourceExhausted},
+		{name: "throttled", err: &enumeration.DeniedError{Action: models.ActionThrottle, RetryAfter: time.Second}, want: codes.ResourceExhausted},
+		{name: "blocked", err: &enumeration.DeniedError{Action: models.ActionBlock, RetryAfter: time.Hour}, want: codes.PermissionDenied},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := status.Code(toStatus(tc.err)); got != tc.want {
+				t.Errorf("toStatus(%v) = %v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+}
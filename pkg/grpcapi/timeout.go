@@ -0,0 +1,23 @@
+package grpcapi
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// TimeoutInterceptor returns a unary interceptor bounding every call to d: calls without a deadline get one d from
+// now, and calls with a later deadline have it shortened. The client's deadline reaches the services through the
+// call's context either way, so storage work stops once the client has given up. A d of zero or less only passes
+// the client's deadline through.
+func TimeoutInterceptor(d time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if d <= 0 {
+			return handler(ctx, req)
+		}
+		ctx, cancel := context.WithTimeout(ctx, d)
+		defer cancel()
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,1169 @@
+// gRPC API of TrueCaller-Lite, mirroring the contact upload, lookup and spam reporting parts of the HTTP API.
+// Generate the Go code with "buf generate" from the repository root (see buf.gen.yaml).
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: truecaller/v1/truecaller.proto
+
+package truecallerv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Contact is one entry of an uploaded phone book.
+type Contact struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PhoneNumber   string                 `protobuf:"bytes,1,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Contact) Reset() {
+	*x = Contact{}
+	mi := &file_truecaller_v1_truecaller_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Contact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Contact) ProtoMessage() {}
+
+func (x *Contact) ProtoReflect() protoreflect.Message {
+	mi := &file_truecaller_v1_truecaller_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Contact.ProtoReflect.Descriptor instead.
+func (*Contact) Descriptor() ([]byte, []int) {
+	return file_truecaller_v1_truecaller_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Contact) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+func (x *Contact) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type UploadContactsRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	OwnerPhoneNumber string                 `protobuf:"bytes,1,opt,name=owner_phone_number,json=ownerPhoneNumber,proto3" json:"owner_phone_number,omitempty"`
+	Contacts         []*Contact             `protobuf:"bytes,2,rep,name=contacts,proto3" json:"contacts,omitempty"`
+	// partial stores the valid contacts and reports the invalid ones, instead of rejecting the whole upload.
+	Partial       bool `protobuf:"varint,3,opt,name=partial,proto3" json:"partial,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadContactsRequest) Reset() {
+	*x = UploadContactsRequest{}
+	mi := &file_truecaller_v1_truecaller_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadContactsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadContactsRequest) ProtoMessage() {}
+
+func (x *UploadContactsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_truecaller_v1_truecaller_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadContactsRequest.ProtoReflect.Descriptor instead.
+func (*UploadContactsRequest) Descriptor() ([]byte, []int) {
+	return file_truecaller_v1_truecaller_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UploadContactsRequest) GetOwnerPhoneNumber() string {
+	if x != nil {
+		return x.OwnerPhoneNumber
+	}
+	return ""
+}
+
+func (x *UploadContactsRequest) GetContacts() []*Contact {
+	if x != nil {
+		return x.Contacts
+	}
+	return nil
+}
+
+func (x *UploadContactsRequest) GetPartial() bool {
+	if x != nil {
+		return x.Partial
+	}
+	return false
+}
+
+// UploadContactsResponse reports how many contacts were stored and why the others were skipped.
+type UploadContactsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Total         int32                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Accepted      int32                  `protobuf:"varint,2,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Rejected      []*RejectedContact     `protobuf:"bytes,3,rep,name=rejected,proto3" json:"rejected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadContactsResponse) Reset() {
+	*x = UploadContactsResponse{}
+	mi := &file_truecaller_v1_truecaller_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadContactsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadContactsResponse) ProtoMessage() {}
+
+func (x *UploadContactsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_truecaller_v1_truecaller_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadContactsResponse.ProtoReflect.Descriptor instead.
+func (*UploadContactsResponse) Descriptor() ([]byte, []int) {
+	return file_truecaller_v1_truecaller_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UploadContactsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *UploadContactsResponse) GetAccepted() int32 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+func (x *UploadContactsResponse) GetRejected() []*RejectedContact {
+	if x != nil {
+		return x.Rejected
+	}
+	return nil
+}
+
+// RejectedContact is a contact skipped by a partial upload.
+type RejectedContact struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// index is the contact's position in the request.
+	Index         int32             `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Contact       *Contact          `protobuf:"bytes,2,opt,name=contact,proto3" json:"contact,omitempty"`
+	Reasons       []*FieldViolation `protobuf:"bytes,3,rep,name=reasons,proto3" json:"reasons,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectedContact) Reset() {
+	*x = RejectedContact{}
+	mi := &file_truecaller_v1_truecaller_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectedContact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectedContact) ProtoMessage() {}
+
+func (x *RejectedContact) ProtoReflect() protoreflect.Message {
+	mi := &file_truecaller_v1_truecaller_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectedContact.ProtoReflect.Descriptor instead.
+func (*RejectedContact) Descriptor() ([]byte, []int) {
+	return file_truecaller_v1_truecaller_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RejectedContact) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *RejectedContact) GetContact() *Contact {
+	if x != nil {
+		return x.Contact
+	}
+	return nil
+}
+
+func (x *RejectedContact) GetReasons() []*FieldViolation {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+// FieldViolation is one validation failure, with the same codes as the HTTP API (e.g. "invalid_format").
+type FieldViolation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldViolation) Reset() {
+	*x = FieldViolation{}
+	mi := &file_truecaller_v1_truecaller_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldViolation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldViolation) ProtoMessage() {}
+
+func (x *FieldViolation) ProtoReflect() protoreflect.Message {
+	mi := &file_truecaller_v1_truecaller_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldViolation.ProtoReflect.Descriptor instead.
+func (*FieldViolation) Descriptor() ([]byte, []int) {
+	return file_truecaller_v1_truecaller_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FieldViolation) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *FieldViolation) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *FieldViolation) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type LookupUserRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	PhoneNumber string                 `protobuf:"bytes,1,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	// alternatives is how many candidate names to return besides the resolved name (at most 10).
+	Alternatives  int32 `protobuf:"varint,2,opt,name=alternatives,proto3" json:"alternatives,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupUserRequest) Reset() {
+	*x = LookupUserRequest{}
+	mi := &file_truecaller_v1_truecaller_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupUserRequest) ProtoMessage() {}
+
+func (x *LookupUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_truecaller_v1_truecaller_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupUserRequest.ProtoReflect.Descriptor instead.
+func (*LookupUserRequest) Descriptor() ([]byte, []int) {
+	return file_truecaller_v1_truecaller_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LookupUserRequest) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+func (x *LookupUserRequest) GetAlternatives() int32 {
+	if x != nil {
+		return x.Alternatives
+	}
+	return 0
+}
+
+type LookupUserResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// result is unset if the number is unlisted.
+	Result *LookupResult `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	// unlisted reports that the owner of the number opted out of caller ID.
+	Unlisted bool `protobuf:"varint,2,opt,name=unlisted,proto3" json:"unlisted,omitempty"`
+	// is_spam is the spam status of an unlisted number; unlisting hides only the name.
+	IsSpam        bool `protobuf:"varint,3,opt,name=is_spam,json=isSpam,proto3" json:"is_spam,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupUserResponse) Reset() {
+	*x = LookupUserResponse{}
+	mi := &file_truecaller_v1_truecaller_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupUserResponse) ProtoMessage() {}
+
+func (x *LookupUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_truecaller_v1_truecaller_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupUserResponse.ProtoReflect.Descriptor instead.
+func (*LookupUserResponse) Descriptor() ([]byte, []int) {
+	return file_truecaller_v1_truecaller_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *LookupUserResponse) GetResult() *LookupResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *LookupUserResponse) GetUnlisted() bool {
+	if x != nil {
+		return x.Unlisted
+	}
+	return false
+}
+
+func (x *LookupUserResponse) GetIsSpam() bool {
+	if x != nil {
+		return x.IsSpam
+	}
+	return false
+}
+
+// LookupResult is the caller ID of a number.
+type LookupResult struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	PhoneNumber string                 `protobuf:"bytes,1,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	// name is the owner's display name if set, else the crowd-sourced name.
+	Name   string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	IsSpam bool   `protobuf:"varint,3,opt,name=is_spam,json=isSpam,proto3" json:"is_spam,omitempty"`
+	// person is name split into its parts.
+	Person *PersonName `protobuf:"bytes,4,opt,name=person,proto3" json:"person,omitempty"`
+	// alternatives are the top candidate names, most widely used first.
+	Alternatives  []*NameCandidate `protobuf:"bytes,5,rep,name=alternatives,proto3" json:"alternatives,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupResult) Reset() {
+	*x = LookupResult{}
+	mi := &file_truecaller_v1_truecaller_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupResult) ProtoMessage() {}
+
+func (x *LookupResult) ProtoReflect() protoreflect.Message {
+	mi := &file_truecaller_v1_truecaller_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupResult.ProtoReflect.Descriptor instead.
+func (*LookupResult) Descriptor() ([]byte, []int) {
+	return file_truecaller_v1_truecaller_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *LookupResult) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+func (x *LookupResult) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LookupResult) GetIsSpam() bool {
+	if x != nil {
+		return x.IsSpam
+	}
+	return false
+}
+
+func (x *LookupResult) GetPerson() *PersonName {
+	if x != nil {
+		return x.Person
+	}
+	return nil
+}
+
+func (x *LookupResult) GetAlternatives() []*NameCandidate {
+	if x != nil {
+		return x.Alternatives
+	}
+	return nil
+}
+
+// PersonName is a name split into honorific, given name, family name and organization.
+type PersonName struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Honorific     string                 `protobuf:"bytes,1,opt,name=honorific,proto3" json:"honorific,omitempty"`
+	GivenName     string                 `protobuf:"bytes,2,opt,name=given_name,json=givenName,proto3" json:"given_name,omitempty"`
+	FamilyName    string                 `protobuf:"bytes,3,opt,name=family_name,json=familyName,proto3" json:"family_name,omitempty"`
+	Organization  string                 `protobuf:"bytes,4,opt,name=organization,proto3" json:"organization,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PersonName) Reset() {
+	*x = PersonName{}
+	mi := &file_truecaller_v1_truecaller_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PersonName) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PersonName) ProtoMessage() {}
+
+func (x *PersonName) ProtoReflect() protoreflect.Message {
+	mi := &file_truecaller_v1_truecaller_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PersonName.ProtoReflect.Descriptor instead.
+func (*PersonName) Descriptor() ([]byte, []int) {
+	return file_truecaller_v1_truecaller_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PersonName) GetHonorific() string {
+	if x != nil {
+		return x.Honorific
+	}
+	return ""
+}
+
+func (x *PersonName) GetGivenName() string {
+	if x != nil {
+		return x.GivenName
+	}
+	return ""
+}
+
+func (x *PersonName) GetFamilyName() string {
+	if x != nil {
+		return x.FamilyName
+	}
+	return ""
+}
+
+func (x *PersonName) GetOrganization() string {
+	if x != nil {
+		return x.Organization
+	}
+	return ""
+}
+
+// NameCandidate is one name a number is saved under, with how many distinct users saved it so.
+type NameCandidate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Uploaders     int32                  `protobuf:"varint,2,opt,name=uploaders,proto3" json:"uploaders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NameCandidate) Reset() {
+	*x = NameCandidate{}
+	mi := &file_truecaller_v1_truecaller_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NameCandidate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NameCandidate) ProtoMessage() {}
+
+func (x *NameCandidate) ProtoReflect() protoreflect.Message {
+	mi := &file_truecaller_v1_truecaller_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NameCandidate.ProtoReflect.Descriptor instead.
+func (*NameCandidate) Descriptor() ([]byte, []int) {
+	return file_truecaller_v1_truecaller_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *NameCandidate) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NameCandidate) GetUploaders() int32 {
+	if x != nil {
+		return x.Uploaders
+	}
+	return 0
+}
+
+type BatchLookupUsersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// phone_numbers to look up, at most 20.
+	PhoneNumbers  []string `protobuf:"bytes,1,rep,name=phone_numbers,json=phoneNumbers,proto3" json:"phone_numbers,omitempty"`
+	Alternatives  int32    `protobuf:"varint,2,opt,name=alternatives,proto3" json:"alternatives,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchLookupUsersRequest) Reset() {
+	*x = BatchLookupUsersRequest{}
+	mi := &file_truecaller_v1_truecaller_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchLookupUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchLookupUsersRequest) ProtoMessage() {}
+
+func (x *BatchLookupUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_truecaller_v1_truecaller_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchLookupUsersRequest.ProtoReflect.Descriptor instead.
+func (*BatchLookupUsersRequest) Descriptor() ([]byte, []int) {
+	return file_truecaller_v1_truecaller_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BatchLookupUsersRequest) GetPhoneNumbers() []string {
+	if x != nil {
+		return x.PhoneNumbers
+	}
+	return nil
+}
+
+func (x *BatchLookupUsersRequest) GetAlternatives() int32 {
+	if x != nil {
+		return x.Alternatives
+	}
+	return 0
+}
+
+// BatchLookupUsersResponse has one entry per requested number, in request order.
+type BatchLookupUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*BatchLookupEntry    `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchLookupUsersResponse) Reset() {
+	*x = BatchLookupUsersResponse{}
+	mi := &file_truecaller_v1_truecaller_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchLookupUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchLookupUsersResponse) ProtoMessage() {}
+
+func (x *BatchLookupUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_truecaller_v1_truecaller_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchLookupUsersResponse.ProtoReflect.Descriptor instead.
+func (*BatchLookupUsersResponse) Descriptor() ([]byte, []int) {
+	return file_truecaller_v1_truecaller_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BatchLookupUsersResponse) GetEntries() []*BatchLookupEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type BatchLookupEntry struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	PhoneNumber string                 `protobuf:"bytes,1,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	// Types that are valid to be assigned to Outcome:
+	//
+	//	*BatchLookupEntry_Result
+	//	*BatchLookupEntry_Unlisted
+	//	*BatchLookupEntry_Error
+	Outcome isBatchLookupEntry_Outcome `protobuf_oneof:"outcome"`
+	// is_spam is the spam status of an unlisted number; unlisting hides only the name.
+	IsSpam        bool `protobuf:"varint,5,opt,name=is_spam,json=isSpam,proto3" json:"is_spam,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchLookupEntry) Reset() {
+	*x = BatchLookupEntry{}
+	mi := &file_truecaller_v1_truecaller_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchLookupEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchLookupEntry) ProtoMessage() {}
+
+func (x *BatchLookupEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_truecaller_v1_truecaller_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchLookupEntry.ProtoReflect.Descriptor instead.
+func (*BatchLookupEntry) Descriptor() ([]byte, []int) {
+	return file_truecaller_v1_truecaller_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BatchLookupEntry) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+func (x *BatchLookupEntry) GetOutcome() isBatchLookupEntry_Outcome {
+	if x != nil {
+		return x.Outcome
+	}
+	return nil
+}
+
+func (x *BatchLookupEntry) GetResult() *LookupResult {
+	if x != nil {
+		if x, ok := x.Outcome.(*BatchLookupEntry_Result); ok {
+			return x.Result
+		}
+	}
+	return nil
+}
+
+func (x *BatchLookupEntry) GetUnlisted() bool {
+	if x != nil {
+		if x, ok := x.Outcome.(*BatchLookupEntry_Unlisted); ok {
+			return x.Unlisted
+		}
+	}
+	return false
+}
+
+func (x *BatchLookupEntry) GetError() *LookupError {
+	if x != nil {
+		if x, ok := x.Outcome.(*BatchLookupEntry_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+func (x *BatchLookupEntry) GetIsSpam() bool {
+	if x != nil {
+		return x.IsSpam
+	}
+	return false
+}
+
+type isBatchLookupEntry_Outcome interface {
+	isBatchLookupEntry_Outcome()
+}
+
+type BatchLookupEntry_Result struct {
+	Result *LookupResult `protobuf:"bytes,2,opt,name=result,proto3,oneof"`
+}
+
+type BatchLookupEntry_Unlisted struct {
+	// unlisted reports that the owner of the number opted out of caller ID.
+	Unlisted bool `protobuf:"varint,3,opt,name=unlisted,proto3,oneof"`
+}
+
+type BatchLookupEntry_Error struct {
+	// error explains why the number could not be looked up, e.g. code "not_found".
+	Error *LookupError `protobuf:"bytes,4,opt,name=error,proto3,oneof"`
+}
+
+func (*BatchLookupEntry_Result) isBatchLookupEntry_Outcome() {}
+
+func (*BatchLookupEntry_Unlisted) isBatchLookupEntry_Outcome() {}
+
+func (*BatchLookupEntry_Error) isBatchLookupEntry_Outcome() {}
+
+// LookupError is why one number of a batch could not be looked up. code is "not_found" or "validation_failed".
+type LookupError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupError) Reset() {
+	*x = LookupError{}
+	mi := &file_truecaller_v1_truecaller_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupError) ProtoMessage() {}
+
+func (x *LookupError) ProtoReflect() protoreflect.Message {
+	mi := &file_truecaller_v1_truecaller_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupError.ProtoReflect.Descriptor instead.
+func (*LookupError) Descriptor() ([]byte, []int) {
+	return file_truecaller_v1_truecaller_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *LookupError) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *LookupError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ReportSpamRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	ReporterPhoneNumber string                 `protobuf:"bytes,1,opt,name=reporter_phone_number,json=reporterPhoneNumber,proto3" json:"reporter_phone_number,omitempty"`
+	PhoneNumber         string                 `protobuf:"bytes,2,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	// reason is an optional free-text reason.
+	Reason        string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportSpamRequest) Reset() {
+	*x = ReportSpamRequest{}
+	mi := &file_truecaller_v1_truecaller_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportSpamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportSpamRequest) ProtoMessage() {}
+
+func (x *ReportSpamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_truecaller_v1_truecaller_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportSpamRequest.ProtoReflect.Descriptor instead.
+func (*ReportSpamRequest) Descriptor() ([]byte, []int) {
+	return file_truecaller_v1_truecaller_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ReportSpamRequest) GetReporterPhoneNumber() string {
+	if x != nil {
+		return x.ReporterPhoneNumber
+	}
+	return ""
+}
+
+func (x *ReportSpamRequest) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+func (x *ReportSpamRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ReportSpamResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportSpamResponse) Reset() {
+	*x = ReportSpamResponse{}
+	mi := &file_truecaller_v1_truecaller_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportSpamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportSpamResponse) ProtoMessage() {}
+
+func (x *ReportSpamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_truecaller_v1_truecaller_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportSpamResponse.ProtoReflect.Descriptor instead.
+func (*ReportSpamResponse) Descriptor() ([]byte, []int) {
+	return file_truecaller_v1_truecaller_proto_rawDescGZIP(), []int{15}
+}
+
+var File_truecaller_v1_truecaller_proto protoreflect.FileDescriptor
+
+const file_truecaller_v1_truecaller_proto_rawDesc = "" +
+	"\n" +
+	"\x1etruecaller/v1/truecaller.proto\x12\rtruecaller.v1\"@\n" +
+	"\aContact\x12!\n" +
+	"\fphone_number\x18\x01 \x01(\tR\vphoneNumber\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\x93\x01\n" +
+	"\x15UploadContactsRequest\x12,\n" +
+	"\x12owner_phone_number\x18\x01 \x01(\tR\x10ownerPhoneNumber\x122\n" +
+	"\bcontacts\x18\x02 \x03(\v2\x16.truecaller.v1.ContactR\bcontacts\x12\x18\n" +
+	"\apartial\x18\x03 \x01(\bR\apartial\"\x86\x01\n" +
+	"\x16UploadContactsResponse\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x05R\x05total\x12\x1a\n" +
+	"\baccepted\x18\x02 \x01(\x05R\baccepted\x12:\n" +
+	"\brejected\x18\x03 \x03(\v2\x1e.truecaller.v1.RejectedContactR\brejected\"\x92\x01\n" +
+	"\x0fRejectedContact\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x120\n" +
+	"\acontact\x18\x02 \x01(\v2\x16.truecaller.v1.ContactR\acontact\x127\n" +
+	"\areasons\x18\x03 \x03(\v2\x1d.truecaller.v1.FieldViolationR\areasons\"T\n" +
+	"\x0eFieldViolation\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"Z\n" +
+	"\x11LookupUserRequest\x12!\n" +
+	"\fphone_number\x18\x01 \x01(\tR\vphoneNumber\x12\"\n" +
+	"\falternatives\x18\x02 \x01(\x05R\falternatives\"~\n" +
+	"\x12LookupUserResponse\x123\n" +
+	"\x06result\x18\x01 \x01(\v2\x1b.truecaller.v1.LookupResultR\x06result\x12\x1a\n" +
+	"\bunlisted\x18\x02 \x01(\bR\bunlisted\x12\x17\n" +
+	"\ais_spam\x18\x03 \x01(\bR\x06isSpam\"\xd3\x01\n" +
+	"\fLookupResult\x12!\n" +
+	"\fphone_number\x18\x01 \x01(\tR\vphoneNumber\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x17\n" +
+	"\ais_spam\x18\x03 \x01(\bR\x06isSpam\x121\n" +
+	"\x06person\x18\x04 \x01(\v2\x19.truecaller.v1.PersonNameR\x06person\x12@\n" +
+	"\falternatives\x18\x05 \x03(\v2\x1c.truecaller.v1.NameCandidateR\falternatives\"\x8e\x01\n" +
+	"\n" +
+	"PersonName\x12\x1c\n" +
+	"\thonorific\x18\x01 \x01(\tR\thonorific\x12\x1d\n" +
+	"\n" +
+	"given_name\x18\x02 \x01(\tR\tgivenName\x12\x1f\n" +
+	"\vfamily_name\x18\x03 \x01(\tR\n" +
+	"familyName\x12\"\n" +
+	"\forganization\x18\x04 \x01(\tR\forganization\"A\n" +
+	"\rNameCandidate\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tuploaders\x18\x02 \x01(\x05R\tuploaders\"b\n" +
+	"\x17BatchLookupUsersRequest\x12#\n" +
+	"\rphone_numbers\x18\x01 \x03(\tR\fphoneNumbers\x12\"\n" +
+	"\falternatives\x18\x02 \x01(\x05R\falternatives\"U\n" +
+	"\x18BatchLookupUsersResponse\x129\n" +
+	"\aentries\x18\x01 \x03(\v2\x1f.truecaller.v1.BatchLookupEntryR\aentries\"\xe2\x01\n" +
+	"\x10BatchLookupEntry\x12!\n" +
+	"\fphone_number\x18\x01 \x01(\tR\vphoneNumber\x125\n" +
+	"\x06result\x18\x02 \x01(\v2\x1b.truecaller.v1.LookupResultH\x00R\x06result\x12\x1c\n" +
+	"\bunlisted\x18\x03 \x01(\bH\x00R\bunlisted\x122\n" +
+	"\x05error\x18\x04 \x01(\v2\x1a.truecaller.v1.LookupErrorH\x00R\x05error\x12\x17\n" +
+	"\ais_spam\x18\x05 \x01(\bR\x06isSpamB\t\n" +
+	"\aoutcome\";\n" +
+	"\vLookupError\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x82\x01\n" +
+	"\x11ReportSpamRequest\x122\n" +
+	"\x15reporter_phone_number\x18\x01 \x01(\tR\x13reporterPhoneNumber\x12!\n" +
+	"\fphone_number\x18\x02 \x01(\tR\vphoneNumber\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\x14\n" +
+	"\x12ReportSpamResponse2\xa4\x02\n" +
+	"\vUserService\x12]\n" +
+	"\x0eUploadContacts\x12$.truecaller.v1.UploadContactsRequest\x1a%.truecaller.v1.UploadContactsResponse\x12Q\n" +
+	"\n" +
+	"LookupUser\x12 .truecaller.v1.LookupUserRequest\x1a!.truecaller.v1.LookupUserResponse\x12c\n" +
+	"\x10BatchLookupUsers\x12&.truecaller.v1.BatchLookupUsersRequest\x1a'.truecaller.v1.BatchLookupUsersResponse2`\n" +
+	"\vSpamService\x12Q\n" +
+	"\n" +
+	"ReportSpam\x12 .truecaller.v1.ReportSpamRequest\x1a!.truecaller.v1.ReportSpamResponseBOZMgithub.com/yourusername/truecaller-lite/pkg/grpcapi/truecallerv1;truecallerv1b\x06proto3"
+
+var (
+	file_truecaller_v1_truecaller_proto_rawDescOnce sync.Once
+	file_truecaller_v1_truecaller_proto_rawDescData []byte
+)
+
+func file_truecaller_v1_truecaller_proto_rawDescGZIP() []byte {
+	file_truecaller_v1_truecaller_proto_rawDescOnce.Do(func() {
+		file_truecaller_v1_truecaller_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_truecaller_v1_truecaller_proto_rawDesc), len(file_truecaller_v1_truecaller_proto_rawDesc)))
+	})
+	return file_truecaller_v1_truecaller_proto_rawDescData
+}
+
+var file_truecaller_v1_truecaller_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_truecaller_v1_truecaller_proto_goTypes = []any{
+	(*Contact)(nil),                  // 0: truecaller.v1.Contact
+	(*UploadContactsRequest)(nil),    // 1: truecaller.v1.UploadContactsRequest
+	(*UploadContactsResponse)(nil),   // 2: truecaller.v1.UploadContactsResponse
+	(*RejectedContact)(nil),          // 3: truecaller.v1.RejectedContact
+	(*FieldViolation)(nil),           // 4: truecaller.v1.FieldViolation
+	(*LookupUserRequest)(nil),        // 5: truecaller.v1.LookupUserRequest
+	(*LookupUserResponse)(nil),       // 6: truecaller.v1.LookupUserResponse
+	(*LookupResult)(nil),             // 7: truecaller.v1.LookupResult
+	(*PersonName)(nil),               // 8: truecaller.v1.PersonName
+	(*NameCandidate)(nil),            // 9: truecaller.v1.NameCandidate
+	(*BatchLookupUsersRequest)(nil),  // 10: truecaller.v1.BatchLookupUsersRequest
+	(*BatchLookupUsersResponse)(nil), // 11: truecaller.v1.BatchLookupUsersResponse
+	(*BatchLookupEntry)(nil),         // 12: truecaller.v1.BatchLookupEntry
+	(*LookupError)(nil),              // 13: truecaller.v1.LookupError
+	(*ReportSpamRequest)(nil),        // 14: truecaller.v1.ReportSpamRequest
+	(*ReportSpamResponse)(nil),       // 15: truecaller.v1.ReportSpamResponse
+}
+var file_truecaller_v1_truecaller_proto_depIdxs = []int32{
+	0,  // 0: truecaller.v1.UploadContactsRequest.contacts:type_name -> truecaller.v1.Contact
+	3,  // 1: truecaller.v1.UploadContactsResponse.rejected:type_name -> truecaller.v1.RejectedContact
+	0,  // 2: truecaller.v1.RejectedContact.contact:type_name -> truecaller.v1.Contact
+	4,  // 3: truecaller.v1.RejectedContact.reasons:type_name -> truecaller.v1.FieldViolation
+	7,  // 4: truecaller.v1.LookupUserResponse.result:type_name -> truecaller.v1.LookupResult
+	8,  // 5: truecaller.v1.LookupResult.person:type_name -> truecaller.v1.PersonName
+	9,  // 6: truecaller.v1.LookupResult.alternatives:type_name -> truecaller.v1.NameCandidate
+	12, // 7: truecaller.v1.BatchLookupUsersResponse.entries:type_name -> truecaller.v1.BatchLookupEntry
+	7,  // 8: truecaller.v1.BatchLookupEntry.result:type_name -> truecaller.v1.LookupResult
+	13, // 9: truecaller.v1.BatchLookupEntry.error:type_name -> truecaller.v1.LookupError
+	1,  // 10: truecaller.v1.UserService.UploadContacts:input_type -> truecaller.v1.UploadContactsRequest
+	5,  // 11: truecaller.v1.UserService.LookupUser:input_type -> truecaller.v1.LookupUserRequest
+	10, // 12: truecaller.v1.UserService.BatchLookupUsers:input_type -> truecaller.v1.BatchLookupUsersRequest
+	14, // 13: truecaller.v1.SpamService.ReportSpam:input_type -> truecaller.v1.ReportSpamRequest
+	2,  // 14: truecaller.v1.UserService.UploadContacts:output_type -> truecaller.v1.UploadContactsResponse
+	6,  // 15: truecaller.v1.UserService.LookupUser:output_type -> truecaller.v1.LookupUserResponse
+	11, // 16: truecaller.v1.UserService.BatchLookupUsers:output_type -> truecaller.v1.BatchLookupUsersResponse
+	15, // 17: truecaller.v1.SpamService.ReportSpam:output_type -> truecaller.v1.ReportSpamResponse
+	14, // [14:18] is the sub-list for method output_type
+	10, // [10:14] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_truecaller_v1_truecaller_proto_init() }
+func file_truecaller_v1_truecaller_proto_init() {
+	if File_truecaller_v1_truecaller_proto != nil {
+		return
+	}
+	file_truecaller_v1_truecaller_proto_msgTypes[12].OneofWrappers = []any{
+		(*BatchLookupEntry_Result)(nil),
+		(*BatchLookupEntry_Unlisted)(nil),
+		(*BatchLookupEntry_Error)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_truecaller_v1_truecaller_proto_rawDesc), len(file_truecaller_v1_truecaller_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_truecaller_v1_truecaller_proto_goTypes,
+		DependencyIndexes: file_truecaller_v1_truecaller_proto_depIdxs,
+		MessageInfos:      file_truecaller_v1_truecaller_proto_msgTypes,
+	}.Build()
+	File_truecaller_v1_truecaller_proto = out.File
+	file_truecaller_v1_truecaller_proto_goTypes = nil
+	file_truecaller_v1_truecaller_proto_depIdxs = nil
+}
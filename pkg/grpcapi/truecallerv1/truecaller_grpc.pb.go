@@ -0,0 +1,338 @@
+// gRPC API of TrueCaller-Lite, mirroring the contact upload, lookup and spam reporting parts of the HTTP API.
+// Generate the Go code with "buf generate" from the repository root (see buf.gen.yaml).
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: truecaller/v1/truecaller.proto
+
+package truecallerv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	UserService_UploadContacts_FullMethodName   = "/truecaller.v1.UserService/UploadContacts"
+	UserService_LookupUser_FullMethodName       = "/truecaller.v1.UserService/LookupUser"
+	UserService_BatchLookupUsers_FullMethodName = "/truecaller.v1.UserService/BatchLookupUsers"
+)
+
+// UserServiceClient is the client API for UserService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// UserService uploads phone books and looks up caller ID.
+//
+// Errors use the standard gRPC status codes: INVALID_ARGUMENT for validation failures (with a
+// google.rpc.BadRequest detail listing each field violation), NOT_FOUND for unknown numbers, UNAUTHENTICATED and
+// PERMISSION_DENIED for missing or wrong credentials, RESOURCE_EXHAUSTED for upload and rate limits and throttled
+// lookups (with a google.rpc.RetryInfo detail), PERMISSION_DENIED for clients blocked for enumerating numbers, and
+// DEADLINE_EXCEEDED or CANCELED when the call's deadline passes or it is canceled.
+type UserServiceClient interface {
+	// UploadContacts replaces the phone book of owner_phone_number. Owner only: the call must carry an
+	// "authorization: Bearer <token>" metadata entry for that number.
+	UploadContacts(ctx context.Context, in *UploadContactsRequest, opts ...grpc.CallOption) (*UploadContactsResponse, error)
+	// LookupUser returns the name and spam status of a number.
+	LookupUser(ctx context.Context, in *LookupUserRequest, opts ...grpc.CallOption) (*LookupUserResponse, error)
+	// BatchLookupUsers looks up several numbers at once. The call must carry an "authorization: Bearer <token>"
+	// metadata entry for a verified number. Each number counts against the rate limits and enumeration screening
+	// as one lookup. A number that cannot be looked up gets an error entry instead of failing the whole call.
+	BatchLookupUsers(ctx context.Context, in *BatchLookupUsersRequest, opts ...grpc.CallOption) (*BatchLookupUsersResponse, error)
+}
+
+type userServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUserServiceClient(cc grpc.ClientConnInterface) UserServiceClient {
+	return &userServiceClient{cc}
+}
+
+func (c *userServiceClient) UploadContacts(ctx context.Context, in *UploadContactsRequest, opts ...grpc.CallOption) (*UploadContactsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadContactsResponse)
+	err := c.cc.Invoke(ctx, UserService_UploadContacts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) LookupUser(ctx context.Context, in *LookupUserRequest, opts ...grpc.CallOption) (*LookupUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LookupUserResponse)
+	err := c.cc.Invoke(ctx, UserService_LookupUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) BatchLookupUsers(ctx context.Context, in *BatchLookupUsersRequest, opts ...grpc.CallOption) (*BatchLookupUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchLookupUsersResponse)
+	err := c.cc.Invoke(ctx, UserService_BatchLookupUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UserServiceServer is the server API for UserService service.
+// All implementations must embed UnimplementedUserServiceServer
+// for forward compatibility.
+//
+// UserService uploads phone books and looks up caller ID.
+//
+// Errors use the standard gRPC status codes: INVALID_ARGUMENT for validation failures (with a
+// google.rpc.BadRequest detail listing each field violation), NOT_FOUND for unknown numbers, UNAUTHENTICATED and
+// PERMISSION_DENIED for missing or wrong credentials, RESOURCE_EXHAUSTED for upload and rate limits and throttled
+// lookups (with a google.rpc.RetryInfo detail), PERMISSION_DENIED for clients blocked for enumerating numbers, and
+// DEADLINE_EXCEEDED or CANCELED when the call's deadline passes or it is canceled.
+type UserServiceServer interface {
+	// UploadContacts replaces the phone book of owner_phone_number. Owner only: the call must carry an
+	// "authorization: Bearer <token>" metadata entry for that number.
+	UploadContacts(context.Context, *UploadContactsRequest) (*UploadContactsResponse, error)
+	// LookupUser returns the name and spam status of a number.
+	LookupUser(context.Context, *LookupUserRequest) (*LookupUserResponse, error)
+	// BatchLookupUsers looks up several numbers at once. The call must carry an "authorization: Bearer <token>"
+	// metadata entry for a verified number. Each number counts against the rate limits and enumeration screening
+	// as one lookup. A number that cannot be looked up gets an error entry instead of failing the whole call.
+	BatchLookupUsers(context.Context, *BatchLookupUsersRequest) (*BatchLookupUsersResponse, error)
+	mustEmbedUnimplementedUserServiceServer()
+}
+
+// UnimplementedUserServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedUserServiceServer struct{}
+
+func (UnimplementedUserServiceServer) UploadContacts(context.Context, *UploadContactsRequest) (*UploadContactsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadContacts not implemented")
+}
+func (UnimplementedUserServiceServer) LookupUser(context.Context, *LookupUserRequest) (*LookupUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LookupUser not implemented")
+}
+func (UnimplementedUserServiceServer) BatchLookupUsers(context.Context, *BatchLookupUsersRequest) (*BatchLookupUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchLookupUsers not implemented")
+}
+func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
+func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UserServiceServer will
+// result in compilation errors.
+type UnsafeUserServiceServer interface {
+	mustEmbedUnimplementedUserServiceServer()
+}
+
+func RegisterUserServiceServer(s grpc.ServiceRegistrar, srv UserServiceServer) {
+	// If the following call pancis, it indicates UnimplementedUserServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&UserService_ServiceDesc, srv)
+}
+
+func _UserService_UploadContacts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadContactsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UploadContacts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UploadContacts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UploadContacts(ctx, req.(*UploadContactsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_LookupUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookupUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).LookupUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_LookupUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).LookupUser(ctx, req.(*LookupUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_BatchLookupUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchLookupUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).BatchLookupUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_BatchLookupUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).BatchLookupUsers(ctx, req.(*BatchLookupUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var UserService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "truecaller.v1.UserService",
+	HandlerType: (*UserServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "UploadContacts",
+			Handler:    _UserService_UploadContacts_Handler,
+		},
+		{
+			MethodName: "LookupUser",
+			Handler:    _UserService_LookupUser_Handler,
+		},
+		{
+			MethodName: "BatchLookupUsers",
+			Handler:    _UserService_BatchLookupUsers_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "truecaller/v1/truecaller.proto",
+}
+
+const (
+	SpamService_ReportSpam_FullMethodName = "/truecaller.v1.SpamService/ReportSpam"
+)
+
+// SpamServiceClient is the client API for SpamService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// SpamService collects spam reports.
+type SpamServiceClient interface {
+	// ReportSpam records that reporter_phone_number reports phone_number as spam. Reporter only: the call must
+	// carry an "authorization: Bearer <token>" metadata entry for the reporter's number.
+	ReportSpam(ctx context.Context, in *ReportSpamRequest, opts ...grpc.CallOption) (*ReportSpamResponse, error)
+}
+
+type spamServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSpamServiceClient(cc grpc.ClientConnInterface) SpamServiceClient {
+	return &spamServiceClient{cc}
+}
+
+func (c *spamServiceClient) ReportSpam(ctx context.Context, in *ReportSpamRequest, opts ...grpc.CallOption) (*ReportSpamResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportSpamResponse)
+	err := c.cc.Invoke(ctx, SpamService_ReportSpam_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SpamServiceServer is the server API for SpamService service.
+// All implementations must embed UnimplementedSpamServiceServer
+// for forward compatibility.
+//
+// SpamService collects spam reports.
+type SpamServiceServer interface {
+	// ReportSpam records that reporter_phone_number reports phone_number as spam. Reporter only: the call must
+	// carry an "authorization: Bearer <token>" metadata entry for the reporter's number.
+	ReportSpam(context.Context, *ReportSpamRequest) (*ReportSpamResponse, error)
+	mustEmbedUnimplementedSpamServiceServer()
+}
+
+// UnimplementedSpamServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSpamServiceServer struct{}
+
+func (UnimplementedSpamServiceServer) ReportSpam(context.Context, *ReportSpamRequest) (*ReportSpamResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportSpam not implemented")
+}
+func (UnimplementedSpamServiceServer) mustEmbedUnimplementedSpamServiceServer() {}
+func (UnimplementedSpamServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeSpamServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SpamServiceServer will
+// result in compilation errors.
+type UnsafeSpamServiceServer interface {
+	mustEmbedUnimplementedSpamServiceServer()
+}
+
+func RegisterSpamServiceServer(s grpc.ServiceRegistrar, srv SpamServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSpamServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SpamService_ServiceDesc, srv)
+}
+
+func _SpamService_ReportSpam_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportSpamRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SpamServiceServer).ReportSpam(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SpamService_ReportSpam_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SpamServiceServer).ReportSpam(ctx, req.(*ReportSpamRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SpamService_ServiceDesc is the grpc.ServiceDesc for SpamService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SpamService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "truecaller.v1.SpamService",
+	HandlerType: (*SpamServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReportSpam",
+			Handler:    _SpamService_ReportSpam_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "truecaller/v1/truecaller.proto",
+}
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao/mem"
+	"github.com/yourusername/truecaller-lite/pkg/enumeration"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+func TestHandler_EnumerationDetector(t *testing.T) {
+	offenders := mem.NewOffenderMemDAO()
+	detector := enumeration.NewDetector(offenders, enumeration.Options{MinLookups: 5, BlockAfter: 2})
+	h, _, _ := newTestHandler(WithEnumerationDetector(detector, KeyByIP))
+	lookup := func(ip, phone string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v1/users/"+phone, nil)
+		req.RemoteAddr = ip + ":1234"
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for i := 0; i < 5; i++ {
+		if rec := lookup("10.0.0.1", fmt.Sprintf("9198765%05d", i)); rec.Code != http.StatusNotFound {
+			t.Fatalf("lookup %d: expected status %d, got %d", i, http.StatusNotFound, rec.Code)
+		}
+	}
+	rec := lookup("10.0.0.1", "919876500005")
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") == "" {
+		t.Fatalf("expected throttled scanner, got %d %q", rec.Code, rec.Header().Get("Retry-After"))
+	}
+	if rec := lookup("10.0.0.2", "919876500005"); rec.Code != http.StatusNotFound {
+		t.Errorf("expected other clients to be unaffected, got %d", rec.Code)
+	}
+	for i := 0; i < 5; i++ {
+		detector.Record(context.Background(), "10.0.0.1", fmt.Sprintf("9198765%05d", 100+i), false)
+	}
+	if rec := lookup("10.0.0.1", "919876500006"); rec.Code != http.StatusForbidden {
+		t.Errorf("expected blocked scanner, got %d", rec.Code)
+	}
+	offender, _ := offenders.GetOffender(context.Background(), "10.0.0.1")
+	if offender == nil || offender.Action != models.ActionBlock {
+		t.Errorf("expected offender recorded for review, got %+v", offender)
+	}
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/yourusername/truecaller-lite/pkg/auth"
+	"github.com/yourusername/truecaller-lite/pkg/dao/daoerrors"
+	"github.com/yourusername/truecaller-lite/pkg/enumeration"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+	"github.com/yourusername/truecaller-lite/pkg/otp"
+	"github.com/yourusername/truecaller-lite/pkg/ratelimit"
+	"github.com/yourusername/truecaller-lite/pkg/service"
+)
+
+// Error codes returned in models.ErrorResponse, in addition to models.ErrCodeValidationFailed.
+const (
+	codeBadRequest           = "bad_request"
+	codeNotFound             = "not_found"
+	codeUnauthorized         = "unauthorized"
+	codeForbidden            = "forbidden"
+	codeBlocked              = "blocked"
+	codeTooManyRequests      = "too_many_requests"
+	codeLimitExceeded        = "limit_exceeded"
+	codeUnsupportedMediaType = "unsupported_media_type"
+	codeUnavailable          = "unavailable"
+	codeInternal             = "internal"
+)
+
+// requestError is an error caused by a malformed request rather than a business rule.
+type requestError struct {
+	message string
+}
+
+func (e *requestError) Error() string { return e.message }
+
+// badRequest returns an error rendered as 400 Bad Request.
+func badRequest(message string) error {
+	return &requestError{message: message}
+}
+
+// writeError maps err to an HTTP status and a models.ErrorResponse body.
+func writeError(w http.ResponseWriter, err error) {
+	status, resp := errorResponse(w, err)
+	writeErrorResponse(w, status, resp)
+}
+
+// errorResponse maps err to an HTTP status and a models.ErrorResponse body, setting headers such as Retry-After on w.
+func errorResponse(w http.ResponseWriter, err error) (int, *models.ErrorResponse) {
+	if resp, ok := models.NewValidationErrorResponse(err); ok {
+		return http.StatusBadRequest, resp
+	}
+	var reqErr *requestError
+	var throttled *otp.ThrottledError
+	var limited *ratelimit.LimitedError
+	var denied *enumeration.DeniedError
+	switch {
+	case errors.As(err, &reqErr):
+		return http.StatusBadRequest, &models.ErrorResponse{Code: codeBadRequest, Message: reqErr.message}
+	case errors.Is(err, otp.ErrInvalidCode), errors.Is(err, otp.ErrCodeExpired), errors.Is(err, otp.ErrInvalidToken), errors.Is(err, auth.ErrInvalidToken):
+		return http.StatusUnauthorized, &models.ErrorResponse{Code: codeUnauthorized, Message: err.Error()}
+	case errors.As(err, &denied):
+		setRetryAfter(w, denied.RetryAfter)
+		if errors.Is(err, enumeration.ErrBlocked) {
+			return http.StatusForbidden, &models.ErrorResponse{Code: codeBlocked, Message: err.Error()}
+		}
+		return http.StatusTooManyRequests, &models.ErrorResponse{Code: codeTooManyRequests, Message: err.Error()}
+	case errors.As(err, &limited):
+		setRetryAfter(w, limited.RetryAfter)
+		return http.StatusTooManyRequests, &models.ErrorResponse{Code: codeTooManyRequests, Message: err.Error()}
+	case errors.As(err, &throttled):
+		setRetryAfter(w, throttled.RetryAfter)
+		return http.StatusTooManyRequests, &models.ErrorResponse{Code: codeTooManyRequests, Message: err.Error()}
+	case errors.Is(err, otp.ErrTooManyAttempts):
+		return http.StatusTooManyRequests, &models.ErrorResponse{Code: codeTooManyRequests, Message: err.Error()}
+	case errors.Is(err, ErrOwnerNotVerified):
+		return http.StatusForbidden, &models.ErrorResponse{Code: codeForbidden, Message: err.Error()}
+	case errors.Is(err, daoerrors.ErrUserNotFound), errors.Is(err, daoerrors.ErrPhoneBookNotFound):
+		return http.StatusNotFound, &models.ErrorResponse{Code: codeNotFound, Message: err.Error()}
+	case errors.Is(err, service.ErrRequestLimitExceeded), errors.Is(err, service.ErrOwnerLimitExceeded):
+		return http.StatusRequestEntityTooLarge, &models.ErrorResponse{Code: codeLimitExceeded, Message: err.Error()}
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return http.StatusServiceUnavailable, &models.ErrorResponse{Code: codeUnavailable, Message: err.Error()}
+	default:
+		return http.StatusInternalServerError, &models.ErrorResponse{Code: codeInternal, Message: "internal error"}
+	}
+}
+
+// setRetryAfter sets the Retry-After header to d rounded up to whole seconds.
+func setRetryAfter(w http.ResponseWriter, d time.Duration) {
+	w.Header().Set("Retry-After", strconv.Itoa(int((d+time.Second-1)/time.Second)))
+}
+
+// writeErrorResponse writes resp as a JSON error body with the given status.
+func writeErrorResponse(w http.ResponseWriter, status int, resp *models.ErrorResponse) {
+	writeJSON(w, status, resp)
+}
+
+// writeJSON writes v as a JSON body with the given status.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", contentTypeJSON)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,338 @@
+// Package handler exposes the service layer over HTTP.
+// Handlers only decode requests, call services and encode responses; business logic stays in the service layer.
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao/daoerrors"
+	"github.com/yourusername/truecaller-lite/pkg/enumeration"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+	"github.com/yourusername/truecaller-lite/pkg/otp"
+	"github.com/yourusername/truecaller-lite/pkg/service"
+	"github.com/yourusername/truecaller-lite/pkg/vcard"
+)
+
+// DefaultMaxBodyBytes bounds non-streaming request bodies when WithMaxBodyBytes is not used.
+const DefaultMaxBodyBytes = 10 << 20
+
+// Content types accepted by the contact upload endpoint.
+const (
+	contentTypeJSON   = "application/json"
+	contentTypeNDJSON = "application/x-ndjson"
+	contentTypeVCard  = "text/vcard"
+	contentTypeXVCard = "text/x-vcard"
+)
+
+// Handler routes HTTP requests to the service layer. It implements http.Handler.
+type Handler struct {
+	userService    service.UserService
+	privacyService service.PrivacyService
+	otpService     otp.Service
+	lookupLog      service.LookupLogService
+	searchService  service.SearchService
+	searchTokens   otp.TokenVerifier
+	tokenVerifier  otp.TokenVerifier
+	ownerVerifier  OwnerVerifier
+	streamOptions  service.StreamUploadOptions
+	maxBodyBytes   int64
+	rateLimits     map[string][]rateLimit
+	detector       *enumeration.Detector
+	detectorKey    RateLimitKey
+	mux            *http.ServeMux
+	patterns       []string // registered patterns, for checking the OpenAPI document
+}
+
+// Option configures a Handler.
+type Option func(*Handler)
+
+// WithStreamUploadOptions sets the chunk size and limits used for NDJSON uploads.
+func WithStreamUploadOptions(opts service.StreamUploadOptions) Option {
+	return func(h *Handler) { h.streamOptions = opts }
+}
+
+// WithPrivacyService enables the owner-only data export endpoint.
+func WithPrivacyService(privacyService service.PrivacyService) Option {
+	return func(h *Handler) { h.privacyService = privacyService }
+}
+
+// WithOTPService enables the phone number verification endpoints.
+func WithOTPService(otpService otp.Service) Option {
+	return func(h *Handler) { h.otpService = otpService }
+}
+
+// WithLookupLog records lookups made with a valid bearer token (verified by tokens) in lookupLog, and enables
+// the owner-only "who viewed me" endpoints. Lookups without a valid token stay anonymous and are not recorded.
+func WithLookupLog(lookupLog service.LookupLogService, tokens otp.TokenVerifier) Option {
+	return func(h *Handler) {
+		h.lookupLog = lookupLog
+		h.tokenVerifier = tokens
+	}
+}
+
+// WithSearchService enables name search for callers with a valid bearer token (verified by tokens). Searches
+// share the lookup rate limits and are screened by the enumeration detector like lookups.
+func WithSearchService(searchService service.SearchService, tokens otp.TokenVerifier) Option {
+	return func(h *Handler) {
+		h.searchService = searchService
+		h.searchTokens = tokens
+	}
+}
+
+// WithOwnerVerifier sets how owner-only endpoints verify the caller. By default every such request is rejected.
+func WithOwnerVerifier(verifier OwnerVerifier) Option {
+	return func(h *Handler) { h.ownerVerifier = verifier }
+}
+
+// WithEnumerationDetector screens lookups with detector, identifying clients by key. Throttled clients get
+// 429 Too Many Requests and blocked clients 403 Forbidden, both with a Retry-After header.
+func WithEnumerationDetector(detector *enumeration.Detector, key RateLimitKey) Option {
+	return func(h *Handler) {
+		h.detector = detector
+		h.detectorKey = key
+	}
+}
+
+// WithMaxBodyBytes bounds the size of non-streaming request bodies.
+func WithMaxBodyBytes(n int64) Option {
+	return func(h *Handler) { h.maxBodyBytes = n }
+}
+
+// NewHandler creates a Handler serving the public API.
+func NewHandler(userService service.UserService, opts ...Option) *Handler {
+	h := &Handler{userService: userService, ownerVerifier: denyAllOwners, maxBodyBytes: DefaultMaxBodyBytes, rateLimits: make(map[string][]rateLimit), mux: http.NewServeMux()}
+	for _, opt := range opts {
+		opt(h)
+	}
+	h.register("GET /openapi.json", h.serveOpenAPI)
+	h.handle(RouteUpload, "POST /v1/users/{phone}/contacts", h.ownerOnly(h.uploadContacts))
+	h.handle(RouteLookup, "GET /v1/users/{phone}", h.lookupUser)
+	h.handle(RoutePrivacy, "PUT /v1/users/{phone}/display-name", h.ownerOnly(h.setDisplayName))
+	if h.searchService != nil {
+		h.handle(RouteLookup, "GET /v1/search", h.searchUsers)
+	}
+	if h.otpService != nil {
+		h.handle(RouteOTP, "POST /v1/otp/request", h.requestOTP)
+		h.handle(RouteOTP, "POST /v1/otp/verify", h.verifyOTP)
+	}
+	if h.privacyService != nil {
+		h.handle(RoutePrivacy, "GET /v1/users/{phone}/export", h.ownerOnly(h.exportUserData))
+		h.handle(RoutePrivacy, "DELETE /v1/users/{phone}", h.ownerOnly(h.deleteUserData))
+		h.handle(RoutePrivacy, "POST /v1/users/{phone}/unlist", h.ownerOnly(h.setUnlisted(true)))
+		h.handle(RoutePrivacy, "POST /v1/users/{phone}/relist", h.ownerOnly(h.setUnlisted(false)))
+	}
+	if h.lookupLog != nil {
+		h.handle(RoutePrivacy, "GET /v1/users/{phone}/viewers", h.ownerOnly(h.listViewers))
+		h.handle(RoutePrivacy, "POST /v1/users/{phone}/viewers/opt-out", h.ownerOnly(h.setViewerOptOut(true)))
+		h.handle(RoutePrivacy, "POST /v1/users/{phone}/viewers/opt-in", h.ownerOnly(h.setViewerOptOut(false)))
+	}
+	return h
+}
+
+// handle registers next for pattern, applying the rate limits configured for route first.
+func (h *Handler) handle(route, pattern string, next http.HandlerFunc) {
+	h.register(pattern, h.rateLimited(route, next))
+}
+
+// register registers next for pattern without rate limits.
+func (h *Handler) register(pattern string, next http.HandlerFunc) {
+	h.patterns = append(h.patterns, pattern)
+	h.mux.HandleFunc(pattern, next)
+}
+
+// ownerOnly wraps next so it only runs for requests verified as coming from the owner of the {phone} path value.
+func (h *Handler) ownerOnly(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := h.ownerVerifier.VerifyOwner(r, r.PathValue("phone")); err != nil {
+			writeError(w, err)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// uploadContactsRequest is the JSON body of a contact upload.
+type uploadContactsRequest struct {
+	Contacts []models.Contact `json:"contacts"`
+}
+
+// uploadErrorResponse is the error body of a contact upload. A failed NDJSON stream also reports where it stopped
+// and what was stored before, since those contacts are kept.
+type uploadErrorResponse struct {
+	Code    string               `json:"code"`
+	Message string               `json:"message"`
+	Details []models.ErrorDetail `json:"details,omitempty"`
+	// StoppedAt is the index of the contact line the stream stopped at: lines before it are counted in Report,
+	// lines from it on were not read.
+	StoppedAt *int                 `json:"stopped_at,omitempty"`
+	Report    *models.UploadReport `json:"report,omitempty"`
+}
+
+// uploadContacts handles POST /v1/users/{phone}/contacts. Only the verified owner of {phone} may upload.
+// The body is either a JSON object with a contacts list or a vCard export (text/vcard), both replacing the
+// phone book, or, with Content-Type application/x-ndjson, one contact per line (merged into the phone book in chunks).
+// Query parameter mode=partial stores valid contacts and reports rejected ones instead of failing; if none is
+// valid, the stored phone book is kept.
+func (h *Handler) uploadContacts(w http.ResponseWriter, r *http.Request) {
+	owner := r.PathValue("phone")
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		mediaType = contentTypeJSON
+	}
+	switch mediaType {
+	case contentTypeNDJSON:
+		report, err := h.userService.UploadContactsStream(r.Context(), owner, r.Body, h.streamOptions)
+		if err != nil {
+			writeStreamError(w, err, report)
+			return
+		}
+		writeJSON(w, http.StatusOK, report)
+	case contentTypeJSON:
+		var req uploadContactsRequest
+		if err := decodeJSONBody(w, r, h.maxBodyBytes, &req); err != nil {
+			writeError(w, err)
+			return
+		}
+		h.storeContacts(w, r, owner, req.Contacts)
+	case contentTypeVCard, contentTypeXVCard:
+		contacts, err := vcard.Parse(http.MaxBytesReader(w, r.Body, h.maxBodyBytes))
+		if err != nil {
+			writeError(w, bodyError(err))
+			return
+		}
+		h.storeContacts(w, r, owner, contacts)
+	default:
+		writeErrorResponse(w, http.StatusUnsupportedMediaType, &models.ErrorResponse{Code: codeUnsupportedMediaType, Message: "unsupported content type: " + mediaType})
+	}
+}
+
+// writeStreamError writes err like writeError, adding the report of what a failed NDJSON stream stored, if any.
+func writeStreamError(w http.ResponseWriter, err error, report *models.UploadReport) {
+	status, resp := errorResponse(w, err)
+	if report == nil {
+		writeErrorResponse(w, status, resp)
+		return
+	}
+	writeJSON(w, status, &uploadErrorResponse{Code: resp.Code, Message: resp.Message, Details: resp.Details, StoppedAt: &report.Total, Report: report})
+}
+
+// storeContacts uploads decoded contacts in the mode requested by the mode query parameter.
+func (h *Handler) storeContacts(w http.ResponseWriter, r *http.Request, owner string, contacts []models.Contact) {
+	if r.URL.Query().Get("mode") == "partial" {
+		report, err := h.userService.UploadContactsPartial(r.Context(), owner, contacts)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, report)
+		return
+	}
+	if err := h.userService.UploadContacts(r.Context(), owner, contacts); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, &models.UploadReport{Total: len(contacts), Accepted: len(contacts), Rejected: []models.RejectedContact{}})
+}
+
+// decodeJSONBody decodes a size-bounded JSON request body into v.
+func decodeJSONBody(w http.ResponseWriter, r *http.Request, maxBytes int64, v any) error {
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBytes)).Decode(v); err != nil {
+		return bodyError(fmt.Errorf("invalid JSON body: %w", err))
+	}
+	return nil
+}
+
+// bodyError classifies a request body decoding error: oversized bodies are reported as
+// service.ErrRequestLimitExceeded, anything else as a bad request.
+func bodyError(err error) error {
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		return service.ErrRequestLimitExceeded
+	}
+	return badRequest(err.Error())
+}
+
+// lookupResponse is the JSON body returned by a lookup.
+type lookupResponse struct {
+	PhoneNumber  string                 `json:"phone_number"`
+	Name         string                 `json:"name"`
+	IsSpam       bool                   `json:"is_spam"`
+	Person       *models.PersonName     `json:"person,omitempty"`
+	Alternatives []models.NameCandidate `json:"alternatives,omitempty"`
+}
+
+// unlistedResponse is the privacy response returned instead of a name for unlisted numbers.
+// Unlisting hides only the name, so the spam status is still returned.
+type unlistedResponse struct {
+	PhoneNumber string `json:"phone_number"`
+	Unlisted    bool   `json:"unlisted"`
+	IsSpam      bool   `json:"is_spam"`
+	Message     string `json:"message"`
+}
+
+// lookupUser handles GET /v1/users/{phone}?alternatives=N.
+// The response splits the name into its parts (honorific, given and family name, organization); with
+// alternatives > 0 it also lists the top candidate names with their distinct uploader counts.
+// Unlisted numbers get a 200 privacy response with the spam status but without a name.
+func (h *Handler) lookupUser(w http.ResponseWriter, r *http.Request) {
+	phone := r.PathValue("phone")
+	alternatives, err := queryInt(r, "alternatives")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	client, screened, err := h.screen(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp, err := h.lookup(r, phone, alternatives)
+	if screened && (err == nil || errors.Is(err, service.ErrUnlisted) || errors.Is(err, daoerrors.ErrUserNotFound)) {
+		h.detector.Record(r.Context(), client, phone, !errors.Is(err, daoerrors.ErrUserNotFound))
+	}
+	if errors.Is(err, service.ErrUnlisted) {
+		writeJSON(w, http.StatusOK, unlistedResponse{PhoneNumber: phone, Unlisted: true, IsSpam: resp.IsSpam, Message: "the owner of this number has opted out of caller ID"})
+		return
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	h.recordLookup(r, phone)
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// screen checks the caller against the enumeration detector, if one is configured. It returns the caller's
+// detector key and whether the caller is screened, i.e. whether its lookups must be recorded.
+func (h *Handler) screen(r *http.Request) (string, bool, error) {
+	if h.detector == nil {
+		return "", false, nil
+	}
+	client, ok := h.detectorKey(r)
+	if !ok {
+		return "", false, nil
+	}
+	return client, true, h.detector.Check(client)
+}
+
+// lookup runs a detailed lookup, listing alternative names only if alternatives > 0.
+// For unlisted numbers it returns service.ErrUnlisted with a response carrying only the spam status.
+func (h *Handler) lookup(r *http.Request, phone string, alternatives int) (*lookupResponse, error) {
+	result, err := h.userService.LookupUserDetailed(r.Context(), phone, alternatives)
+	if errors.Is(err, service.ErrUnlisted) {
+		return &lookupResponse{PhoneNumber: phone, IsSpam: result.GetIsSpam()}, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &lookupResponse{PhoneNumber: phone, Name: result.Name, IsSpam: result.IsSpam, Person: result.GetPerson(), Alternatives: result.GetAlternatives()}, nil
+}
@@ -0,0 +1,217 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao/mem"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+	"github.com/yourusername/truecaller-lite/pkg/service"
+)
+
+// newTestHandler returns a Handler backed by in-memory DAOs.
+func newTestHandler(opts ...Option) (*Handler, *mem.UserMemDAO, *mem.PhoneBookMemDAO) {
+	userDAO := mem.NewUserMemDAO()
+	phoneBookDAO := mem.NewPhoneBookMemDAO()
+	opts = append([]Option{WithOwnerVerifier(ownerHeaderVerifier)}, opts...)
+	return NewHandler(service.NewUserService(userDAO, phoneBookDAO), opts...), userDAO, phoneBookDAO
+}
+
+func TestHandler_UploadContacts(t *testing.T) {
+	tests := []struct {
+		name         string
+		path         string
+		caller       string
+		contentType  string
+		body         string
+		opts         []Option
+		wantStatus   int
+		wantAccepted int
+		wantCode     string
+	}{
+		{
+			name:         "json upload",
+			path:         "/v1/users/919876543210/contacts",
+			contentType:  "application/json",
+			body:         `{"contacts":[{"phone_number":"919123456789","name":"Bob"}]}`,
+			wantStatus:   http.StatusOK,
+			wantAccepted: 1,
+		},
+		{
+			name:        "upload as another owner",
+			path:        "/v1/users/919876543210/contacts",
+			caller:      "919123456789",
+			contentType: "application/json",
+			body:        `{"contacts":[{"phone_number":"919123456789","name":"Bob"}]}`,
+			wantStatus:  http.StatusForbidden,
+			wantCode:    codeForbidden,
+		},
+		{
+			name:        "json upload with invalid contact",
+			path:        "/v1/users/919876543210/contacts",
+			contentType: "application/json",
+			body:        `{"contacts":[{"phone_number":"919123456789","name":"Bob"},{"phone_number":"123","name":"Bad"}]}`,
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    models.ErrCodeValidationFailed,
+		},
+		{
+			name:         "partial json upload",
+			path:         "/v1/users/919876543210/contacts?mode=partial",
+			contentType:  "application/json",
+			body:         `{"contacts":[{"phone_number":"919123456789","name":"Bob"},{"phone_number":"123","name":"Bad"}]}`,
+			wantStatus:   http.StatusOK,
+			wantAccepted: 1,
+		},
+		{
+			name:        "malformed json",
+			path:        "/v1/users/919876543210/contacts",
+			contentType: "application/json",
+			body:        `{"contacts":`,
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    codeBadRequest,
+		},
+		{
+			name:         "ndjson upload",
+			path:         "/v1/users/919876543210/contacts",
+			contentType:  "application/x-ndjson",
+			body:         "{\"phone_number\":\"919123456789\",\"name\":\"Bob\"}\n{\"phone_number\":\"919123456780\",\"name\":\"Carol\"}\n",
+			wantStatus:   http.StatusOK,
+			wantAccepted: 2,
+		},
+		{
+			name:        "ndjson upload over request limit",
+			path:        "/v1/users/919876543210/contacts",
+			contentType: "application/x-ndjson",
+			body:        "{\"phone_number\":\"919123456789\",\"name\":\"Bob\"}\n{\"phone_number\":\"919123456780\",\"name\":\"Carol\"}\n",
+			opts:        []Option{WithStreamUploadOptions(service.StreamUploadOptions{MaxContactsPerRequest: 1})},
+			wantStatus:  http.StatusRequestEntityTooLarge,
+			wantCode:    codeLimitExceeded,
+		},
+		{
+			name:        "ndjson upload with an over-long line",
+			path:        "/v1/users/919876543210/contacts",
+			contentType: "application/x-ndjson",
+			body:        "{\"phone_number\":\"919123456789\",\"name\":\"Bob\"}\n" + strings.Repeat("x", 70000) + "\n",
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    models.ErrCodeValidationFailed,
+		},
+		{
+			name:         "vcard upload",
+			path:         "/v1/users/919876543210/contacts",
+			contentType:  "text/vcard; charset=utf-8",
+			body:         "BEGIN:VCARD\r\nVERSION:3.0\r\nFN:Bob\r\nTEL:+91 91234 56789\r\nEND:VCARD\r\n",
+			wantStatus:   http.StatusOK,
+			wantAccepted: 1,
+		},
+		{
+			name:        "malformed vcard",
+			path:        "/v1/users/919876543210/contacts",
+			contentType: "text/vcard",
+			body:        "BEGIN:VCARD\r\nFN:Bob\r\n",
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    codeBadRequest,
+		},
+		{
+			name:        "unsupported content type",
+			path:        "/v1/users/919876543210/contacts",
+			contentType: "application/xml",
+			body:        `<contacts/>`,
+			wantStatus:  http.StatusUnsupportedMediaType,
+			wantCode:    codeUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			h, _, _ := newTestHandler(tc.opts...)
+			req := httptest.NewRequest(http.MethodPost, tc.path, strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", tc.contentType)
+			if tc.caller == "" {
+				tc.caller = "919876543210"
+			}
+			req.Header.Set("X-Test-Owner", tc.caller)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tc.wantStatus, rec.Code, rec.Body)
+			}
+			if tc.wantCode != "" {
+				var resp models.ErrorResponse
+				if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil || resp.Code != tc.wantCode {
+					t.Errorf("expected error code %s, got %+v (%v)", tc.wantCode, resp, err)
+				}
+				return
+			}
+			var report models.UploadReport
+			if err := json.NewDecoder(rec.Body).Decode(&report); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if report.Accepted != tc.wantAccepted {
+				t.Errorf("expected %d accepted, got %+v", tc.wantAccepted, report)
+			}
+		})
+	}
+}
+
+func TestHandler_UploadContactsStreamFailure(t *testing.T) {
+	h, _, phoneBookDAO := newTestHandler(WithStreamUploadOptions(service.StreamUploadOptions{ChunkSize: 1, MaxContactsPerRequest: 2}))
+	body := "{\"phone_number\":\"919123456789\",\"name\":\"Bob\"}\n{\"phone_number\":\"123\",\"name\":\"Bad\"}\n{\"phone_number\":\"919123456780\",\"name\":\"Carol\"}\n"
+	req := httptest.NewRequest(http.MethodPost, "/v1/users/919876543210/contacts", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	req.Header.Set("X-Test-Owner", "919876543210")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusRequestEntityTooLarge, rec.Code, rec.Body)
+	}
+	var resp uploadErrorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Code != codeLimitExceeded || resp.StoppedAt == nil || *resp.StoppedAt != 2 {
+		t.Errorf("expected limit_exceeded stopping at line 2, got %+v", resp)
+	}
+	if r := resp.Report; r == nil || r.Total != 2 || r.Accepted != 1 || len(r.Rejected) != 1 || r.Rejected[0].Index != 1 {
+		t.Errorf("expected a report of 1 accepted and line 1 rejected, got %+v", r)
+	}
+	if pb, err := phoneBookDAO.GetPhoneBookByUserPhoneNumber(context.Background(), "919876543210"); err != nil || len(pb.Contacts) != 1 {
+		t.Errorf("expected the stored contact to be kept, got %+v (%v)", pb, err)
+	}
+}
+
+func TestHandler_LookupUser(t *testing.T) {
+	h, userDAO, phoneBookDAO := newTestHandler()
+	_ = userDAO.CreateOrUpdateUser(context.Background(), &models.User{PhoneNumber: "919876543210", Name: "Alice", IsSpam: true})
+	_ = phoneBookDAO.CreateOrUpdatePhoneBook(context.Background(), &models.PhoneBook{PhoneNumber: "919111111111", Contacts: []models.Contact{{PhoneNumber: "919876543210", Name: "Alice"}}})
+	_ = userDAO.CreateOrUpdateUser(context.Background(), &models.User{PhoneNumber: "919876543211", Name: "Dr. Anita Rao - Apollo"})
+	tests := []struct {
+		name       string
+		phone      string
+		query      string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "found", phone: "919876543210", wantStatus: http.StatusOK, wantBody: `{"phone_number":"919876543210","name":"Alice","is_spam":true,"person":{"given_name":"Alice"}}`},
+		{name: "structured name", phone: "919876543211", wantStatus: http.StatusOK, wantBody: `{"phone_number":"919876543211","name":"Dr. Anita Rao - Apollo","is_spam":false,"person":{"honorific":"Dr.","given_name":"Anita","family_name":"Rao","organization":"Apollo"}}`},
+		{name: "with alternatives", phone: "919876543210", query: "?alternatives=3", wantStatus: http.StatusOK, wantBody: `{"phone_number":"919876543210","name":"Alice","is_spam":true,"person":{"given_name":"Alice"},"alternatives":[{"name":"Alice","uploaders":1}]}`},
+		{name: "invalid alternatives", phone: "919876543210", query: "?alternatives=x", wantStatus: http.StatusBadRequest},
+		{name: "not found", phone: "919999999999", wantStatus: http.StatusNotFound},
+		{name: "invalid phone number", phone: "123", wantStatus: http.StatusBadRequest},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/users/"+tc.phone+tc.query, nil))
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tc.wantStatus, rec.Code, rec.Body)
+			}
+			if tc.wantBody != "" && strings.TrimSpace(rec.Body.String()) != tc.wantBody {
+				t.Errorf("expected body %s, got %s", tc.wantBody, rec.Body)
+			}
+		})
+	}
+}
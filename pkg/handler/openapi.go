@@ -0,0 +1,18 @@
+package handler
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+// openAPISpec is the OpenAPI 3 document describing every endpoint of the API. Keep it in sync with the routes
+// registered by NewHandler and the JSON shapes they use; TestOpenAPISpec fails when they drift apart.
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+// serveOpenAPI handles GET /openapi.json.
+func (h *Handler) serveOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", contentTypeJSON)
+	_, _ = w.Write(openAPISpec)
+}
@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/truecaller-lite/pkg/auth"
+	"github.com/yourusername/truecaller-lite/pkg/dao/mem"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+	"github.com/yourusername/truecaller-lite/pkg/otp"
+	"github.com/yourusername/truecaller-lite/pkg/search"
+	"github.com/yourusername/truecaller-lite/pkg/service"
+)
+
+// openAPIDocument is the part of the OpenAPI document checked against the handler.
+type openAPIDocument struct {
+	OpenAPI    string                                `json:"openapi"`
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+			Required   []string                   `json:"required"`
+		} `json:"schemas"`
+	} `json:"components"`
+}
+
+// openAPISchemas maps each schema of the OpenAPI document to the Go type encoded or decoded for it.
+var openAPISchemas = map[string]any{
+	"Contact":               models.Contact{},
+	"UploadContactsRequest": uploadContactsRequest{},
+	"UploadReport":          models.UploadReport{},
+	"RejectedContact":       models.RejectedContact{},
+	"ErrorResponse":         models.ErrorResponse{},
+	"UploadErrorResponse":   uploadErrorResponse{},
+	"ErrorDetail":           models.ErrorDetail{},
+	"LookupResponse":        lookupResponse{},
+	"UnlistedResponse":      unlistedResponse{},
+	"PersonName":            models.PersonName{},
+	"NameCandidate":         models.NameCandidate{},
+	"DisplayNameRequest":    displayNameRequest{},
+	"SearchPage":            models.SearchPage{},
+	"SearchResult":          models.SearchResult{},
+	"ViewerPage":            models.ViewerPage{},
+	"Viewer":                models.Viewer{},
+	"UserDataExport":        models.UserDataExport{},
+	"User":                  models.User{},
+	"SavedName":             models.SavedName{},
+	"LookupRecord":          models.LookupRecord{},
+	"SpamStatusChange":      models.SpamStatusChange{},
+	"SpamReport":            models.SpamReport{},
+	"OTPRequest":            otpRequest{},
+	"OTPRequestResponse":    otpRequestResponse{},
+	"OTPVerifyRequest":      otpVerifyRequest{},
+	"OTPVerifyResponse":     otpVerifyResponse{},
+}
+
+// newFullHandler returns a handler with every optional endpoint enabled.
+func newFullHandler(t *testing.T) *Handler {
+	t.Helper()
+	key, _ := auth.GenerateKey()
+	sessions, err := auth.NewIssuer([]auth.Key{key}, auth.Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	userDAO, phoneBookDAO, privacyDAO, lookupLogDAO := mem.NewUserMemDAO(), mem.NewPhoneBookMemDAO(), mem.NewPrivacyMemDAO(), mem.NewLookupLogMemDAO()
+	return NewHandler(service.NewUserService(userDAO, phoneBookDAO),
+		WithPrivacyService(service.NewPrivacyService(userDAO, phoneBookDAO, mem.NewSpamReportMemDAO(), privacyDAO)),
+		WithOTPService(otp.NewService(mem.NewOTPMemDAO(), otp.NewLogSender(nil), sessions, otp.Options{})),
+		WithLookupLog(service.NewLookupLogService(lookupLogDAO, userDAO, privacyDAO, service.LookupLogOptions{}), sessions),
+		WithSearchService(service.NewSearchService(search.NewIndex(), privacyDAO), sessions),
+	)
+}
+
+func TestOpenAPISpec(t *testing.T) {
+	var doc openAPIDocument
+	if err := json.Unmarshal(openAPISpec, &doc); err != nil {
+		t.Fatalf("openapi.json is not valid JSON: %v", err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		t.Errorf("expected an OpenAPI 3 document, got version %q", doc.OpenAPI)
+	}
+
+	t.Run("paths match routes", func(t *testing.T) {
+		var documented []string
+		for path, ops := range doc.Paths {
+			for method := range ops {
+				documented = append(documented, strings.ToUpper(method)+" "+path)
+			}
+		}
+		registered := append([]string(nil), newFullHandler(t).patterns...)
+		sort.Strings(documented)
+		sort.Strings(registered)
+		if !reflect.DeepEqual(documented, registered) {
+			t.Errorf("documented operations %v do not match registered routes %v", documented, registered)
+		}
+	})
+
+	t.Run("schemas match types", func(t *testing.T) {
+		for name := range doc.Components.Schemas {
+			if _, ok := openAPISchemas[name]; !ok {
+				t.Errorf("schema %s has no Go type in openAPISchemas", name)
+			}
+		}
+		for name, v := range openAPISchemas {
+			schema, ok := doc.Components.Schemas[name]
+			if !ok {
+				t.Errorf("schema %s is missing from openapi.json", name)
+				continue
+			}
+			fields, required := jsonFields(reflect.TypeOf(v))
+			var properties []string
+			for p := range schema.Properties {
+				properties = append(properties, p)
+			}
+			sort.Strings(properties)
+			sort.Strings(schema.Required)
+			if !reflect.DeepEqual(properties, fields) {
+				t.Errorf("schema %s has properties %v, type has JSON fields %v", name, properties, fields)
+			}
+			if !reflect.DeepEqual(schema.Required, required) && (len(schema.Required) > 0 || len(required) > 0) {
+				t.Errorf("schema %s requires %v, type always encodes %v", name, schema.Required, required)
+			}
+		}
+	})
+
+	t.Run("references resolve", func(t *testing.T) {
+		var raw struct {
+			Components map[string]map[string]json.RawMessage `json:"components"`
+		}
+		_ = json.Unmarshal(openAPISpec, &raw)
+		for _, ref := range bytes.Split(openAPISpec, []byte(`"$ref": "`))[1:] {
+			target := string(ref[:bytes.IndexByte(ref, '"')])
+			parts := strings.Split(strings.TrimPrefix(target, "#/components/"), "/")
+			if len(parts) != 2 || raw.Components[parts[0]][parts[1]] == nil {
+				t.Errorf("unresolved reference %s", target)
+			}
+		}
+	})
+}
+
+// jsonFields returns the sorted JSON field names of struct type t, and those encoded even when empty.
+func jsonFields(t reflect.Type) (fields, required []string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if !f.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, name)
+		if !strings.Contains(opts, "omitempty") {
+			required = append(required, name)
+		}
+	}
+	sort.Strings(fields)
+	sort.Strings(required)
+	return fields, required
+}
+
+func TestHandler_ServeOpenAPI(t *testing.T) {
+	h, _, _ := newTestHandler()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("expected 200 with JSON, got %d %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	if !bytes.Equal(rec.Body.Bytes(), openAPISpec) {
+		t.Error("expected the embedded document to be served")
+	}
+}
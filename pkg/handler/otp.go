@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+)
+
+// otpRequest is the JSON body of a verification code request.
+type otpRequest struct {
+	PhoneNumber string `json:"phone_number"`
+}
+
+// otpRequestResponse is the JSON body returned when a code has been sent.
+type otpRequestResponse struct {
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// otpVerifyRequest is the JSON body of a verification attempt.
+type otpVerifyRequest struct {
+	PhoneNumber string `json:"phone_number"`
+	Code        string `json:"code"`
+}
+
+// otpVerifyResponse is the JSON body returned when a code is verified.
+type otpVerifyResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// requestOTP handles POST /v1/otp/request by sending a verification code to the phone number.
+func (h *Handler) requestOTP(w http.ResponseWriter, r *http.Request) {
+	var req otpRequest
+	if err := decodeJSONBody(w, r, h.maxBodyBytes, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	expiresAt, err := h.otpService.RequestCode(r.Context(), req.PhoneNumber)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, otpRequestResponse{ExpiresAt: expiresAt})
+}
+
+// verifyOTP handles POST /v1/otp/verify by exchanging a valid code for a session token.
+func (h *Handler) verifyOTP(w http.ResponseWriter, r *http.Request) {
+	var req otpVerifyRequest
+	if err := decodeJSONBody(w, r, h.maxBodyBytes, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	token, expiresAt, err := h.otpService.VerifyCode(r.Context(), req.PhoneNumber, req.Code)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, otpVerifyResponse{Token: token, ExpiresAt: expiresAt})
+}
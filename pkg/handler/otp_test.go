@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/truecaller-lite/pkg/auth"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao/mem"
+	"github.com/yourusername/truecaller-lite/pkg/otp"
+	"github.com/yourusername/truecaller-lite/pkg/service"
+)
+
+func TestHandler_OTPVerificationGrantsOwnerAccess(t *testing.T) {
+	var lastCode string
+	sender := otp.SMSSenderFunc(func(ctx context.Context, phone, message string) error {
+		lastCode = regexp.MustCompile(`\d{6}`).FindString(message)
+		return nil
+	})
+	key, _ := auth.GenerateKey()
+	sessions, err := auth.NewIssuer([]auth.Key{key}, auth.Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	userDAO, phoneBookDAO, privacyDAO := mem.NewUserMemDAO(), mem.NewPhoneBookMemDAO(), mem.NewPrivacyMemDAO()
+	h := NewHandler(service.NewUserService(userDAO, phoneBookDAO),
+		WithOTPService(otp.NewService(mem.NewOTPMemDAO(), sender, sessions, otp.Options{})),
+		WithPrivacyService(service.NewPrivacyService(userDAO, phoneBookDAO, mem.NewSpamReportMemDAO(), privacyDAO)),
+		WithOwnerVerifier(NewTokenOwnerVerifier(sessions)),
+	)
+	do := func(method, path, body, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := do(http.MethodPost, "/v1/otp/request", `{"phone_number":"919876543210"}`, ""); rec.Code != http.StatusAccepted {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusAccepted, rec.Code, rec.Body)
+	}
+	rec := do(http.MethodPost, "/v1/otp/request", `{"phone_number":"919876543210"}`, "")
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") == "" {
+		t.Errorf("expected throttled resend with Retry-After, got %d %q", rec.Code, rec.Header().Get("Retry-After"))
+	}
+	if rec := do(http.MethodPost, "/v1/otp/verify", `{"phone_number":"919876543210","code":"x"}`, ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected status %d for a wrong code, got %d", http.StatusUnauthorized, rec.Code)
+	}
+	rec = do(http.MethodPost, "/v1/otp/verify", `{"phone_number":"919876543210","code":"`+lastCode+`"}`, "")
+	var verified otpVerifyResponse
+	if err := json.NewDecoder(rec.Body).Decode(&verified); err != nil || rec.Code != http.StatusOK || verified.Token == "" {
+		t.Fatalf("expected a token, got %d %+v (%v)", rec.Code, verified, err)
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		token      string
+		wantStatus int
+	}{
+		{name: "own number", path: "/v1/users/919876543210/unlist", token: verified.Token, wantStatus: http.StatusNoContent},
+		{name: "upload own contacts", path: "/v1/users/919876543210/contacts", body: `{"contacts":[]}`, token: verified.Token, wantStatus: http.StatusOK},
+		{name: "upload as another owner", path: "/v1/users/919123456789/contacts", body: `{"contacts":[]}`, token: verified.Token, wantStatus: http.StatusForbidden},
+		{name: "other number", path: "/v1/users/919123456789/unlist", token: verified.Token, wantStatus: http.StatusForbidden},
+		{name: "missing token", path: "/v1/users/919876543210/unlist", wantStatus: http.StatusForbidden},
+		{name: "unknown token", path: "/v1/users/919876543210/unlist", token: "bogus", wantStatus: http.StatusUnauthorized},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if rec := do(http.MethodPost, tc.path, tc.body, tc.token); rec.Code != tc.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.wantStatus, rec.Code, rec.Body)
+			}
+		})
+	}
+}
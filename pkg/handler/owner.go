@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/yourusername/truecaller-lite/pkg/apierrors"
+	"github.com/yourusername/truecaller-lite/pkg/otp"
+)
+
+// ErrOwnerNotVerified is returned by an OwnerVerifier when a request does not prove ownership of a phone number.
+// It is apierrors.ErrOwnerNotVerified, so clients can match it without importing this package.
+var ErrOwnerNotVerified = apierrors.ErrOwnerNotVerified
+
+// OwnerVerifier checks that a request is made by the verified owner of a phone number.
+// It guards owner-only endpoints such as contact upload and data export.
+type OwnerVerifier interface {
+	// VerifyOwner returns nil if r proves ownership of phoneNumber, or an error wrapping ErrOwnerNotVerified.
+	VerifyOwner(r *http.Request, phoneNumber string) error
+}
+
+// OwnerVerifierFunc adapts a function to the OwnerVerifier interface.
+type OwnerVerifierFunc func(r *http.Request, phoneNumber string) error
+
+// VerifyOwner calls f(r, phoneNumber).
+func (f OwnerVerifierFunc) VerifyOwner(r *http.Request, phoneNumber string) error {
+	return f(r, phoneNumber)
+}
+
+// denyAllOwners is the default OwnerVerifier: without a configured verifier no request can act as an owner.
+var denyAllOwners = OwnerVerifierFunc(func(r *http.Request, phoneNumber string) error {
+	return ErrOwnerNotVerified
+})
+
+// NewTokenOwnerVerifier returns an OwnerVerifier accepting requests with an "Authorization: Bearer <token>"
+// header whose token verifier resolves to the phone number being accessed. verifier is typically an
+// *auth.Issuer; an *otp.SessionIssuer works for single-instance development setups.
+func NewTokenOwnerVerifier(verifier otp.TokenVerifier) OwnerVerifier {
+	return OwnerVerifierFunc(func(r *http.Request, phoneNumber string) error {
+		token, ok := bearerToken(r)
+		if !ok {
+			return fmt.Errorf("%w: missing bearer token", ErrOwnerNotVerified)
+		}
+		subject, err := verifier.VerifyToken(r.Context(), token)
+		if err != nil {
+			return err
+		}
+		if subject != phoneNumber {
+			return ErrOwnerNotVerified
+		}
+		return nil
+	})
+}
+
+// bearerToken returns the token from the request's Authorization header, if any.
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+	return token, true
+}
@@ -0,0 +1,35 @@
+package handler
+
+import "net/http"
+
+// exportUserData handles GET /v1/users/{phone}/export, returning the owner's data as a downloadable JSON archive.
+func (h *Handler) exportUserData(w http.ResponseWriter, r *http.Request) {
+	phone := r.PathValue("phone")
+	export, err := h.privacyService.ExportUserData(r.Context(), phone)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Disposition", `attachment; filename="truecaller-lite-export-`+phone+`.json"`)
+	writeJSON(w, http.StatusOK, export)
+}
+
+// deleteUserData handles DELETE /v1/users/{phone}, erasing the owner's data.
+func (h *Handler) deleteUserData(w http.ResponseWriter, r *http.Request) {
+	if err := h.privacyService.DeleteUserData(r.Context(), r.PathValue("phone")); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// setUnlisted returns a handler for POST /v1/users/{phone}/unlist and /relist.
+func (h *Handler) setUnlisted(unlisted bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := h.privacyService.SetUnlisted(r.Context(), r.PathValue("phone"), unlisted); err != nil {
+			writeError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
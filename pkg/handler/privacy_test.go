@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao/mem"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+	"github.com/yourusername/truecaller-lite/pkg/service"
+)
+
+// ownerHeaderVerifier trusts an X-Test-Owner header; for tests only.
+var ownerHeaderVerifier = OwnerVerifierFunc(func(r *http.Request, phoneNumber string) error {
+	if r.Header.Get("X-Test-Owner") != phoneNumber {
+		return ErrOwnerNotVerified
+	}
+	return nil
+})
+
+func TestHandler_ExportUserData(t *testing.T) {
+	ctx := context.Background()
+	userDAO, phoneBookDAO, spamReportDAO, privacyDAO := mem.NewUserMemDAO(), mem.NewPhoneBookMemDAO(), mem.NewSpamReportMemDAO(), mem.NewPrivacyMemDAO()
+	_ = phoneBookDAO.CreateOrUpdatePhoneBook(ctx, &models.PhoneBook{PhoneNumber: "919876543210", Contacts: []models.Contact{{PhoneNumber: "919123456789", Name: "Bob"}}})
+	_ = phoneBookDAO.CreateOrUpdatePhoneBook(ctx, &models.PhoneBook{PhoneNumber: "919123456789", Contacts: []models.Contact{{PhoneNumber: "919876543210", Name: "Alice"}}})
+	privacyService := service.NewPrivacyService(userDAO, phoneBookDAO, spamReportDAO, privacyDAO)
+
+	tests := []struct {
+		name       string
+		opts       []Option
+		owner      string
+		wantStatus int
+	}{
+		{name: "verified owner", opts: []Option{WithPrivacyService(privacyService), WithOwnerVerifier(ownerHeaderVerifier)}, owner: "919876543210", wantStatus: http.StatusOK},
+		{name: "different caller", opts: []Option{WithPrivacyService(privacyService), WithOwnerVerifier(ownerHeaderVerifier)}, owner: "919123456789", wantStatus: http.StatusForbidden},
+		{name: "no verifier configured", opts: []Option{WithPrivacyService(privacyService)}, owner: "919876543210", wantStatus: http.StatusForbidden},
+		{name: "privacy service not configured", opts: nil, owner: "919876543210", wantStatus: http.StatusNotFound},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			h := NewHandler(service.NewUserService(userDAO, phoneBookDAO), tc.opts...)
+			req := httptest.NewRequest(http.MethodGet, "/v1/users/919876543210/export", nil)
+			req.Header.Set("X-Test-Owner", tc.owner)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tc.wantStatus, rec.Code, rec.Body)
+			}
+			if tc.wantStatus != http.StatusOK {
+				return
+			}
+			if rec.Header().Get("Content-Disposition") == "" {
+				t.Error("expected attachment Content-Disposition header")
+			}
+			var export models.UserDataExport
+			if err := json.NewDecoder(rec.Body).Decode(&export); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(export.PhoneBook) != 1 || len(export.SavedAs) != 1 || export.SavedAs[0].Name != "Alice" || export.SavedAs[0].Uploader == "919123456789" {
+				t.Errorf("unexpected export %+v", export)
+			}
+		})
+	}
+}
+
+func TestHandler_DeleteUserData(t *testing.T) {
+	ctx := context.Background()
+	userDAO, phoneBookDAO, spamReportDAO, privacyDAO := mem.NewUserMemDAO(), mem.NewPhoneBookMemDAO(), mem.NewSpamReportMemDAO(), mem.NewPrivacyMemDAO()
+	userService := service.NewUserService(userDAO, phoneBookDAO, service.WithPrivacyDAO(privacyDAO))
+	_ = userService.UploadContacts(ctx, "919123456789", []models.Contact{{PhoneNumber: "919876543210", Name: "Alice"}})
+	h := NewHandler(userService, WithPrivacyService(service.NewPrivacyService(userDAO, phoneBookDAO, spamReportDAO, privacyDAO)), WithOwnerVerifier(ownerHeaderVerifier))
+
+	req := httptest.NewRequest(http.MethodDelete, "/v1/users/919876543210", nil)
+	req.Header.Set("X-Test-Owner", "919123456789")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected status %d for non-owner, got %d", http.StatusForbidden, rec.Code)
+	}
+
+	req.Header.Set("X-Test-Owner", "919876543210")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusNoContent, rec.Code, rec.Body)
+	}
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/users/919876543210", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected erased number to be not found, got %d: %s", rec.Code, rec.Body)
+	}
+}
+
+func TestHandler_Unlist(t *testing.T) {
+	ctx := context.Background()
+	userDAO, phoneBookDAO, spamReportDAO, privacyDAO := mem.NewUserMemDAO(), mem.NewPhoneBookMemDAO(), mem.NewSpamReportMemDAO(), mem.NewPrivacyMemDAO()
+	userService := service.NewUserService(userDAO, phoneBookDAO, service.WithPrivacyDAO(privacyDAO))
+	_ = userService.UploadContacts(ctx, "919123456789", []models.Contact{{PhoneNumber: "919876543210", Name: "Alice"}})
+	h := NewHandler(userService, WithPrivacyService(service.NewPrivacyService(userDAO, phoneBookDAO, spamReportDAO, privacyDAO)), WithOwnerVerifier(ownerHeaderVerifier))
+
+	do := func(method, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("X-Test-Owner", "919876543210")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+	if rec := do(http.MethodPost, "/v1/users/919876543210/unlist"); rec.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusNoContent, rec.Code, rec.Body)
+	}
+	rec := do(http.MethodGet, "/v1/users/919876543210")
+	var resp map[string]any
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	if rec.Code != http.StatusOK || resp["unlisted"] != true || resp["name"] != nil || resp["is_spam"] != false {
+		t.Errorf("expected privacy response, got %d %v", rec.Code, resp)
+	}
+	_ = userDAO.UpdateSpamStatus(ctx, "919876543210", true)
+	rec = do(http.MethodGet, "/v1/users/919876543210")
+	resp = nil
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	if rec.Code != http.StatusOK || resp["unlisted"] != true || resp["name"] != nil || resp["is_spam"] != true {
+		t.Errorf("expected the spam status of an unlisted number, got %d %v", rec.Code, resp)
+	}
+	if rec := do(http.MethodPost, "/v1/users/919876543210/relist"); rec.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusNoContent, rec.Code, rec.Body)
+	}
+	rec = do(http.MethodGet, "/v1/users/919876543210")
+	resp = nil
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	if rec.Code != http.StatusOK || resp["name"] != "Alice" {
+		t.Errorf("expected name after relisting, got %d %v", rec.Code, resp)
+	}
+}
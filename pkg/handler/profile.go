@@ -0,0 +1,22 @@
+package handler
+
+import "net/http"
+
+// displayNameRequest is the JSON body of a display name update.
+type displayNameRequest struct {
+	DisplayName string `json:"display_name"`
+}
+
+// setDisplayName handles PUT /v1/users/{phone}/display-name. An empty display name clears it.
+func (h *Handler) setDisplayName(w http.ResponseWriter, r *http.Request) {
+	var req displayNameRequest
+	if err := decodeJSONBody(w, r, h.maxBodyBytes, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := h.userService.SetDisplayName(r.Context(), r.PathValue("phone"), req.DisplayName); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+func TestHandler_SetDisplayName(t *testing.T) {
+	h, userDAO, _ := newTestHandler()
+	_ = userDAO.CreateOrUpdateUser(context.Background(), &models.User{PhoneNumber: "919876543210", Name: "Plumber Ramesh"})
+
+	tests := []struct {
+		name       string
+		caller     string
+		body       string
+		wantStatus int
+		wantName   string
+	}{
+		{name: "owner sets display name", caller: "919876543210", body: `{"display_name":"Ramesh Kumar"}`, wantStatus: http.StatusNoContent, wantName: "Ramesh Kumar"},
+		{name: "someone else", caller: "919123456789", body: `{"display_name":"Scammer"}`, wantStatus: http.StatusForbidden, wantName: "Ramesh Kumar"},
+		{name: "invalid display name", caller: "919876543210", body: `{"display_name":"   "}`, wantStatus: http.StatusBadRequest, wantName: "Ramesh Kumar"},
+		{name: "owner clears display name", caller: "919876543210", body: `{"display_name":""}`, wantStatus: http.StatusNoContent, wantName: "Plumber Ramesh"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "/v1/users/919876543210/display-name", strings.NewReader(tc.body))
+			req.Header.Set("X-Test-Owner", tc.caller)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tc.wantStatus, rec.Code, rec.Body)
+			}
+			rec = httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/users/919876543210", nil))
+			var resp lookupResponse
+			_ = json.NewDecoder(rec.Body).Decode(&resp)
+			if resp.Name != tc.wantName {
+				t.Errorf("expected lookup name %q, got %q", tc.wantName, resp.Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/yourusername/truecaller-lite/pkg/otp"
+	"github.com/yourusername/truecaller-lite/pkg/ratelimit"
+)
+
+// Route names for WithRateLimit. Each names a group of endpoints.
+const (
+	// RouteUpload is POST /v1/users/{phone}/contacts.
+	RouteUpload = "upload"
+	// RouteLookup is GET /v1/users/{phone} and GET /v1/search.
+	RouteLookup = "lookup"
+	// RouteOTP is the phone verification endpoints under /v1/otp.
+	RouteOTP = "otp"
+	// RoutePrivacy is the owner-only profile and privacy endpoints, e.g. display name, export and erasure.
+	RoutePrivacy = "privacy"
+)
+
+// RateLimitKey extracts the key a request is rate limited (or screened for enumeration) by. It returns false if the request has no such
+// key (e.g. no bearer token), in which case the limit does not apply.
+type RateLimitKey func(r *http.Request) (string, bool)
+
+// KeyByIP limits by the client IP address taken from the connection. Deployments behind a proxy should pass a
+// key function reading the proxy's client IP header instead.
+func KeyByIP(r *http.Request) (string, bool) {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr, r.RemoteAddr != ""
+	}
+	return host, true
+}
+
+// KeyByToken returns a key limiting by the phone number of the request's bearer token, once verifier accepts
+// the token. Requests without a valid token have no such key, so made-up tokens cannot each get a fresh limit;
+// pair it with KeyByIP to limit those.
+func KeyByToken(verifier otp.TokenVerifier) RateLimitKey {
+	return func(r *http.Request) (string, bool) {
+		token, ok := bearerToken(r)
+		if !ok {
+			return "", false
+		}
+		phoneNumber, err := verifier.VerifyToken(r.Context(), token)
+		if err != nil {
+			return "", false
+		}
+		return phoneNumber, true
+	}
+}
+
+// KeyByTokenOrIP returns a key for requests with a token verifier accepts by the token's phone number, and for
+// all other requests, including those with an invalid token, by client IP.
+func KeyByTokenOrIP(verifier otp.TokenVerifier) RateLimitKey {
+	byToken := KeyByToken(verifier)
+	return func(r *http.Request) (string, bool) {
+		if key, ok := byToken(r); ok {
+			return "owner:" + key, true
+		}
+		key, ok := KeyByIP(r)
+		return "ip:" + key, ok
+	}
+}
+
+// KeyByOwner returns a key limiting by the {phone} path value, i.e. the owner number an upload or owner-only
+// call targets, once verifier accepts the request as made by that owner. Requests that do not prove ownership
+// have no such key, so others cannot use up an owner's budget; pair it with KeyByIP to limit those.
+func KeyByOwner(verifier OwnerVerifier) RateLimitKey {
+	return func(r *http.Request) (string, bool) {
+		phone := r.PathValue("phone")
+		if phone == "" || verifier.VerifyOwner(r, phone) != nil {
+			return "", false
+		}
+		return phone, true
+	}
+}
+
+// rateLimit is one limiter applied to a route under a key.
+type rateLimit struct {
+	key     RateLimitKey
+	limiter *ratelimit.Limiter
+}
+
+// WithRateLimit limits requests to the endpoints of route, keyed by key. Several limits may apply to one route;
+// a request must pass all of them, and a request one of them refuses takes no tokens from the others. Limited
+// requests get 429 Too Many Requests with a Retry-After header.
+func WithRateLimit(route string, key RateLimitKey, limiter *ratelimit.Limiter) Option {
+	return func(h *Handler) {
+		h.rateLimits[route] = append(h.rateLimits[route], rateLimit{key: key, limiter: limiter})
+	}
+}
+
+// rateLimited wraps next so it only runs if every rate limit configured for route allows the request.
+func (h *Handler) rateLimited(route string, next http.HandlerFunc) http.HandlerFunc {
+	limits := h.rateLimits[route]
+	if len(limits) == 0 {
+		return next
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		var charges []ratelimit.Charge
+		for _, limit := range limits {
+			if key, ok := limit.key(r); ok {
+				charges = append(charges, ratelimit.Charge{Limiter: limit.limiter, Key: key, Tokens: 1})
+			}
+		}
+		if err := ratelimit.AllowAll(charges...); err != nil {
+			writeError(w, err)
+			return
+		}
+		next(w, r)
+	}
+}
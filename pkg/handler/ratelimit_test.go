@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/truecaller-lite/pkg/ratelimit"
+)
+
+func TestHandler_RateLimit(t *testing.T) {
+	h, _, _ := newTestHandler(
+		WithRateLimit(RouteLookup, KeyByIP, ratelimit.NewLimiter(ratelimit.Every(2, time.Minute), ratelimit.Options{})),
+		WithRateLimit(RouteLookup, KeyByToken(phoneTokens), ratelimit.NewLimiter(ratelimit.Every(1, time.Minute), ratelimit.Options{})),
+		WithRateLimit(RouteUpload, KeyByOwner(ownerHeaderVerifier), ratelimit.NewLimiter(ratelimit.Every(1, time.Minute), ratelimit.Options{})),
+	)
+	lookup := func(ip, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v1/users/919876543210", nil)
+		req.RemoteAddr = ip + ":1234"
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+	upload := func(owner string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v1/users/"+owner+"/contacts", strings.NewReader(`{"contacts":[]}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Test-Owner", owner)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	tests := []struct {
+		name       string
+		do         func() *httptest.ResponseRecorder
+		wantStatus int
+	}{
+		{name: "first lookup from ip", do: func() *httptest.ResponseRecorder { return lookup("10.0.0.1", "") }, wantStatus: http.StatusNotFound},
+		{name: "second lookup from ip", do: func() *httptest.ResponseRecorder { return lookup("10.0.0.1", "") }, wantStatus: http.StatusNotFound},
+		{name: "ip limit exceeded", do: func() *httptest.ResponseRecorder { return lookup("10.0.0.1", "") }, wantStatus: http.StatusTooManyRequests},
+		{name: "other ip", do: func() *httptest.ResponseRecorder { return lookup("10.0.0.2", "919000000001") }, wantStatus: http.StatusNotFound},
+		{name: "token limit exceeded from a third ip", do: func() *httptest.ResponseRecorder { return lookup("10.0.0.3", "919000000001") }, wantStatus: http.StatusTooManyRequests},
+		{name: "unverified token is not limited as a token", do: func() *httptest.ResponseRecorder { return lookup("10.0.0.4", "bogus") }, wantStatus: http.StatusNotFound},
+		{name: "first upload", do: func() *httptest.ResponseRecorder { return upload("919876543210") }, wantStatus: http.StatusOK},
+		{name: "owner limit exceeded", do: func() *httptest.ResponseRecorder { return upload("919876543210") }, wantStatus: http.StatusTooManyRequests},
+		{name: "other owner", do: func() *httptest.ResponseRecorder { return upload("919123456789") }, wantStatus: http.StatusOK},
+	}
+	for _, tc := range tests {
+		rec := tc.do()
+		if rec.Code != tc.wantStatus {
+			t.Fatalf("%s: expected status %d, got %d: %s", tc.name, tc.wantStatus, rec.Code, rec.Body)
+		}
+		if rec.Code == http.StatusTooManyRequests && rec.Header().Get("Retry-After") == "" {
+			t.Errorf("%s: expected Retry-After header", tc.name)
+		}
+	}
+}
+
+func TestHandler_RateLimitOwner(t *testing.T) {
+	const victim = "919876543210"
+	h, _, _ := newTestHandler(
+		WithRateLimit(RouteUpload, KeyByOwner(ownerHeaderVerifier), ratelimit.NewLimiter(ratelimit.Every(1, time.Minute), ratelimit.Options{})),
+		WithRateLimit(RouteUpload, KeyByIP, ratelimit.NewLimiter(ratelimit.Every(3, time.Minute), ratelimit.Options{})),
+	)
+	upload := func(ip, owner, caller string) int {
+		req := httptest.NewRequest(http.MethodPost, "/v1/users/"+owner+"/contacts", strings.NewReader(`{"contacts":[]}`))
+		req.RemoteAddr = ip + ":1234"
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Test-Owner", caller)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	for i := 0; i < 3; i++ {
+		if code := upload("10.0.0.1", victim, ""); code != http.StatusForbidden {
+			t.Fatalf("unauthenticated upload %d: expected status %d, got %d", i, http.StatusForbidden, code)
+		}
+	}
+	if code := upload("10.0.0.1", victim, ""); code != http.StatusTooManyRequests {
+		t.Fatalf("expected the flood to be limited by ip, got status %d", code)
+	}
+	if code := upload("10.0.0.2", victim, victim); code != http.StatusOK {
+		t.Fatalf("expected the owner's upload to pass after the flood, got status %d", code)
+	}
+	if code := upload("10.0.0.2", victim, victim); code != http.StatusTooManyRequests {
+		t.Fatalf("expected the owner limit to apply, got status %d", code)
+	}
+	// The refused upload took no ip token, so two more owners fit in the ip limit of 3.
+	for _, owner := range []string{"919123456789", "919555555555"} {
+		if code := upload("10.0.0.2", owner, owner); code != http.StatusOK {
+			t.Errorf("upload for %s: expected status %d, got %d", owner, http.StatusOK, code)
+		}
+	}
+}
+
+func TestKeyByTokenOrIP(t *testing.T) {
+	key := KeyByTokenOrIP(phoneTokens)
+	tests := []struct {
+		name  string
+		token string
+		want  string
+	}{
+		{name: "verified token keys by its number", token: "919000000001", want: "owner:919000000001"},
+		{name: "unverified token keys by ip", token: "made-up", want: "ip:10.0.0.1"},
+		{name: "no token keys by ip", want: "ip:10.0.0.1"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/users/919876543210", nil)
+			req.RemoteAddr = "10.0.0.1:1234"
+			if tc.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+			if got, ok := key(req); !ok || got != tc.want {
+				t.Errorf("expected %q, got %q (%v)", tc.want, got, ok)
+			}
+		})
+	}
+}
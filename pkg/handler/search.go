@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/yourusername/truecaller-lite/pkg/otp"
+)
+
+// searchUsers handles GET /v1/search?q=&offset=&limit=. The caller needs a valid bearer token and is screened
+// for enumeration like a lookup; every number returned counts as one lookup.
+func (h *Handler) searchUsers(w http.ResponseWriter, r *http.Request) {
+	token, ok := bearerToken(r)
+	if !ok {
+		writeError(w, fmt.Errorf("%w: missing bearer token", otp.ErrInvalidToken))
+		return
+	}
+	if _, err := h.searchTokens.VerifyToken(r.Context(), token); err != nil {
+		writeError(w, err)
+		return
+	}
+	offset, err := queryInt(r, "offset")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	limit, err := queryInt(r, "limit")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	client, screened, err := h.screen(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	page, err := h.searchService.SearchUsers(r.Context(), r.URL.Query().Get("q"), offset, limit)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if screened {
+		for _, result := range page.Results {
+			h.detector.Record(r.Context(), client, result.PhoneNumber, true)
+		}
+	}
+	writeJSON(w, http.StatusOK, page)
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao/mem"
+	"github.com/yourusername/truecaller-lite/pkg/enumeration"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+	"github.com/yourusername/truecaller-lite/pkg/search"
+	"github.com/yourusername/truecaller-lite/pkg/service"
+)
+
+func TestHandler_SearchUsers(t *testing.T) {
+	ctx := context.Background()
+	index := search.NewIndex()
+	phoneBookDAO := mem.NewPhoneBookMemDAO()
+	userDAO := search.NewIndexedUserDAO(mem.NewUserMemDAO(), phoneBookDAO, index)
+	userService := service.NewUserService(userDAO, phoneBookDAO)
+	_ = userService.UploadContacts(ctx, "919876543210", []models.Contact{{PhoneNumber: "919000000001", Name: "Rahul Sharma"}, {PhoneNumber: "919000000002", Name: "Rahul Verma"}})
+	_ = userService.UploadContacts(ctx, "919876543211", []models.Contact{{PhoneNumber: "919000000002", Name: "Rahul Verma"}})
+	h := NewHandler(userService, WithSearchService(service.NewSearchService(index, nil), phoneTokens))
+
+	tests := []struct {
+		name       string
+		query      string
+		token      string
+		wantStatus int
+		wantPhones []string
+	}{
+		{name: "ranked by uploaders", query: "?q=rahul", wantStatus: http.StatusOK, wantPhones: []string{"919000000002", "919000000001"}},
+		{name: "paginated", query: "?q=rahul&offset=1&limit=1", wantStatus: http.StatusOK, wantPhones: []string{"919000000001"}},
+		{name: "prefix", query: "?q=rahul+sh", wantStatus: http.StatusOK, wantPhones: []string{"919000000001"}},
+		{name: "missing query", query: "", wantStatus: http.StatusBadRequest},
+		{name: "invalid limit", query: "?q=rahul&limit=-1", wantStatus: http.StatusBadRequest},
+		{name: "two letters", query: "?q=ra", wantStatus: http.StatusBadRequest},
+		{name: "missing token", query: "?q=rahul", token: "-", wantStatus: http.StatusUnauthorized},
+		{name: "invalid token", query: "?q=rahul", token: "made-up", wantStatus: http.StatusUnauthorized},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/search"+tc.query, nil)
+			switch tc.token {
+			case "":
+				req.Header.Set("Authorization", "Bearer 919876543212")
+			case "-":
+			default:
+				req.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tc.wantStatus, rec.Code, rec.Body)
+			}
+			if tc.wantStatus != http.StatusOK {
+				return
+			}
+			var page models.SearchPage
+			if err := json.NewDecoder(rec.Body).Decode(&page); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if len(page.Results) != len(tc.wantPhones) {
+				t.Fatalf("expected %d results, got %+v", len(tc.wantPhones), page.Results)
+			}
+			for i, r := range page.Results {
+				if r.PhoneNumber != tc.wantPhones[i] {
+					t.Errorf("result %d: expected %s, got %s", i, tc.wantPhones[i], r.PhoneNumber)
+				}
+			}
+		})
+	}
+}
+
+func TestHandler_SearchUsers_EnumerationDetector(t *testing.T) {
+	index := search.NewIndex()
+	for i := 1; i <= 6; i++ {
+		index.Put(models.SearchResult{PhoneNumber: fmt.Sprintf("91900000000%d", i), Name: "Rahul", Uploaders: 1})
+	}
+	detector := enumeration.NewDetector(mem.NewOffenderMemDAO(), enumeration.Options{MinLookups: 5})
+	h := NewHandler(service.NewUserService(mem.NewUserMemDAO(), mem.NewPhoneBookMemDAO()),
+		WithSearchService(service.NewSearchService(index, nil), phoneTokens),
+		WithEnumerationDetector(detector, KeyByTokenOrIP(phoneTokens)),
+	)
+	searchAs := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v1/search?q=rahul", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := searchAs("919876543212"); rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body)
+	}
+	// Six consecutive numbers in one page look like a sequential scan
+	if rec := searchAs("919876543212"); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("expected the searcher to be throttled, got %d: %s", rec.Code, rec.Body)
+	}
+	if rec := searchAs("919876543213"); rec.Code != http.StatusOK {
+		t.Errorf("expected other callers to be unaffected, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// recordLookup logs a successful lookup of phone if the request carries a valid bearer token.
+// Logging is best effort: a failure must not fail the lookup itself.
+func (h *Handler) recordLookup(r *http.Request, phone string) {
+	if h.lookupLog == nil {
+		return
+	}
+	token, ok := bearerToken(r)
+	if !ok {
+		return
+	}
+	requester, err := h.tokenVerifier.VerifyToken(r.Context(), token)
+	if err != nil {
+		return
+	}
+	_ = h.lookupLog.RecordLookup(r.Context(), requester, phone)
+}
+
+// listViewers handles GET /v1/users/{phone}/viewers?offset=&limit=.
+func (h *Handler) listViewers(w http.ResponseWriter, r *http.Request) {
+	offset, err := queryInt(r, "offset")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	limit, err := queryInt(r, "limit")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	page, err := h.lookupLog.ListViewers(r.Context(), r.PathValue("phone"), offset, limit)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+// setViewerOptOut returns a handler for POST /v1/users/{phone}/viewers/opt-out and /opt-in.
+func (h *Handler) setViewerOptOut(optOut bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := h.lookupLog.SetOptOut(r.Context(), r.PathValue("phone"), optOut); err != nil {
+			writeError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// queryInt parses an optional non-negative integer query parameter, returning 0 if it is absent.
+func queryInt(r *http.Request, name string) (int, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return 0, badRequest("query parameter " + name + " must be a non-negative integer")
+	}
+	return n, nil
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao/mem"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+	"github.com/yourusername/truecaller-lite/pkg/otp"
+	"github.com/yourusername/truecaller-lite/pkg/service"
+)
+
+// phoneTokens is a token verifier whose tokens are the phone numbers themselves; for tests only.
+var phoneTokens = tokenVerifierFunc(func(ctx context.Context, token string) (string, error) {
+	if len(token) != 12 {
+		return "", otp.ErrInvalidToken
+	}
+	return token, nil
+})
+
+type tokenVerifierFunc func(ctx context.Context, token string) (string, error)
+
+func (f tokenVerifierFunc) VerifyToken(ctx context.Context, token string) (string, error) {
+	return f(ctx, token)
+}
+
+func TestHandler_Viewers(t *testing.T) {
+	ctx := context.Background()
+	userDAO, phoneBookDAO, privacyDAO := mem.NewUserMemDAO(), mem.NewPhoneBookMemDAO(), mem.NewPrivacyMemDAO()
+	userService := service.NewUserService(userDAO, phoneBookDAO, service.WithPrivacyDAO(privacyDAO))
+	_ = userService.UploadContacts(ctx, "919123456789", []models.Contact{{PhoneNumber: "919876543210", Name: "Alice"}})
+	lookupLog := service.NewLookupLogService(mem.NewLookupLogMemDAO(), userDAO, privacyDAO, service.LookupLogOptions{})
+	h := NewHandler(userService, WithLookupLog(lookupLog, phoneTokens), WithOwnerVerifier(NewTokenOwnerVerifier(phoneTokens)))
+	do := func(method, path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	do(http.MethodGet, "/v1/users/919876543210", "919123456789")
+	do(http.MethodGet, "/v1/users/919876543210", "919123456780")
+	do(http.MethodGet, "/v1/users/919876543210", "")
+	do(http.MethodGet, "/v1/users/919876543210", "bogus")
+	if rec := do(http.MethodPost, "/v1/users/919123456780/viewers/opt-out", "919123456780"); rec.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusNoContent, rec.Code, rec.Body)
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		token      string
+		wantStatus int
+		wantPhones []string
+	}{
+		{name: "owner", path: "/v1/users/919876543210/viewers", token: "919876543210", wantStatus: http.StatusOK, wantPhones: []string{"919123456789"}},
+		{name: "someone else", path: "/v1/users/919876543210/viewers", token: "919123456789", wantStatus: http.StatusForbidden},
+		{name: "bad limit", path: "/v1/users/919876543210/viewers?limit=-1", token: "919876543210", wantStatus: http.StatusBadRequest},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := do(http.MethodGet, tc.path, tc.token)
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tc.wantStatus, rec.Code, rec.Body)
+			}
+			if tc.wantStatus != http.StatusOK {
+				return
+			}
+			var page models.ViewerPage
+			if err := json.NewDecoder(rec.Body).Decode(&page); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(page.Viewers) != len(tc.wantPhones) || page.Viewers[0].PhoneNumber != tc.wantPhones[0] || page.Viewers[0].Name != "" {
+				t.Errorf("expected viewers %v, got %+v", tc.wantPhones, page.Viewers)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Validation error codes. Codes are stable identifiers intended for clients; messages may change.
+const (
+	// CodeRequired indicates a required field is missing or blank.
+	CodeRequired = "required"
+	// CodeInvalidFormat indicates a field does not match the expected format.
+	CodeInvalidFormat = "invalid_format"
+	// CodeTooLong indicates a field exceeds its maximum length.
+	CodeTooLong = "too_long"
+	// CodeMalformed indicates an input record could not be decoded at all.
+	CodeMalformed = "malformed"
+)
+
+// NoIndex is the ValidationError.Index value for fields that are not part of a list.
+const NoIndex = -1
+
+// ValidationError describes a single business rule violation on a model field.
+// Use errors.As to extract it from errors returned by Validate methods.
+type ValidationError struct {
+	// Field is the JSON name of the offending field, e.g. "phone_number" or "contacts.name".
+	Field string
+	// Index is the position of the offending element in its list, or NoIndex.
+	Index int
+	// Code is a stable machine-readable error code, e.g. CodeInvalidFormat.
+	Code string
+	// Message is a human-readable description of the violation.
+	Message string
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	if e.Index == NoIndex {
+		return e.Field + ": " + e.Message
+	}
+	return e.Field + "[" + strconv.Itoa(e.Index) + "]: " + e.Message
+}
+
+// ValidationErrors aggregates multiple validation failures, e.g. one per invalid contact in a phone book.
+// errors.As and errors.Is inspect every contained error.
+type ValidationErrors []*ValidationError
+
+// Error implements the error interface by joining all contained messages.
+func (errs ValidationErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, e := range errs {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the contained errors so errors.As can match individual ValidationErrors.
+func (errs ValidationErrors) Unwrap() []error {
+	result := make([]error, len(errs))
+	for i, e := range errs {
+		result[i] = e
+	}
+	return result
+}
+
+// withIndex returns a copy of the error scoped to a list element, e.g. "contacts.name" at index 3.
+func (e *ValidationError) withIndex(prefix string, index int) *ValidationError {
+	return &ValidationError{Field: prefix + "." + e.Field, Index: index, Code: e.Code, Message: e.Message}
+}
+
+// CollectValidationErrors returns every ValidationError found in err's tree, or nil if there are none.
+func CollectValidationErrors(err error) ValidationErrors {
+	var result ValidationErrors
+	var walk func(error)
+	walk = func(err error) {
+		if err == nil {
+			return
+		}
+		if ve, ok := err.(*ValidationError); ok {
+			result = append(result, ve)
+			return
+		}
+		switch x := err.(type) {
+		case interface{ Unwrap() []error }:
+			for _, e := range x.Unwrap() {
+				walk(e)
+			}
+		case interface{ Unwrap() error }:
+			walk(x.Unwrap())
+		}
+	}
+	walk(err)
+	return result
+}
+
+// ErrorResponse is the machine-readable error body returned to API clients.
+type ErrorResponse struct {
+	// Code is a stable machine-readable error code, e.g. "validation_failed".
+	Code string `json:"code"`
+	// Message is a human-readable summary of the error.
+	Message string `json:"message"`
+	// Details lists individual field violations, if any.
+	Details []ErrorDetail `json:"details,omitempty"`
+}
+
+// ErrorDetail is a single field violation within an ErrorResponse.
+type ErrorDetail struct {
+	// Field is the JSON name of the offending field.
+	Field string `json:"field"`
+	// Index is the position of the offending element in its list; omitted for non-list fields.
+	Index *int `json:"index,omitempty"`
+	// Code is a stable machine-readable error code, e.g. "invalid_format".
+	Code string `json:"code"`
+	// Message is a human-readable description of the violation.
+	Message string `json:"message"`
+}
+
+// ErrCodeValidationFailed is the ErrorResponse code for requests rejected by model validation.
+const ErrCodeValidationFailed = "validation_failed"
+
+// NewValidationErrorResponse renders the validation errors in err as an ErrorResponse.
+// The second return value is false if err contains no ValidationError.
+func NewValidationErrorResponse(err error) (*ErrorResponse, bool) {
+	errs := CollectValidationErrors(err)
+	if len(errs) == 0 {
+		return nil, false
+	}
+	resp := &ErrorResponse{Code: ErrCodeValidationFailed, Message: "request validation failed"}
+	for _, e := range errs {
+		detail := ErrorDetail{Field: e.Field, Code: e.Code, Message: e.Message}
+		if e.Index != NoIndex {
+			index := e.Index
+			detail.Index = &index
+		}
+		resp.Details = append(resp.Details, detail)
+	}
+	return resp, true
+}
@@ -0,0 +1,129 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestPhoneBookValidate_AggregatesContactErrors(t *testing.T) {
+	pb := PhoneBook{PhoneNumber: "919876543210", Contacts: []Contact{
+		{PhoneNumber: "919123456789", Name: "Alice"},
+		{PhoneNumber: "123", Name: "Bob"},
+		{PhoneNumber: "919123456780", Name: " "},
+	}}
+	err := pb.Validate()
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected ValidationErrors, got %T: %v", err, err)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %d: %v", len(errs), errs)
+	}
+	want := []ValidationError{
+		{Field: "contacts.phone_number", Index: 1, Code: CodeInvalidFormat},
+		{Field: "contacts.name", Index: 2, Code: CodeRequired},
+	}
+	for i, w := range want {
+		if errs[i].Field != w.Field || errs[i].Index != w.Index || errs[i].Code != w.Code {
+			t.Errorf("error %d: expected %+v, got %+v", i, w, *errs[i])
+		}
+	}
+	if got := errs[0].Error(); got != "contacts.phone_number[1]: phone number must be 12 digits and start with '91'" {
+		t.Errorf("unexpected message: %q", got)
+	}
+}
+
+func TestContactValidationErrors(t *testing.T) {
+	nameErr := &ValidationError{Field: "name", Index: NoIndex, Code: CodeRequired, Message: "name is required"}
+	phoneErr := &ValidationError{Field: "phone_number", Index: NoIndex, Code: CodeInvalidFormat, Message: "bad number"}
+	tests := []struct {
+		name string
+		err  error
+		want []ValidationError
+	}{
+		{name: "single", err: nameErr, want: []ValidationError{{Field: "contacts.name", Index: 3, Code: CodeRequired, Message: "name is required"}}},
+		{
+			name: "several wrapped",
+			err:  fmt.Errorf("contact: %w", ValidationErrors{phoneErr, nameErr}),
+			want: []ValidationError{
+				{Field: "contacts.phone_number", Index: 3, Code: CodeInvalidFormat, Message: "bad number"},
+				{Field: "contacts.name", Index: 3, Code: CodeRequired, Message: "name is required"},
+			},
+		},
+		{name: "not a validation error", err: errors.New("lexicon unavailable"), want: []ValidationError{{Field: "contacts", Index: 3, Code: CodeInvalidFormat, Message: "lexicon unavailable"}}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := contactValidationErrors(3, tc.err)
+			if len(got) != len(tc.want) {
+				t.Fatalf("expected %d errors, got %v", len(tc.want), got)
+			}
+			for i, w := range tc.want {
+				if *got[i] != w {
+					t.Errorf("error %d: expected %+v, got %+v", i, w, *got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidationError_ErrorsAs(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantField string
+		wantIndex int
+	}{
+		{
+			name:      "user error",
+			err:       (&User{PhoneNumber: "919876543210", Name: string(make([]byte, 101))}).Validate(),
+			wantField: "name",
+			wantIndex: NoIndex,
+		},
+		{
+			name:      "phone book owner error",
+			err:       (&PhoneBook{PhoneNumber: "123"}).Validate(),
+			wantField: "phone_number",
+			wantIndex: NoIndex,
+		},
+		{
+			name:      "wrapped aggregate",
+			err:       fmt.Errorf("upload: %w", (&PhoneBook{PhoneNumber: "919876543210", Contacts: []Contact{{PhoneNumber: "91abcdefghij", Name: "Bob"}}}).Validate()),
+			wantField: "contacts.phone_number",
+			wantIndex: 0,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var ve *ValidationError
+			if !errors.As(tc.err, &ve) {
+				t.Fatalf("expected *ValidationError, got %T: %v", tc.err, tc.err)
+			}
+			if ve.Field != tc.wantField || ve.Index != tc.wantIndex {
+				t.Errorf("expected field %s index %d, got %s index %d", tc.wantField, tc.wantIndex, ve.Field, ve.Index)
+			}
+		})
+	}
+}
+
+func TestNewValidationErrorResponse(t *testing.T) {
+	err := (&PhoneBook{PhoneNumber: "919876543210", Contacts: []Contact{{PhoneNumber: "123", Name: "Bob"}}}).Validate()
+	resp, ok := NewValidationErrorResponse(err)
+	if !ok {
+		t.Fatal("expected a validation error response")
+	}
+	body, jsonErr := json.Marshal(resp)
+	if jsonErr != nil {
+		t.Fatalf("unexpected error: %v", jsonErr)
+	}
+	want := `{"code":"validation_failed","message":"request validation failed","details":[{"field":"contacts.phone_number","index":0,"code":"invalid_format","message":"phone number must be 12 digits and start with '91'"}]}`
+	if string(body) != want {
+		t.Errorf("expected %s, got %s", want, body)
+	}
+
+	if _, ok := NewValidationErrorResponse(errors.New("storage error")); ok {
+		t.Error("expected no response for a non-validation error")
+	}
+}
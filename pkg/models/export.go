@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// ContactEntry is a contact as saved in one owner's phone book, as returned by reverse lookups.
+type ContactEntry struct {
+	// OwnerPhoneNumber is the phone number of the user whose phone book holds the contact.
+	OwnerPhoneNumber string `json:"owner_phone_number"`
+	// Contact is the saved contact.
+	Contact Contact `json:"contact"`
+	// SavedAt is when the owner last uploaded the contact.
+	SavedAt time.Time `json:"saved_at"`
+}
+
+// SavedName is a name another user saved a phone number under. The uploader is anonymized.
+type SavedName struct {
+	// Uploader is an opaque alias such as "uploader-1", stable only within one export.
+	Uploader string `json:"uploader"`
+	// Name is the name the uploader saved.
+	Name string `json:"name"`
+}
+
+// UserDataExport is everything held about a phone number, assembled for data portability requests.
+type UserDataExport struct {
+	// PhoneNumber is the phone number the export is for.
+	PhoneNumber string `json:"phone_number"`
+	// GeneratedAt is when the export was assembled.
+	GeneratedAt time.Time `json:"generated_at"`
+	// Profile is the resolved user record (name and spam status), if one exists.
+	Profile *User `json:"profile,omitempty"`
+	// PhoneBook is the phone book uploaded by this number.
+	PhoneBook []Contact `json:"phone_book"`
+	// SavedAs lists the names other users saved this number under.
+	SavedAs []SavedName `json:"saved_as"`
+	// Unlisted reports whether the number has opted out of caller ID.
+	Unlisted bool `json:"unlisted"`
+	// LookupsMade lists the authenticated lookups the number made, most recent first.
+	LookupsMade []LookupRecord `json:"lookups_made"`
+	// ViewedBy lists who looked the number up, most recent first, leaving out viewers who opted out.
+	ViewedBy []Viewer `json:"viewed_by"`
+	// SpamStatusHistory lists spam status changes for this number, oldest first.
+	SpamStatusHistory []SpamStatusChange `json:"spam_status_history"`
+	// SpamReportsFiled lists the spam reports this number filed against others.
+	SpamReportsFiled []SpamReport `json:"spam_reports_filed"`
+}
@@ -0,0 +1,51 @@
+package models
+
+// LookupResult is a detailed caller ID lookup: the resolved name plus the other names the number is saved under.
+// Business rules:
+// - Name is the name a plain lookup returns (the display name if set, else the crowd name).
+// - Person is Name split into its parts, so clients can show the clean name and organization separately.
+// - Alternatives lists crowd-sourced names, most widely used first, and may include Name itself.
+type LookupResult struct {
+	// PhoneNumber is the number that was looked up.
+	PhoneNumber string `json:"phone_number"`
+	// Name is the resolved name.
+	Name string `json:"name"`
+	// IsSpam indicates if the number is marked as spam.
+	IsSpam bool `json:"is_spam"`
+	// Person is the resolved name split into honorific, given name, family name and organization.
+	Person *PersonName `json:"person,omitempty"`
+	// Alternatives lists the top candidate names with how many distinct uploaders used each.
+	Alternatives []NameCandidate `json:"alternatives"`
+}
+
+// NameCandidate is one name a number is saved under across users' phone books.
+type NameCandidate struct {
+	// Name is the most recently saved spelling of the name.
+	Name string `json:"name"`
+	// Uploaders is the number of distinct users who saved the number under this name.
+	Uploaders int `json:"uploaders"`
+}
+
+// GetAlternatives returns the candidate names. Returns nil if receiver is nil.
+func (r *LookupResult) GetAlternatives() []NameCandidate {
+	if r == nil {
+		return nil
+	}
+	return r.Alternatives
+}
+
+// GetPerson returns the parsed resolved name. Returns nil if receiver is nil.
+func (r *LookupResult) GetPerson() *PersonName {
+	if r == nil {
+		return nil
+	}
+	return r.Person
+}
+
+// GetIsSpam returns the spam status. Returns false if receiver is nil.
+func (r *LookupResult) GetIsSpam() bool {
+	if r == nil {
+		return false
+	}
+	return r.IsSpam
+}
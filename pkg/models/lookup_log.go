@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// LookupRecord is one authenticated lookup: who looked up which number, and when.
+type LookupRecord struct {
+	// RequesterPhoneNumber is the verified phone number of the user who made the lookup.
+	RequesterPhoneNumber string `json:"requester_phone_number"`
+	// PhoneNumber is the number that was looked up.
+	PhoneNumber string `json:"phone_number"`
+	// LookedUpAt is when the lookup was made.
+	LookedUpAt time.Time `json:"looked_up_at"`
+}
+
+// Viewer is an entry in a number owner's "who viewed me" list.
+type Viewer struct {
+	// PhoneNumber is the viewer's phone number.
+	PhoneNumber string `json:"phone_number"`
+	// Name is the viewer's resolved name, empty if unknown or unlisted.
+	Name string `json:"name,omitempty"`
+	// ViewedAt is when the viewer looked the owner up.
+	ViewedAt time.Time `json:"viewed_at"`
+}
+
+// ViewerPage is one page of a "who viewed me" list, most recent first.
+type ViewerPage struct {
+	// Viewers is the page of viewers.
+	Viewers []Viewer `json:"viewers"`
+	// Total is the number of viewers across all pages.
+	Total int `json:"total"`
+	// Offset is the position of the first viewer on this page.
+	Offset int `json:"offset"`
+	// Limit is the maximum page size.
+	Limit int `json:"limit"`
+	// HasMore reports whether another page follows.
+	HasMore bool `json:"has_more"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// CodeOffensive indicates a name was rejected by the moderation filter.
+const CodeOffensive = "offensive"
+
+// SuppressedName is a crowd-sourced name withheld from caller ID because the moderation filter flagged it.
+type SuppressedName struct {
+	// Name is the name as the uploader saved it.
+	Name string `json:"name"`
+	// UploaderPhoneNumber is the phone number of the user whose phone book holds the name.
+	UploaderPhoneNumber string `json:"uploader_phone_number"`
+	// SavedAt is when the uploader last uploaded the name.
+	SavedAt time.Time `json:"saved_at"`
+}
+
+// NameModeration is the "name suppressed" state of a phone number, kept for moderator review.
+// Business rules:
+// - It exists only while at least one phone book holds an offensive name for the number.
+// - Suppressed names are never chosen as the number's crowd name.
+type NameModeration struct {
+	// PhoneNumber is the number the names were saved for.
+	PhoneNumber string `json:"phone_number"`
+	// Suppressed lists the offensive names currently saved for the number, most recent first.
+	Suppressed []SuppressedName `json:"suppressed"`
+	// UpdatedAt is when the state was last recomputed.
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// Enumeration signals that can flag a lookup client as an offender.
+const (
+	// SignalSequential means the client looked up numbers in sequence, e.g. 919876500000, 919876500001, ...
+	SignalSequential = "sequential_scan"
+	// SignalDensePrefix means the client looked up many distinct numbers sharing a prefix.
+	SignalDensePrefix = "dense_prefix_scan"
+	// SignalNotFound means most of the client's lookups were for unknown numbers.
+	SignalNotFound = "high_not_found_ratio"
+)
+
+// Offender actions, in escalating order.
+const (
+	// ActionThrottle slows the client's lookups down.
+	ActionThrottle = "throttle"
+	// ActionBlock rejects all of the client's lookups for a while.
+	ActionBlock = "block"
+)
+
+// Offender is a lookup client flagged for number enumeration, kept for admin review.
+type Offender struct {
+	// ClientKey identifies the client, e.g. an IP address or a hashed session token.
+	ClientKey string `json:"client_key"`
+	// Signals lists the enumeration signals that flagged the client, most recent last, without duplicates.
+	Signals []string `json:"signals"`
+	// Action is the strongest action taken against the client, ActionThrottle or ActionBlock.
+	Action string `json:"action"`
+	// Detections counts how often the client was flagged.
+	Detections int `json:"detections"`
+	// FirstSeen is when the client was first flagged.
+	FirstSeen time.Time `json:"first_seen"`
+	// LastSeen is when the client was last flagged.
+	LastSeen time.Time `json:"last_seen"`
+	// SampleNumbers holds a few of the numbers looked up in the window that triggered the last detection.
+	SampleNumbers []string `json:"sample_numbers,omitempty"`
+}
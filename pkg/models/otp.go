@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// OTPChallenge is a pending one-time password sent to a phone number to prove its ownership.
+// Only a salted hash of the code is stored; the code itself is never persisted.
+type OTPChallenge struct {
+	// PhoneNumber is the phone number being verified.
+	PhoneNumber string `json:"phone_number"`
+	// CodeHash is the salted SHA-256 hash of the code.
+	CodeHash []byte `json:"-"`
+	// Salt is the random salt mixed into CodeHash.
+	Salt []byte `json:"-"`
+	// ExpiresAt is when the code stops being accepted.
+	ExpiresAt time.Time `json:"expires_at"`
+	// Attempts counts failed verification attempts against the current code.
+	Attempts int `json:"attempts"`
+	// LastSentAt is when the current code was sent.
+	LastSentAt time.Time `json:"last_sent_at"`
+	// WindowStart is when the current resend window began.
+	WindowStart time.Time `json:"window_start"`
+	// SendCount counts codes sent within the current resend window.
+	SendCount int `json:"send_count"`
+}
@@ -0,0 +1,60 @@
+package models
+
+import "strings"
+
+// PersonName is a contact name split into its parts, e.g. "Dr. Anita Rao - Apollo" into honorific "Dr.", given
+// name "Anita", family name "Rao" and organization "Apollo".
+// Business rules:
+// - Parts keep the spelling of the name they were parsed from.
+// - GivenName holds every word of the person's name but the last; a single-word name has no FamilyName.
+type PersonName struct {
+	// Honorific is the title before the name, such as "Dr." or "Shri".
+	Honorific string `json:"honorific,omitempty"`
+	// GivenName is the person's first and middle names.
+	GivenName string `json:"given_name,omitempty"`
+	// FamilyName is the person's last name.
+	FamilyName string `json:"family_name,omitempty"`
+	// Organization is the company or place the name was annotated with, such as "Apollo" in "Anita - Apollo".
+	Organization string `json:"organization,omitempty"`
+}
+
+// FullName returns the clean name of the person: the given and family names, without honorific or organization.
+// Returns empty string if receiver is nil.
+func (p *PersonName) FullName() string {
+	if p == nil {
+		return ""
+	}
+	return strings.TrimSpace(p.GivenName + " " + p.FamilyName)
+}
+
+// GetHonorific returns the honorific. Returns empty string if receiver is nil.
+func (p *PersonName) GetHonorific() string {
+	if p == nil {
+		return ""
+	}
+	return p.Honorific
+}
+
+// GetGivenName returns the given name. Returns empty string if receiver is nil.
+func (p *PersonName) GetGivenName() string {
+	if p == nil {
+		return ""
+	}
+	return p.GivenName
+}
+
+// GetFamilyName returns the family name. Returns empty string if receiver is nil.
+func (p *PersonName) GetFamilyName() string {
+	if p == nil {
+		return ""
+	}
+	return p.FamilyName
+}
+
+// GetOrganization returns the organization. Returns empty string if receiver is nil.
+func (p *PersonName) GetOrganization() string {
+	if p == nil {
+		return ""
+	}
+	return p.Organization
+}
@@ -0,0 +1,25 @@
+package models
+
+import "strings"
+
+// NormalizePhoneNumber converts a phone number as written in an address book, e.g. "+91 98765-43210",
+// "0091 9876543210" or "09876543210", to the canonical 12-digit form starting with "91".
+// Formatting characters are dropped; numbers that cannot be mapped are returned as their digits only,
+// so they still fail Validate.
+func NormalizePhoneNumber(raw string) string {
+	var b strings.Builder
+	for _, r := range raw {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	digits := b.String()
+	digits = strings.TrimPrefix(digits, "00")
+	switch {
+	case len(digits) == 11 && strings.HasPrefix(digits, "0"):
+		return "91" + digits[1:]
+	case len(digits) == 10:
+		return "91" + digits
+	}
+	return digits
+}
@@ -0,0 +1,26 @@
+package models
+
+import "testing"
+
+func TestNormalizePhoneNumber(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{name: "canonical", raw: "919876543210", want: "919876543210"},
+		{name: "international with plus and spaces", raw: "+91 98765 43210", want: "919876543210"},
+		{name: "international with 00 prefix", raw: "0091-98765-43210", want: "919876543210"},
+		{name: "trunk prefix", raw: "098765 43210", want: "919876543210"},
+		{name: "local ten digits", raw: "(987) 654-3210", want: "919876543210"},
+		{name: "too short stays invalid", raw: "12345", want: "12345"},
+		{name: "empty", raw: "", want: ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := NormalizePhoneNumber(tc.raw); got != tc.want {
+				t.Errorf("expected %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
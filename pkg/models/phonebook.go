@@ -1,9 +1,5 @@
 package models
 
-import (
-	"errors"
-)
-
 // Contact represents a single contact entry in a user's phone book.
 // Business rules:
 // - PhoneNumber must be a 10-digit string starting with "91".
@@ -13,6 +9,12 @@ type Contact struct {
 	PhoneNumber string `json:"phone_number" validate:"required,len=12,startswith=91,numeric"`
 	// Name is the contact's name as uploaded from a phone book.
 	Name string `json:"name" validate:"required,min=1,max=100"`
+	// NormalizedName is Name cleaned up for aggregation (see package names), set by the service when the contact
+	// is stored. Empty if nothing name-like is left, e.g. for a name made only of emoji.
+	NormalizedName string `json:"normalized_name,omitempty"`
+	// ParsedName is Name split into honorific, given name, family name and organization (see names.Parse), set by
+	// the service when the contact is stored. Nil if nothing name-like is left.
+	ParsedName *PersonName `json:"parsed_name,omitempty"`
 }
 
 // Validate checks the Contact fields for business rule compliance.
@@ -37,6 +39,22 @@ func (c *Contact) GetName() string {
 	return c.Name
 }
 
+// GetNormalizedName returns the contact's normalized name. Returns empty string if receiver is nil.
+func (c *Contact) GetNormalizedName() string {
+	if c == nil {
+		return ""
+	}
+	return c.NormalizedName
+}
+
+// GetParsedName returns the contact's parsed name. Returns nil if receiver is nil.
+func (c *Contact) GetParsedName() *PersonName {
+	if c == nil {
+		return nil
+	}
+	return c.ParsedName
+}
+
 // PhoneBook represents a user's phone book (list of contacts).
 // Business rules:
 // - PhoneNumber is the owner of the phone book (the uploader's phone number).
@@ -49,18 +67,39 @@ type PhoneBook struct {
 }
 
 // Validate checks the PhoneBook fields and all contained contacts for business rule compliance.
+// An invalid owner is returned as a single *ValidationError; invalid contacts are aggregated into
+// ValidationErrors with one entry per violation, indexed by the contact's position. A contact error that holds no
+// *ValidationError is reported as CodeInvalidFormat with the error's message, like NewRejectedContact does.
 func (pb *PhoneBook) Validate() error {
-	if err := (&User{PhoneNumber: pb.GetPhoneNumber(), Name: "dummy"}).Validate(); err != nil {
-		return errors.New("invalid phone book owner: " + err.Error())
+	if err := validatePhoneNumber(pb.GetPhoneNumber()); err != nil {
+		err.Message = "invalid phone book owner: " + err.Message
+		return err
 	}
+	var errs ValidationErrors
 	for i, c := range pb.GetContacts() {
 		if err := c.Validate(); err != nil {
-			return errors.New("invalid contact at index " + string(rune(i)) + ": " + err.Error())
+			errs = append(errs, contactValidationErrors(i, err)...)
 		}
 	}
+	if len(errs) > 0 {
+		return errs
+	}
 	return nil
 }
 
+// contactValidationErrors scopes every ValidationError in err to the contact at index, or wraps err in one if it
+// holds none.
+func contactValidationErrors(index int, err error) ValidationErrors {
+	var result ValidationErrors
+	for _, ve := range CollectValidationErrors(err) {
+		result = append(result, ve.withIndex("contacts", index))
+	}
+	if len(result) == 0 {
+		result = append(result, &ValidationError{Field: "contacts", Index: index, Code: CodeInvalidFormat, Message: err.Error()})
+	}
+	return result
+}
+
 // GetPhoneNumber returns the phone book owner's phone number. Returns empty string if receiver is nil.
 func (pb *PhoneBook) GetPhoneNumber() string {
 	if pb == nil {
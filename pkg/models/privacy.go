@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// PrivacySettings is the privacy state of one phone number.
+type PrivacySettings struct {
+	// PhoneNumber is the phone number the settings apply to.
+	PhoneNumber string `json:"phone_number"`
+	// Erased reports whether the number's data was erased. Erased numbers get no crowd-sourced name until the
+	// erasure's tombstone is pruned.
+	Erased bool `json:"erased,omitempty"`
+	// ErasedAt is when the number was last erased, if it was.
+	ErasedAt *time.Time `json:"erased_at,omitempty"`
+	// Unlisted reports whether the number has opted out of caller ID.
+	Unlisted bool `json:"unlisted,omitempty"`
+	// LookupLogOptOut reports whether the number's lookups are hidden from "who viewed me" lists.
+	LookupLogOptOut bool `json:"lookup_log_opt_out,omitempty"`
+}
@@ -0,0 +1,27 @@
+package models
+
+// SearchResult is a number found by searching resolved names.
+type SearchResult struct {
+	// PhoneNumber is the matching number.
+	PhoneNumber string `json:"phone_number"`
+	// Name is the number's resolved name (the display name if set, else the crowd name).
+	Name string `json:"name"`
+	// IsSpam indicates if the number is marked as spam.
+	IsSpam bool `json:"is_spam"`
+	// Uploaders is the number of distinct users who have the number in their phone book.
+	Uploaders int `json:"uploaders"`
+}
+
+// SearchPage is one page of name search results, most widely saved numbers first.
+type SearchPage struct {
+	// Results is the page of results.
+	Results []SearchResult `json:"results"`
+	// Total is the number of results across all pages.
+	Total int `json:"total"`
+	// Offset is the position of the first result on this page.
+	Offset int `json:"offset"`
+	// Limit is the maximum page size.
+	Limit int `json:"limit"`
+	// HasMore reports whether another page follows.
+	HasMore bool `json:"has_more"`
+}
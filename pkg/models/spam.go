@@ -0,0 +1,70 @@
+package models
+
+import "time"
+
+// SpamReport represents one user's report that a phone number is spam.
+// Business rules:
+// - ReporterPhoneNumber and PhoneNumber must be valid phone numbers and must differ.
+// - Reason is optional free text of at most 200 characters.
+type SpamReport struct {
+	// ReporterPhoneNumber is the phone number of the user filing the report.
+	ReporterPhoneNumber string `json:"reporter_phone_number" validate:"required,len=12,startswith=91,numeric"`
+	// PhoneNumber is the reported phone number.
+	PhoneNumber string `json:"phone_number" validate:"required,len=12,startswith=91,numeric"`
+	// Reason is the reporter's free-text reason, if any.
+	Reason string `json:"reason,omitempty" validate:"max=200"`
+	// ReportedAt is when the report was filed.
+	ReportedAt time.Time `json:"reported_at"`
+}
+
+// Validate checks the SpamReport fields for business rule compliance.
+func (r *SpamReport) Validate() error {
+	if err := validatePhoneNumber(r.GetReporterPhoneNumber()); err != nil {
+		err.Field = "reporter_phone_number"
+		return err
+	}
+	if err := validatePhoneNumber(r.GetPhoneNumber()); err != nil {
+		return err
+	}
+	if r.GetReporterPhoneNumber() == r.GetPhoneNumber() {
+		return &ValidationError{Field: "phone_number", Index: NoIndex, Code: CodeInvalidFormat, Message: "cannot report your own phone number"}
+	}
+	if len(r.GetReason()) > 200 {
+		return &ValidationError{Field: "reason", Index: NoIndex, Code: CodeTooLong, Message: "reason must be at most 200 characters"}
+	}
+	return nil
+}
+
+// GetReporterPhoneNumber returns the reporter's phone number. Returns empty string if receiver is nil.
+func (r *SpamReport) GetReporterPhoneNumber() string {
+	if r == nil {
+		return ""
+	}
+	return r.ReporterPhoneNumber
+}
+
+// GetPhoneNumber returns the reported phone number. Returns empty string if receiver is nil.
+func (r *SpamReport) GetPhoneNumber() string {
+	if r == nil {
+		return ""
+	}
+	return r.PhoneNumber
+}
+
+// GetReason returns the report reason. Returns empty string if receiver is nil.
+func (r *SpamReport) GetReason() string {
+	if r == nil {
+		return ""
+	}
+	return r.Reason
+}
+
+// SpamStatusChange records a change of a phone number's spam status by the nightly job.
+type SpamStatusChange struct {
+	// PhoneNumber is the phone number whose status changed.
+	PhoneNumber string `json:"phone_number"`
+	// IsSpam is the new spam status.
+	IsSpam bool `json:"is_spam"`
+	// ChangedAt is when the status changed.
+	ChangedAt time.Time `json:"changed_at"`
+}
@@ -0,0 +1,71 @@
+package models
+
+// UploadReport summarizes the outcome of a partial-acceptance or streaming contact upload.
+// Business rules:
+// - Total counts every contact received, valid or not.
+// - Accepted counts the contacts that passed validation and were stored.
+// - Rejected lists every contact that failed validation, in upload order.
+type UploadReport struct {
+	// Total is the number of contacts received.
+	Total int `json:"total"`
+	// Accepted is the number of contacts stored.
+	Accepted int `json:"accepted"`
+	// Rejected lists the contacts that were skipped, with reasons.
+	Rejected []RejectedContact `json:"rejected"`
+}
+
+// RejectedContact describes a contact skipped during a partial-acceptance upload.
+type RejectedContact struct {
+	// Index is the contact's position in the uploaded list.
+	Index int `json:"index"`
+	// Contact is the contact as uploaded.
+	Contact Contact `json:"contact"`
+	// Reasons lists the validation failures for this contact.
+	Reasons []ErrorDetail `json:"reasons"`
+}
+
+// NewRejectedContact builds a RejectedContact from a contact and the error returned by its Validate method.
+func NewRejectedContact(index int, contact Contact, err error) RejectedContact {
+	rejected := RejectedContact{Index: index, Contact: contact}
+	for _, ve := range CollectValidationErrors(err) {
+		rejected.Reasons = append(rejected.Reasons, ErrorDetail{Field: ve.Field, Code: ve.Code, Message: ve.Message})
+	}
+	if len(rejected.Reasons) == 0 {
+		rejected.Reasons = []ErrorDetail{{Code: CodeInvalidFormat, Message: err.Error()}}
+	}
+	return rejected
+}
+
+// UploadProgress is a snapshot of a streaming upload, reported after each stored chunk.
+type UploadProgress struct {
+	// Processed is the number of contacts read so far.
+	Processed int `json:"processed"`
+	// Accepted is the number of contacts stored so far.
+	Accepted int `json:"accepted"`
+	// Rejected is the number of contacts skipped so far.
+	Rejected int `json:"rejected"`
+}
+
+// GetTotal returns the number of contacts received. Returns 0 if receiver is nil.
+func (r *UploadReport) GetTotal() int {
+	if r == nil {
+		return 0
+	}
+	return r.Total
+}
+
+// GetAccepted returns the number of accepted contacts. Returns 0 if receiver is nil.
+func (r *UploadReport) GetAccepted() int {
+	if r == nil {
+		return 0
+	}
+	return r.Accepted
+}
+
+// GetRejected returns the rejected contacts. Returns nil if receiver is nil.
+func (r *UploadReport) GetRejected() []RejectedContact {
+	if r == nil {
+		return nil
+	}
+	return r.Rejected
+}
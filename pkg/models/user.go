@@ -1,38 +1,74 @@
 package models
 
 import (
-	"errors"
 	"regexp"
 	"strings"
 )
 
 // User represents a user in the system.
 // Business rules:
-// - PhoneNumber is the unique identifier for a user.
-// - Name is the most recent name uploaded for this user.
-// - IsSpam is set by a nightly job, not by user input.
+//   - PhoneNumber is the unique identifier for a user.
+//   - Name is the most recent name uploaded for this user (the crowd name).
+//   - DisplayName is set by the verified owner of the number and takes precedence over Name in lookups.
+//   - At least one of Name and DisplayName is set, unless IsSpam is: a number flagged as spam keeps its record,
+//     and so its spam status, after losing both names.
+//   - IsSpam is set by a nightly job, not by user input.
 type User struct {
 	// PhoneNumber is the user's unique identifier. Must be a 10-digit string starting with "91".
 	PhoneNumber string `json:"phone_number" validate:"required,len=12,startswith=91,numeric"`
 	// Name is the user's name as uploaded from a phone book.
-	Name string `json:"name" validate:"required,min=1,max=100"`
+	Name string `json:"name" validate:"max=100"`
+	// DisplayName is the name the number's verified owner chose for themselves, if any.
+	DisplayName string `json:"display_name,omitempty" validate:"max=100"`
 	// IsSpam indicates if the user is marked as spam (populated by nightly job).
 	IsSpam bool `json:"is_spam"`
 }
 
 // Validate checks the User fields for business rule compliance.
+// Violations are returned as *ValidationError.
 func (u *User) Validate() error {
-	if len(u.GetPhoneNumber()) != 12 || !strings.HasPrefix(u.GetPhoneNumber(), "91") {
-		return errors.New("phone number must be 12 digits and start with '91'")
+	if err := validatePhoneNumber(u.GetPhoneNumber()); err != nil {
+		return err
 	}
-	if !regexp.MustCompile(`^91[0-9]{10}$`).MatchString(u.GetPhoneNumber()) {
-		return errors.New("phone number must be numeric and 10 digits after '91'")
+	if u.GetDisplayName() != "" {
+		if err := validateName(u.GetDisplayName()); err != nil {
+			err.Field = "display_name"
+			return err
+		}
+		if u.GetName() == "" {
+			return nil
+		}
 	}
-	if len(strings.TrimSpace(u.GetName())) == 0 {
-		return errors.New("name is required")
+	if u.GetName() == "" && u.GetDisplayName() == "" && u.GetIsSpam() {
+		return nil
 	}
-	if len(u.GetName()) > 100 {
-		return errors.New("name must be at most 100 characters")
+	if err := validateName(u.GetName()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// phoneNumberPattern matches a 12-digit phone number starting with "91".
+var phoneNumberPattern = regexp.MustCompile(`^91[0-9]{10}$`)
+
+// validatePhoneNumber checks that phoneNumber is 12 digits and starts with "91".
+func validatePhoneNumber(phoneNumber string) *ValidationError {
+	if len(phoneNumber) != 12 || !strings.HasPrefix(phoneNumber, "91") {
+		return &ValidationError{Field: "phone_number", Index: NoIndex, Code: CodeInvalidFormat, Message: "phone number must be 12 digits and start with '91'"}
+	}
+	if !phoneNumberPattern.MatchString(phoneNumber) {
+		return &ValidationError{Field: "phone_number", Index: NoIndex, Code: CodeInvalidFormat, Message: "phone number must be numeric and 10 digits after '91'"}
+	}
+	return nil
+}
+
+// validateName checks that name is non-blank and at most 100 characters.
+func validateName(name string) *ValidationError {
+	if len(strings.TrimSpace(name)) == 0 {
+		return &ValidationError{Field: "name", Index: NoIndex, Code: CodeRequired, Message: "name is required"}
+	}
+	if len(name) > 100 {
+		return &ValidationError{Field: "name", Index: NoIndex, Code: CodeTooLong, Message: "name must be at most 100 characters"}
 	}
 	return nil
 }
@@ -53,6 +89,22 @@ func (u *User) GetName() string {
 	return u.Name
 }
 
+// GetDisplayName returns the owner-claimed display name. Returns empty string if receiver is nil.
+func (u *User) GetDisplayName() string {
+	if u == nil {
+		return ""
+	}
+	return u.DisplayName
+}
+
+// PreferredName returns the name shown in lookups: the display name if the owner set one, else the crowd name.
+func (u *User) PreferredName() string {
+	if name := u.GetDisplayName(); name != "" {
+		return name
+	}
+	return u.GetName()
+}
+
 // GetIsSpam returns the user's spam status. Returns false if receiver is nil.
 func (u *User) GetIsSpam() bool {
 	if u == nil {
@@ -35,11 +35,36 @@ func TestUserValidate(t *testing.T) {
 			user:    User{PhoneNumber: "919876543210", Name: ""},
 			wantErr: true,
 		},
+		{
+			name:    "spam number without names",
+			user:    User{PhoneNumber: "919876543210", IsSpam: true},
+			wantErr: false,
+		},
 		{
 			name:    "name too long",
 			user:    User{PhoneNumber: "919876543210", Name: string(make([]byte, 101))},
 			wantErr: true,
 		},
+		{
+			name:    "display name only",
+			user:    User{PhoneNumber: "919876543210", DisplayName: "Alice"},
+			wantErr: false,
+		},
+		{
+			name:    "display name and crowd name",
+			user:    User{PhoneNumber: "919876543210", Name: "Plumber Ramesh", DisplayName: "Ramesh Kumar"},
+			wantErr: false,
+		},
+		{
+			name:    "blank display name",
+			user:    User{PhoneNumber: "919876543210", Name: "Alice", DisplayName: "   "},
+			wantErr: true,
+		},
+		{
+			name:    "display name too long",
+			user:    User{PhoneNumber: "919876543210", DisplayName: string(make([]byte, 101))},
+			wantErr: true,
+		},
 	}
 
 	for _, tc := range tests {
@@ -51,3 +76,22 @@ func TestUserValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestUser_PreferredName(t *testing.T) {
+	tests := []struct {
+		name string
+		user *User
+		want string
+	}{
+		{name: "display name wins", user: &User{Name: "Plumber Ramesh", DisplayName: "Ramesh Kumar"}, want: "Ramesh Kumar"},
+		{name: "crowd name fallback", user: &User{Name: "Plumber Ramesh"}, want: "Plumber Ramesh"},
+		{name: "nil user", user: nil, want: ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.user.PreferredName(); got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+// Package moderation flags abusive and offensive contact names so they are never shown as caller ID.
+package moderation
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/yourusername/truecaller-lite/pkg/names"
+)
+
+// Filter decides whether a name must not be shown as caller ID.
+type Filter interface {
+	// Offensive reports whether name contains abusive or offensive words.
+	Offensive(name string) bool
+}
+
+// defaultWords are the words of DefaultWordList: common English and Hindi (Latin and Devanagari) abuse.
+var defaultWords = []string{
+	"fuck", "fucker", "fucking", "motherfucker", "shit", "bitch", "bastard", "asshole", "arsehole", "dickhead",
+	"cunt", "slut", "whore", "prick", "wanker", "retard", "moron", "idiot", "loser", "pervert", "creep",
+	"chutiya", "chutiye", "chootiya", "madarchod", "maderchod", "bhenchod", "behenchod", "bhosdike",
+	"bhosdiwala", "gandu", "gaandu", "harami", "haramkhor", "kamina", "kameena", "kutta", "kutte", "kutiya",
+	"randi", "lavde", "lauda", "lodu", "jhant", "tharki",
+	"चूतिया", "चुतिया", "मादरचोद", "बहनचोद", "भेनचोद", "भोसड़ीके", "गांडू", "हरामी", "हरामखोर", "कमीना",
+	"कुत्ता", "कुत्ते", "कुतिया", "रंडी", "लौड़ा", "ठरकी",
+}
+
+// leet maps look-alike digits and symbols to the letters they stand for.
+var leet = map[rune]rune{
+	'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '7': 't', '8': 'b', '9': 'g',
+	'@': 'a', '$': 's', '!': 'i', '|': 'l', '+': 't', '€': 'e',
+}
+
+// minSqueezedLen is the shortest word, once repeated letters are squeezed, matched with repeats ignored.
+// Shorter words would match too many innocent names, e.g. "as" for a squeezed "ass".
+const minSqueezedLen = 4
+
+// WordList is a Filter backed by a list of words and phrases. Names are compared by comparison key
+// (names.Key) after leetspeak folding ("b1tch", "$lut"), so "F U C K" and "fuuuck" match "fuck".
+// A nil *WordList flags nothing.
+type WordList struct {
+	words    map[string]struct{} // folded words and phrases
+	squeezed map[string]struct{} // folded words with repeated letters squeezed, at least minSqueezedLen long
+	maxWords int                 // the most words in one phrase
+}
+
+// NewWordList returns a word list of words. Entries may be phrases of several words.
+func NewWordList(words ...string) *WordList {
+	return (*WordList)(nil).With(words...)
+}
+
+// DefaultWordList returns a new word list of common English and Hindi abuse.
+func DefaultWordList() *WordList {
+	return NewWordList(defaultWords...)
+}
+
+// With returns a copy of l that also contains words. l is not modified.
+func (l *WordList) With(words ...string) *WordList {
+	out := &WordList{words: make(map[string]struct{}), squeezed: make(map[string]struct{})}
+	if l != nil {
+		for w := range l.words {
+			out.add(w)
+		}
+	}
+	for _, w := range words {
+		out.add(Fold(w))
+	}
+	return out
+}
+
+// add adds a folded word or phrase.
+func (l *WordList) add(folded string) {
+	if folded == "" {
+		return
+	}
+	l.words[folded] = struct{}{}
+	tokens := strings.Fields(folded)
+	l.maxWords = max(l.maxWords, len(tokens))
+	if len(tokens) == 1 {
+		if s := squeeze(folded); utf8.RuneCountInString(s) >= minSqueezedLen {
+			l.squeezed[s] = struct{}{}
+		}
+	}
+}
+
+// Len returns the number of words and phrases in the list.
+func (l *WordList) Len() int {
+	if l == nil {
+		return 0
+	}
+	return len(l.words)
+}
+
+// Offensive reports whether name contains a word or phrase of the list, as a whole word, or spelled out with
+// spaces or punctuation between its letters.
+func (l *WordList) Offensive(name string) bool {
+	if l == nil || len(l.words) == 0 {
+		return false
+	}
+	tokens := strings.Fields(Fold(name))
+	for i := range tokens {
+		if l.matchesWord(tokens[i]) {
+			return true
+		}
+		for n := 2; n <= l.maxWords && i+n <= len(tokens); n++ {
+			if _, ok := l.words[strings.Join(tokens[i:i+n], " ")]; ok {
+				return true
+			}
+		}
+	}
+	return len(tokens) > 1 && l.matchesWord(strings.Join(tokens, ""))
+}
+
+// matchesWord reports whether a single folded token is in the list, with or without repeated letters.
+func (l *WordList) matchesWord(token string) bool {
+	if _, ok := l.words[token]; ok {
+		return true
+	}
+	_, ok := l.squeezed[squeeze(token)]
+	return ok
+}
+
+// Fold returns the form names are matched in: look-alike digits and symbols replaced by letters, Devanagari
+// nukta dropped and chandrabindu written as anusvara, then the comparison key with punctuation removed.
+func Fold(name string) string {
+	name = norm.NFD.String(name)
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case r == '\u093c': // nukta
+		case r == '\u0901': // chandrabindu
+			b.WriteRune('\u0902')
+		default:
+			if l, ok := leet[r]; ok {
+				r = l
+			}
+			b.WriteRune(r)
+		}
+	}
+	key := names.Key(b.String())
+	return strings.Join(strings.FieldsFunc(key, func(r rune) bool {
+		return r == ' ' || strings.ContainsRune(".,-_'*~:;/\\\"’", r)
+	}), " ")
+}
+
+// squeeze collapses runs of the same letter, e.g. "fuuuck" to "fuck".
+func squeeze(s string) string {
+	var b strings.Builder
+	var prev rune
+	for i, r := range s {
+		if i > 0 && r == prev {
+			continue
+		}
+		b.WriteRune(r)
+		prev = r
+	}
+	return b.String()
+}
+
+var _ Filter = (*WordList)(nil)
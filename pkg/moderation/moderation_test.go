@@ -0,0 +1,55 @@
+package moderation
+
+import "testing"
+
+func TestWordList_Offensive(t *testing.T) {
+	l := DefaultWordList()
+	tests := []struct {
+		name string
+		in   string
+		want bool
+	}{
+		{name: "clean name", in: "Rahul Sharma", want: false},
+		{name: "whole word", in: "Rahul Idiot", want: true},
+		{name: "case and emoji ignored", in: "BITCH 😡", want: true},
+		{name: "leetspeak", in: "b1tch", want: true},
+		{name: "leetspeak symbols", in: "$lut", want: true},
+		{name: "repeated letters", in: "fuuuuck", want: true},
+		{name: "spelled out", in: "F U C K", want: true},
+		{name: "dotted", in: "f.u.c.k", want: true},
+		{name: "hindi romanized", in: "Chutiya Driver", want: true},
+		{name: "devanagari", in: "हरामी आदमी", want: true},
+		{name: "devanagari nukta variant", in: "भोसडीके", want: true},
+		{name: "substring of innocent name", in: "Hassan Dikshit", want: false},
+		{name: "short word not squeezed", in: "As", want: false},
+		{name: "digits in a real name", in: "Flat 101", want: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := l.Offensive(tc.in); got != tc.want {
+				t.Errorf("Offensive(%q) = %v, want %v", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWordList_With(t *testing.T) {
+	base := NewWordList("scumbag")
+	extended := base.With("total fraud", "")
+	if !extended.Offensive("Scumbag") || !extended.Offensive("Rahul Total Fraud") {
+		t.Error("expected extended list to match old words and new phrases")
+	}
+	if extended.Offensive("Fraud Prevention Cell") {
+		t.Error("expected phrases to match only as a whole")
+	}
+	if base.Offensive("total fraud") {
+		t.Error("expected With to leave the original list unchanged")
+	}
+	if extended.Len() != 2 {
+		t.Errorf("expected empty words to be skipped, got %d words", extended.Len())
+	}
+	var none *WordList
+	if none.Offensive("idiot") {
+		t.Error("expected nil word list to flag nothing")
+	}
+}
@@ -0,0 +1,128 @@
+package names
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// Lexicon is a set of relationship words and placeholders, such as "Mom", "Boss" or "Don't pick", that users save
+// numbers under but that are useless or wrong as caller ID for anyone else. A nil *Lexicon contains nothing.
+type Lexicon struct {
+	terms map[string]struct{}
+}
+
+// defaultTerms are the relationship words and placeholders of DefaultLexicon, in English, Hindi (Devanagari and
+// romanized) and romanized South Indian languages. Terms are matched by comparison key, so case does not matter.
+var defaultTerms = []string{
+	// Parents and grandparents
+	"mom", "mum", "mummy", "mommy", "mother", "mama", "maa", "ma", "amma", "aai", "mataji", "ammi",
+	"dad", "daddy", "papa", "pappa", "father", "pitaji", "baba", "abba", "appa", "nanna",
+	"nana", "nani", "dada", "dadi", "thatha", "paati", "ajji", "grandma", "grandpa", "granny",
+	"माँ", "मां", "मम्मी", "माता", "पापा", "पिताजी", "बाबा", "नाना", "नानी", "दादा", "दादी",
+	// Siblings, spouses and other relatives
+	"bro", "brother", "bhai", "bhaiya", "bhaiyya", "sis", "sister", "didi", "behen", "akka", "thambi", "chechi",
+	"wife", "wifey", "husband", "hubby", "jaan", "baby", "babu", "love", "sweetheart",
+	"chacha", "chachi", "mami", "mausi", "masi", "bua", "fufa", "tau", "tai", "uncle", "aunty", "auntie",
+	"cousin", "son", "daughter", "beta", "beti", "jiju", "bhabhi", "saala", "sasur", "saas",
+	"भाई", "भैया", "दीदी", "बहन", "पति", "पत्नी", "चाचा", "चाची", "मामा", "मामी", "मौसी", "बुआ", "भाभी", "बेटा", "बेटी",
+	// Roles
+	"boss", "sir", "madam", "maam", "ma'am", "office", "home", "landlord", "neighbour", "neighbor",
+	"driver", "maid", "cook", "bai", "watchman", "doctor", "friend", "बॉस", "सर", "ऑफिस", "घर",
+	// Placeholders
+	"unknown", "no name", "noname", "unnamed", "private", "contact", "number", "me",
+	"don't pick", "dont pick", "do not pick", "don't answer", "dont answer", "do not answer", "ignore", "no",
+	"spam", "fraud", "fake", "blocked", "block", "test", "xyz", "abc", "?", "अज्ञात", "मत उठाना",
+}
+
+// fillers are words dropped before matching, so "My Mom", "Papa ji" and "Mom 2" match. Numbers are dropped too.
+var fillers = map[string]struct{}{
+	"my": {}, "new": {}, "old": {}, "the": {}, "mera": {}, "meri": {}, "mere": {}, "ji": {},
+	"मेरा": {}, "मेरी": {}, "मेरे": {}, "जी": {},
+}
+
+// NewLexicon returns a lexicon containing terms.
+func NewLexicon(terms ...string) *Lexicon {
+	return (*Lexicon)(nil).With(terms...)
+}
+
+// DefaultLexicon returns a new lexicon of common relationship words and placeholders.
+func DefaultLexicon() *Lexicon {
+	return NewLexicon(defaultTerms...)
+}
+
+// With returns a copy of l that also contains terms. l is not modified.
+func (l *Lexicon) With(terms ...string) *Lexicon {
+	out := &Lexicon{terms: make(map[string]struct{}, l.Len()+len(terms))}
+	if l != nil {
+		for term := range l.terms {
+			out.terms[term] = struct{}{}
+		}
+	}
+	for _, term := range terms {
+		if p := phrase(term); p != "" {
+			out.terms[p] = struct{}{}
+		}
+	}
+	return out
+}
+
+// Contains reports whether name, ignoring case, emoji, bracketed annotations, fillers such as "my" and numbers,
+// is a term of the lexicon.
+func (l *Lexicon) Contains(name string) bool {
+	if l == nil {
+		return false
+	}
+	p := phrase(name)
+	if p == "" {
+		return false
+	}
+	_, ok := l.terms[p]
+	return ok
+}
+
+// Len returns the number of terms in the lexicon.
+func (l *Lexicon) Len() int {
+	if l == nil {
+		return 0
+	}
+	return len(l.terms)
+}
+
+// phrase returns the words of name's comparison key that matter for matching, joined by single spaces.
+// A name made only of punctuation, fillers or numbers is returned as its whole key.
+func phrase(name string) string {
+	key := strings.ReplaceAll(Key(name), "’", "'")
+	var kept []string
+	for _, w := range strings.Fields(key) {
+		w = strings.TrimFunc(w, unicode.IsPunct)
+		if _, filler := fillers[w]; w == "" || filler || isNumber(w) {
+			continue
+		}
+		kept = append(kept, w)
+	}
+	if len(kept) == 0 {
+		return key
+	}
+	return strings.Join(kept, " ")
+}
+
+// isNumber reports whether w is a non-empty run of digits.
+func isNumber(w string) bool {
+	return w != "" && strings.IndexFunc(w, func(r rune) bool { return !unicode.IsDigit(r) }) < 0
+}
+
+// ReadTerms reads lexicon terms from r, one per line. Blank lines and lines starting with "#" are ignored.
+func ReadTerms(r io.Reader) ([]string, error) {
+	var terms []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		terms = append(terms, line)
+	}
+	return terms, scanner.Err()
+}
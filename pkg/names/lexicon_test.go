@@ -0,0 +1,64 @@
+package names
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLexicon_Contains(t *testing.T) {
+	l := DefaultLexicon()
+	tests := []struct {
+		name string
+		in   string
+		want bool
+	}{
+		{name: "relationship", in: "Mom", want: true},
+		{name: "case and emoji ignored", in: "PAPA ❤️", want: true},
+		{name: "filler and number ignored", in: "My Boss 2", want: true},
+		{name: "honorific ignored", in: "Chacha ji", want: true},
+		{name: "devanagari", in: "मम्मी", want: true},
+		{name: "placeholder phrase", in: "Don’t pick!!", want: true},
+		{name: "punctuation only placeholder", in: "?", want: true},
+		{name: "bracketed annotation ignored", in: "Unknown (call back)", want: true},
+		{name: "real name", in: "Rahul", want: false},
+		{name: "relationship with name", in: "Rahul Bhai", want: false},
+		{name: "empty", in: "", want: false},
+		{name: "number only", in: "2", want: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := l.Contains(tc.in); got != tc.want {
+				t.Errorf("Contains(%q) = %v, want %v", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLexicon_With(t *testing.T) {
+	base := NewLexicon("Mom")
+	extended := base.With("Landlady", "  ")
+	if !extended.Contains("landlady") || !extended.Contains("mom") {
+		t.Error("expected extended lexicon to contain old and new terms")
+	}
+	if base.Contains("Landlady") {
+		t.Error("expected With to leave the original lexicon unchanged")
+	}
+	if extended.Len() != 2 {
+		t.Errorf("expected blank terms to be skipped, got %d terms", extended.Len())
+	}
+	var none *Lexicon
+	if none.Contains("Mom") {
+		t.Error("expected nil lexicon to contain nothing")
+	}
+}
+
+func TestReadTerms(t *testing.T) {
+	terms, err := ReadTerms(strings.NewReader("# relatives\nMausa\n\n  Landlady  \n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"Mausa", "Landlady"}; !reflect.DeepEqual(terms, want) {
+		t.Errorf("expected %v, got %v", want, terms)
+	}
+}
@@ -0,0 +1,117 @@
+// Package names cleans up and compares the contact names users upload, so that spellings of the same name
+// written differently in different phone books are aggregated together.
+package names
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	zeroWidthNonJoiner = '\u200c'
+	zeroWidthJoiner    = '\u200d'
+)
+
+// separators are trimmed from both ends of a normalized name, e.g. the dash left behind in "Rahul - 🙂".
+const separators = "-‐–—_,;:|/\\·•*~"
+
+// Normalize returns the cleaned, human-readable form of a contact name:
+//   - Unicode NFC composition
+//   - bracketed annotations such as "(Office)" or "[old]" removed
+//   - emoji, pictographs and other symbols removed
+//   - runs of whitespace collapsed to one space and separators trimmed from the ends
+//
+// Case is preserved; use Key to compare names. The result is empty if nothing name-like is left.
+func Normalize(name string) string {
+	name = norm.NFC.String(name)
+	name = stripSymbols(stripBrackets(name))
+	name = strings.Join(strings.Fields(name), " ")
+	return strings.TrimFunc(name, func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune(separators, r)
+	})
+}
+
+// Key returns the comparison key of a contact name: its normalized form, case folded.
+// Names with the same key are considered the same name, e.g. "  RAHUL   ", "rahul", "Rahul 🙂" and "Rahul (Office)".
+func Key(name string) string {
+	return norm.NFC.String(cases.Fold().String(Normalize(name)))
+}
+
+// brackets maps each opening bracket to its closing bracket.
+var brackets = map[rune]rune{'(': ')', '[': ']', '{': '}', '<': '>', '（': '）', '［': '］', '【': '】'}
+
+// stripBrackets removes bracketed text, including nested brackets. Unmatched brackets are dropped on their own
+// and the text after them is kept.
+func stripBrackets(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i := 0; i < len(runes); i++ {
+		closer, ok := brackets[runes[i]]
+		if !ok {
+			if !isClosingBracket(runes[i]) {
+				b.WriteRune(runes[i])
+			}
+			continue
+		}
+		if end := matchingBracket(runes, i, runes[i], closer); end >= 0 {
+			b.WriteRune(' ')
+			i = end
+		}
+	}
+	return b.String()
+}
+
+// matchingBracket returns the index of the bracket closing the one at runes[start], or -1 if it is unmatched.
+func matchingBracket(runes []rune, start int, opener, closer rune) int {
+	depth := 0
+	for i := start; i < len(runes); i++ {
+		switch runes[i] {
+		case opener:
+			depth++
+		case closer:
+			if depth--; depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// isClosingBracket reports whether r closes one of the known bracket pairs.
+func isClosingBracket(r rune) bool {
+	for _, closer := range brackets {
+		if r == closer {
+			return true
+		}
+	}
+	return false
+}
+
+// stripSymbols removes emoji and other symbol, control and formatting characters. Letters, combining marks,
+// digits, punctuation and spaces are kept, as are zero-width joiners between letters (used in Indic scripts).
+func stripSymbols(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		switch {
+		case r == zeroWidthJoiner || r == zeroWidthNonJoiner:
+			if i > 0 && i+1 < len(runes) && isLetterOrMark(runes[i-1]) && isLetterOrMark(runes[i+1]) {
+				b.WriteRune(r)
+			}
+		case unicode.Is(unicode.Variation_Selector, r):
+		case unicode.IsSpace(r):
+			b.WriteRune(' ')
+		case isLetterOrMark(r), unicode.IsDigit(r), unicode.IsPunct(r):
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// isLetterOrMark reports whether r is a letter or a non-enclosing combining mark, such as a Devanagari vowel sign.
+func isLetterOrMark(r rune) bool {
+	return unicode.IsLetter(r) || unicode.In(r, unicode.Mn, unicode.Mc)
+}
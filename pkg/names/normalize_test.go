@@ -0,0 +1,48 @@
+package names
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "already clean", in: "Rahul Sharma", want: "Rahul Sharma"},
+		{name: "whitespace collapsed", in: "  RAHUL \t  Sharma ", want: "RAHUL Sharma"},
+		{name: "emoji removed", in: "Rahul 🙂", want: "Rahul"},
+		{name: "emoji sequence removed", in: "👨‍👩‍👧 Rahul ❤️", want: "Rahul"},
+		{name: "bracketed annotation removed", in: "Rahul (Office)", want: "Rahul"},
+		{name: "nested brackets removed", in: "Rahul [old (2019)] Sharma", want: "Rahul Sharma"},
+		{name: "unmatched bracket dropped", in: "Rahul (Office", want: "Rahul Office"},
+		{name: "trailing separator trimmed", in: "Rahul - 🙂", want: "Rahul"},
+		{name: "punctuation inside kept", in: "Dr. Anne-Marie O'Brien", want: "Dr. Anne-Marie O'Brien"},
+		{name: "decomposed accent composed", in: "Jose\u0301", want: "Jos\u00e9"},
+		{name: "devanagari kept", in: "राहुल शर्मा 🙏", want: "राहुल शर्मा"},
+		{name: "joiner between letters kept", in: "क्\u200dष", want: "क्\u200dष"},
+		{name: "stray joiner dropped", in: "\u200dRahul", want: "Rahul"},
+		{name: "only symbols", in: "🙂 (x)", want: ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Normalize(tc.in); got != tc.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestKey(t *testing.T) {
+	same := []string{"  RAHUL   ", "rahul", "Rahul 🙂", "Rahul (Office)"}
+	for _, name := range same {
+		if got := Key(name); got != "rahul" {
+			t.Errorf("Key(%q) = %q, want %q", name, got, "rahul")
+		}
+	}
+	if Key("Straße") != Key("STRASSE") {
+		t.Errorf("expected case folding to match %q and %q", "Straße", "STRASSE")
+	}
+	if Key("Rahul") == Key("Rahul S") {
+		t.Error("expected different names to have different keys")
+	}
+}
@@ -0,0 +1,125 @@
+package names
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+// organizationSeparators split a name from the organization written after it, as in "Anita Rao - Apollo",
+// "Anita @ Apollo" or "Anita Rao, Apollo Hospital". Dashes must be spaced, so "Anne-Marie" stays one name.
+// A comma between two single name words is read as "Family, Given" instead (see familyFirst), unless the second is
+// an organization word.
+var organizationSeparators = []string{" - ", " – ", " — ", " @ ", " | ", ","}
+
+// honorifics are the titles recognized before a name, by comparison key and without a trailing period.
+var honorifics = map[string]struct{}{
+	"mr": {}, "mrs": {}, "ms": {}, "miss": {}, "mx": {}, "dr": {}, "prof": {}, "er": {}, "adv": {}, "ca": {},
+	"capt": {}, "col": {}, "maj": {}, "gen": {}, "lt": {}, "rev": {}, "fr": {}, "hon": {},
+	"shri": {}, "sri": {}, "shree": {}, "smt": {}, "kumari": {}, "km": {}, "sushri": {},
+	"श्री": {}, "श्रीमती": {}, "सुश्री": {}, "कुमारी": {}, "डॉ": {}, "डा": {},
+}
+
+// organizationWords are single words that name an organization rather than a person, by comparison key: generic
+// business words and widely saved employers, hospitals, banks and services. "Anita, Apollo" is read as a name and
+// its organization instead of "Apollo Anita". Unknown organizations after a comma are still taken as given names.
+var organizationWords = map[string]struct{}{
+	"office": {}, "work": {}, "shop": {}, "store": {}, "hospital": {}, "clinic": {}, "bank": {}, "school": {},
+	"college": {}, "ltd": {}, "pvt": {}, "inc": {}, "llp": {}, "corp": {}, "company": {}, "agency": {},
+	"apollo": {}, "fortis": {}, "manipal": {}, "aiims": {}, "infosys": {}, "wipro": {}, "tcs": {}, "hcl": {},
+	"accenture": {}, "cognizant": {}, "airtel": {}, "jio": {}, "vodafone": {}, "sbi": {}, "hdfc": {}, "icici": {},
+	"axis": {}, "kotak": {}, "amazon": {}, "flipkart": {}, "swiggy": {}, "zomato": {}, "uber": {}, "ola": {},
+}
+
+// Parse splits a contact name into honorific, given name, family name and organization, e.g.
+// "Dr. Anita Rao - Apollo" into "Dr.", "Anita", "Rao" and "Apollo". The name is normalized first, so emoji and
+// bracketed annotations are ignored and Parse(Normalize(name)) equals Parse(name). Leading words that are
+// honorifics are taken as the honorific; the last remaining word is the family name and the words before it the
+// given name. Names written family name first, as in "Sharma, Rahul", are split into "Rahul" and "Sharma".
+// Returns nil if nothing name-like is left.
+func Parse(name string) *models.PersonName {
+	name = Normalize(name)
+	if given, family, ok := familyFirst(name); ok {
+		return &models.PersonName{GivenName: given, FamilyName: family}
+	}
+	person, org := splitOrganization(name)
+	words := strings.Fields(person)
+	var titles []string
+	for len(words) > 0 && isHonorific(words[0]) {
+		titles = append(titles, words[0])
+		words = words[1:]
+	}
+	p := &models.PersonName{Honorific: strings.Join(titles, " "), Organization: org}
+	switch len(words) {
+	case 0:
+	case 1:
+		p.GivenName = words[0]
+	default:
+		p.GivenName = strings.Join(words[:len(words)-1], " ")
+		p.FamilyName = words[len(words)-1]
+	}
+	if *p == (models.PersonName{}) {
+		return nil
+	}
+	return p
+}
+
+// splitOrganization splits a normalized name at its first organization separator. A name without one is all
+// person; so is a name with nothing before the separator.
+func splitOrganization(name string) (person, org string) {
+	at, sep := -1, ""
+	for _, s := range organizationSeparators {
+		if i := strings.Index(name, s); i >= 0 && (at < 0 || i < at) {
+			at, sep = i, s
+		}
+	}
+	if at < 0 {
+		return name, ""
+	}
+	person, org = Normalize(name[:at]), Normalize(name[at+len(sep):])
+	if person == "" {
+		return org, ""
+	}
+	return person, org
+}
+
+// familyFirst splits a normalized name written as "Family, Given", such as "Sharma, Rahul": a single name word on
+// each side of the only comma, neither of them an honorific and the second not an organization word. Other names,
+// as in "Anita Rao, Apollo Hospital" or "Ravi, Infosys", are left to splitOrganization.
+func familyFirst(name string) (given, family string, ok bool) {
+	family, given, found := strings.Cut(name, ",")
+	if !found || strings.Contains(given, ",") {
+		return "", "", false
+	}
+	family, given = strings.TrimSpace(family), strings.TrimSpace(given)
+	if !isNameWord(family) || !isNameWord(given) || isHonorific(family) || isHonorific(given) || isOrganizationWord(given) {
+		return "", "", false
+	}
+	return given, family, true
+}
+
+// isNameWord reports whether word is a single word made of letters, combining marks, hyphens and apostrophes.
+func isNameWord(word string) bool {
+	if word == "" {
+		return false
+	}
+	for _, r := range word {
+		if !unicode.IsLetter(r) && !unicode.IsMark(r) && r != '-' && r != '\'' {
+			return false
+		}
+	}
+	return true
+}
+
+// isOrganizationWord reports whether word, ignoring case and a trailing period, is a known organization word.
+func isOrganizationWord(word string) bool {
+	_, ok := organizationWords[strings.TrimSuffix(Key(word), ".")]
+	return ok
+}
+
+// isHonorific reports whether word, ignoring case and a trailing period, is a known honorific.
+func isHonorific(word string) bool {
+	_, ok := honorifics[strings.TrimSuffix(Key(word), ".")]
+	return ok
+}
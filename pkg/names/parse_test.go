@@ -0,0 +1,47 @@
+package names
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want *models.PersonName
+	}{
+		{name: "full example", in: "Dr. Anita Rao - Apollo", want: &models.PersonName{Honorific: "Dr.", GivenName: "Anita", FamilyName: "Rao", Organization: "Apollo"}},
+		{name: "given name only", in: "Rahul", want: &models.PersonName{GivenName: "Rahul"}},
+		{name: "middle names are given names", in: "Rahul Kumar Sharma", want: &models.PersonName{GivenName: "Rahul Kumar", FamilyName: "Sharma"}},
+		{name: "honorific without period", in: "shri Ramesh Gupta", want: &models.PersonName{Honorific: "shri", GivenName: "Ramesh", FamilyName: "Gupta"}},
+		{name: "several honorifics", in: "Prof. Dr. Meera Iyer", want: &models.PersonName{Honorific: "Prof. Dr.", GivenName: "Meera", FamilyName: "Iyer"}},
+		{name: "at separator", in: "Suresh @ Big Bazaar", want: &models.PersonName{GivenName: "Suresh", Organization: "Big Bazaar"}},
+		{name: "comma separator", in: "Anita Rao, Apollo Hospital", want: &models.PersonName{GivenName: "Anita", FamilyName: "Rao", Organization: "Apollo Hospital"}},
+		{name: "family name first", in: "Sharma, Rahul", want: &models.PersonName{GivenName: "Rahul", FamilyName: "Sharma"}},
+		{name: "family name first in devanagari", in: "शर्मा, राहुल", want: &models.PersonName{GivenName: "राहुल", FamilyName: "शर्मा"}},
+		{name: "comma before an organization word", in: "Anita, Apollo", want: &models.PersonName{GivenName: "Anita", Organization: "Apollo"}},
+		{name: "comma before a known employer", in: "Ravi, Infosys", want: &models.PersonName{GivenName: "Ravi", Organization: "Infosys"}},
+		{name: "comma before an unknown organization is family name first", in: "Ravi, Acme", want: &models.PersonName{GivenName: "Acme", FamilyName: "Ravi"}},
+		{name: "comma before a longer organization", in: "Suresh, Big Bazaar", want: &models.PersonName{GivenName: "Suresh", Organization: "Big Bazaar"}},
+		{name: "hyphenated name kept", in: "Anne-Marie Fernandes", want: &models.PersonName{GivenName: "Anne-Marie", FamilyName: "Fernandes"}},
+		{name: "emoji and brackets ignored", in: "🙂 Anita Rao (old) - Apollo", want: &models.PersonName{GivenName: "Anita", FamilyName: "Rao", Organization: "Apollo"}},
+		{name: "devanagari", in: "श्री राहुल शर्मा", want: &models.PersonName{Honorific: "श्री", GivenName: "राहुल", FamilyName: "शर्मा"}},
+		{name: "honorific only", in: "Dr.", want: &models.PersonName{Honorific: "Dr."}},
+		{name: "nothing before separator", in: "- Apollo", want: &models.PersonName{GivenName: "Apollo"}},
+		{name: "nothing name-like", in: "🙂🙂", want: nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Parse(tc.in)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Parse(%q) = %+v, want %+v", tc.in, got, tc.want)
+			}
+			if again := Parse(Normalize(tc.in)); !reflect.DeepEqual(again, got) {
+				t.Errorf("Parse(Normalize(%q)) = %+v, want %+v", tc.in, again, got)
+			}
+		})
+	}
+}
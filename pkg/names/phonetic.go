@@ -0,0 +1,245 @@
+package names
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Devanagari signs that change how the preceding consonant is read.
+const (
+	virama       = '\u094d'
+	nukta        = '\u093c'
+	anusvara     = '\u0902'
+	chandrabindu = '\u0901'
+	visarga      = '\u0903'
+	avagraha     = '\u093d'
+)
+
+// devanagariVowels maps independent vowels and vowel signs to Latin. Long and short vowels are not told apart,
+// as in common Latin spellings of names ("Rahul", not "Raahul").
+var devanagariVowels = map[rune]string{
+	'अ': "a", 'आ': "a", 'इ': "i", 'ई': "i", 'उ': "u", 'ऊ': "u", 'ऋ': "ri", 'ए': "e", 'ऐ': "ai", 'ओ': "o", 'औ': "au",
+	'ऍ': "e", 'ऑ': "o",
+	'ा': "a", 'ि': "i", 'ी': "i", 'ु': "u", 'ू': "u", 'ृ': "ri", 'े': "e", 'ै': "ai", 'ो': "o", 'ौ': "au",
+	'ॅ': "e", 'ॉ': "o",
+}
+
+// devanagariConsonants maps consonants to Latin, without the inherent vowel.
+var devanagariConsonants = map[rune]string{
+	'क': "k", 'ख': "kh", 'ग': "g", 'घ': "gh", 'ङ': "n",
+	'च': "ch", 'छ': "chh", 'ज': "j", 'झ': "jh", 'ञ': "n",
+	'ट': "t", 'ठ': "th", 'ड': "d", 'ढ': "dh", 'ण': "n",
+	'त': "t", 'थ': "th", 'द': "d", 'ध': "dh", 'न': "n",
+	'प': "p", 'फ': "ph", 'ब': "b", 'भ': "bh", 'म': "m",
+	'य': "y", 'र': "r", 'ल': "l", 'व': "v", 'श': "sh", 'ष': "sh", 'स': "s", 'ह': "h", 'ळ': "l",
+}
+
+// nuktaConsonants maps consonants written with a nukta, mostly for Perso-Arabic sounds.
+var nuktaConsonants = map[rune]string{'क': "q", 'ख': "kh", 'ग': "g", 'ज': "z", 'ड': "r", 'ढ': "rh", 'फ': "f", 'य': "y"}
+
+// Transliterate writes Devanagari text in Latin letters the way names are commonly spelled, e.g. "मोहम्मद" as
+// "mohammad" and "राहुल" as "rahul". The inherent vowel of a word's last consonant is dropped unless the consonant
+// starts the word or ends a conjunct ("कृष्ण" is "krishna"). Other scripts are returned unchanged.
+func Transliterate(s string) string {
+	runes := []rune(norm.NFD.String(s))
+	var b strings.Builder
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if v, ok := devanagariVowels[r]; ok {
+			b.WriteString(v)
+			continue
+		}
+		c, ok := devanagariConsonants[r]
+		if !ok {
+			switch {
+			case r == anusvara || r == chandrabindu:
+				b.WriteString(nasal(runes, i+1))
+			case r == visarga:
+				b.WriteByte('h')
+			case r == avagraha || r == nukta || r == virama:
+			case r >= '०' && r <= '९':
+				b.WriteRune('0' + r - '०')
+			default:
+				b.WriteRune(r)
+			}
+			continue
+		}
+		if i+1 < len(runes) && runes[i+1] == nukta {
+			c = nuktaConsonants[r]
+			i++
+		}
+		b.WriteString(c)
+		if i+1 < len(runes) {
+			if _, sign := devanagariVowels[runes[i+1]]; sign || runes[i+1] == virama {
+				continue
+			}
+		}
+		if !endsWord(runes, i+1) || startsWord(runes, i) || runes[i-1] == virama {
+			b.WriteByte('a')
+		}
+	}
+	return b.String()
+}
+
+// endsWord reports whether runes[i:] starts with the end of a Devanagari word, ignoring trailing nasalization.
+func endsWord(runes []rune, i int) bool {
+	for ; i < len(runes); i++ {
+		switch r := runes[i]; {
+		case r == anusvara || r == chandrabindu || r == visarga:
+		case unicode.In(r, unicode.Devanagari) && unicode.IsLetter(r):
+			return false
+		default:
+			return true
+		}
+	}
+	return true
+}
+
+// nasal returns the Latin spelling of an anusvara followed by runes[next:]: "m" before labials ("अंबर" is "ambar"),
+// "ng" before "ह" ("सिंह" is "singh") and "n" otherwise.
+func nasal(runes []rune, next int) string {
+	if next < len(runes) {
+		switch runes[next] {
+		case 'प', 'फ', 'ब', 'भ', 'म':
+			return "m"
+		case 'ह':
+			return "ng"
+		}
+	}
+	return "n"
+}
+
+// startsWord reports whether runes[i] is the first letter of a Devanagari word.
+func startsWord(runes []rune, i int) bool {
+	return i == 0 || !unicode.In(runes[i-1], unicode.Devanagari)
+}
+
+// phoneticDigraphs are Latin letter pairs read as one sound, replaced before the phonetic key is built.
+// "ch" becomes "c", so a lone "c" is first read as "k"; "ee" and "oo" are read as the "i" and "u" they spell.
+var phoneticDigraphs = strings.NewReplacer(
+	"ee", "i", "oo", "u",
+	"chh", "c", "ch", "c", "sh", "s", "ph", "f", "th", "t", "dh", "d", "bh", "b", "kh", "k", "gh", "g", "jh", "j",
+	"rh", "r", "ck", "k", "c", "k", "q", "k", "x", "ks", "z", "j", "w", "v",
+)
+
+// PhoneticKey returns a sound-alike key for a name, so that spelling variants and transliterations share a key:
+// "Mohammed", "Muhammad" and "मोहम्मद" all become "muhamad". Each word is transliterated to Latin, digraphs such as
+// "sh" and "ph" are reduced to one letter, vowels are reduced to their class ("a" and "e" to "a", "i" and "y" to
+// "i", "o" and "u" to "u") and repeated letters are collapsed. Words are joined by single spaces.
+// Vowel classes keep names that differ only in their vowels apart, such as "Sunil" and "Sonal".
+func PhoneticKey(name string) string {
+	var keys []string
+	for _, w := range strings.Fields(Key(name)) {
+		if k := phoneticWordKey(w); k != "" {
+			keys = append(keys, k)
+		}
+	}
+	return strings.Join(keys, " ")
+}
+
+// phoneticWordKey returns the phonetic key of a single case-folded word.
+func phoneticWordKey(word string) string {
+	var latin strings.Builder
+	for _, r := range Transliterate(word) {
+		// Letters of other scripts are kept, so such names still get distinct keys
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			latin.WriteRune(r)
+		}
+	}
+	s := phoneticDigraphs.Replace(latin.String())
+	var b strings.Builder
+	var prev rune
+	for i, r := range s {
+		if isVowel(r) && (i > 0 || r != 'y') {
+			r = vowelClass(r)
+		}
+		if r != prev {
+			b.WriteRune(r)
+		}
+		prev = r
+	}
+	return b.String()
+}
+
+// isVowel reports whether r is a Latin vowel. "y" counts as one except as the first letter.
+func isVowel(r rune) bool {
+	return strings.ContainsRune("aeiouy", r)
+}
+
+// vowelClass returns the vowel standing for the class of the vowel r: "a" for "a" and "e", "i" for "i" and "y",
+// and "u" for "o" and "u".
+func vowelClass(r rune) rune {
+	switch r {
+	case 'e':
+		return 'a'
+	case 'y':
+		return 'i'
+	case 'o':
+		return 'u'
+	}
+	return r
+}
+
+// EditDistance returns the Levenshtein distance between a and b, counted in runes.
+func EditDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+// MaxEdits returns how many typos a word of n runes may contain and still match: none below 5 runes, one below 9,
+// and two otherwise.
+func MaxEdits(n int) int {
+	switch {
+	case n < 5:
+		return 0
+	case n < 9:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Similar reports whether two names are the same name spelled differently: their phonetic keys are equal, or
+// they have as many words and each word sounds the same (PhoneticKey) or is within MaxEdits of its counterpart in
+// the other name. Typos are counted per word, so "Anil Kumar" and "Sunil Kumar" are different names.
+func Similar(a, b string) bool {
+	if PhoneticKey(a) == PhoneticKey(b) {
+		return true
+	}
+	wa, wb := strings.Fields(Transliterate(Key(a))), strings.Fields(Transliterate(Key(b)))
+	if len(wa) != len(wb) {
+		return false
+	}
+	for i := range wa {
+		if !similarWord(wa[i], wb[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// similarWord reports whether two Latin words sound the same or are within MaxEdits of each other.
+func similarWord(a, b string) bool {
+	if phoneticWordKey(a) == phoneticWordKey(b) {
+		return true
+	}
+	return EditDistance(a, b) <= MaxEdits(min(len([]rune(a)), len([]rune(b))))
+}
@@ -0,0 +1,100 @@
+package names
+
+import "testing"
+
+func TestTransliterate(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "राहुल", want: "rahul"},
+		{in: "मोहम्मद", want: "mohammad"},
+		{in: "शर्मा", want: "sharma"},
+		{in: "कृष्ण", want: "krishna"},
+		{in: "राज", want: "raj"},
+		{in: "ज़ाकिर", want: "zakir"},
+		{in: "फ़ातिमा", want: "fatima"},
+		{in: "सिंह", want: "singh"},
+		{in: "अंबर", want: "ambar"},
+		{in: "प्रिया गुप्ता", want: "priya gupta"},
+		{in: "Rahul ४२", want: "Rahul 42"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.in, func(t *testing.T) {
+			if got := Transliterate(tc.in); got != tc.want {
+				t.Errorf("Transliterate(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPhoneticKey(t *testing.T) {
+	groups := [][]string{
+		{"Mohammed", "Muhammad", "मोहम्मद", "MOHAMMAD"},
+		{"Rahul Sharma", "राहुल शर्मा", "rahul sarma"},
+		{"Deepak", "Dipak", "दीपक"},
+		{"Zakir", "Jakir", "ज़ाकिर"},
+		{"Krishna", "कृष्ण"},
+		{"Sai", "Sayee"},
+		{"Singh", "सिंह"},
+	}
+	for _, group := range groups {
+		want := PhoneticKey(group[0])
+		for _, name := range group[1:] {
+			if got := PhoneticKey(name); got != want {
+				t.Errorf("PhoneticKey(%q) = %q, want %q as for %q", name, got, want, group[0])
+			}
+		}
+	}
+	if PhoneticKey("Yash") == PhoneticKey("Ash") {
+		t.Error("expected a leading y to be kept as a consonant")
+	}
+	for _, pair := range [][2]string{{"Rahul", "Ravi"}, {"Sunil", "Sonal"}, {"Rohan", "Rehana"}, {"Anil Kumar", "Sunil Kumar"}} {
+		if PhoneticKey(pair[0]) == PhoneticKey(pair[1]) {
+			t.Errorf("expected %q and %q to have different keys, both got %q", pair[0], pair[1], PhoneticKey(pair[0]))
+		}
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "", b: "", want: 0},
+		{a: "rahul", b: "", want: 5},
+		{a: "rahul", b: "rahul", want: 0},
+		{a: "rahul", b: "rahool", want: 2},
+		{a: "priyanka", b: "prianka", want: 1},
+		{a: "राहुल", b: "राहल", want: 1},
+	}
+	for _, tc := range tests {
+		if got := EditDistance(tc.a, tc.b); got != tc.want {
+			t.Errorf("EditDistance(%q, %q) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestSimilar(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{a: "Mohammed", b: "मोहम्मद", want: true},
+		{a: "Priyanka", b: "Priyanaka", want: true},
+		{a: "Srinivasan", b: "Shreenivasan", want: true},
+		{a: "Ravi", b: "Rahi", want: false},
+		{a: "Rahul", b: "Mohan", want: false},
+		{a: "Rahul Sharma", b: "Rahul Sharmaa", want: true},
+		{a: "Anil Kumar", b: "Sunil Kumar", want: false},
+		{a: "Raj Kumar", b: "Ram Kumar", want: false},
+		{a: "Sunil", b: "Sonal", want: false},
+		{a: "Rohan", b: "Rehana", want: false},
+		{a: "Rahul", b: "Rahul Sharma", want: false},
+	}
+	for _, tc := range tests {
+		if got := Similar(tc.a, tc.b); got != tc.want {
+			t.Errorf("Similar(%q, %q) = %v, want %v", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
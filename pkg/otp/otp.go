@@ -0,0 +1,234 @@
+// Package otp verifies phone number ownership with one-time passwords sent by SMS.
+// A verified number receives a session token from a pluggable TokenIssuer.
+package otp
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao"
+	"github.com/yourusername/truecaller-lite/pkg/dao/daoerrors"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+// Defaults applied to zero Options fields.
+const (
+	DefaultCodeLength     = 6
+	DefaultCodeTTL        = 5 * time.Minute
+	DefaultMaxAttempts    = 5
+	DefaultResendInterval = 30 * time.Second
+	DefaultMaxSends       = 5
+	DefaultSendWindow     = time.Hour
+)
+
+var (
+	// ErrInvalidCode is returned when a code does not match, or there is no pending challenge for the number.
+	ErrInvalidCode = errors.New("invalid verification code")
+	// ErrCodeExpired is returned when the pending code has expired.
+	ErrCodeExpired = errors.New("verification code expired")
+	// ErrTooManyAttempts is returned once the attempt limit for the current code is reached; a new code must be requested.
+	ErrTooManyAttempts = errors.New("too many verification attempts")
+	// ErrResendThrottled is returned when a new code is requested too soon. The error is a *ThrottledError.
+	ErrResendThrottled = errors.New("verification code requested too often")
+)
+
+// ThrottledError reports when a new code may be requested. It matches ErrResendThrottled with errors.Is.
+type ThrottledError struct {
+	// RetryAfter is how long the caller must wait before requesting a new code.
+	RetryAfter time.Duration
+}
+
+// Error implements the error interface.
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("%s: retry after %s", ErrResendThrottled, e.RetryAfter.Round(time.Second))
+}
+
+// Is reports whether target is ErrResendThrottled.
+func (e *ThrottledError) Is(target error) bool { return target == ErrResendThrottled }
+
+// Options configures a Service. Zero fields use the package defaults.
+type Options struct {
+	// CodeLength is the number of digits in a code.
+	CodeLength int
+	// CodeTTL is how long a code is accepted after it is sent.
+	CodeTTL time.Duration
+	// MaxAttempts is the number of failed verifications allowed per code.
+	MaxAttempts int
+	// ResendInterval is the minimum time between two codes sent to the same number.
+	ResendInterval time.Duration
+	// MaxSends is the number of codes that may be sent to a number within SendWindow.
+	MaxSends int
+	// SendWindow is the period over which MaxSends applies.
+	SendWindow time.Duration
+	// Now returns the current time; time.Now if nil. Intended for tests.
+	Now func() time.Time
+}
+
+// withDefaults returns a copy of o with zero fields replaced by defaults.
+func (o Options) withDefaults() Options {
+	if o.CodeLength <= 0 {
+		o.CodeLength = DefaultCodeLength
+	}
+	if o.CodeTTL <= 0 {
+		o.CodeTTL = DefaultCodeTTL
+	}
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = DefaultMaxAttempts
+	}
+	if o.ResendInterval <= 0 {
+		o.ResendInterval = DefaultResendInterval
+	}
+	if o.MaxSends <= 0 {
+		o.MaxSends = DefaultMaxSends
+	}
+	if o.SendWindow <= 0 {
+		o.SendWindow = DefaultSendWindow
+	}
+	if o.Now == nil {
+		o.Now = time.Now
+	}
+	return o
+}
+
+// Service defines phone number ownership verification.
+type Service interface {
+	// RequestCode sends a new code to phoneNumber, replacing any pending one, and returns when it expires.
+	// The code is stored before it is sent, so a code is never delivered that cannot be verified; a failed send
+	// still counts against the resend limits. Returns a *ThrottledError if codes are requested too often.
+	RequestCode(ctx context.Context, phoneNumber string) (expiresAt time.Time, err error)
+	// VerifyCode checks code against the pending challenge for phoneNumber and, on success, issues a session token.
+	// Returns ErrInvalidCode, ErrCodeExpired or ErrTooManyAttempts if verification fails.
+	VerifyCode(ctx context.Context, phoneNumber, code string) (token string, expiresAt time.Time, err error)
+}
+
+// service implements Service.
+type service struct {
+	// mu serializes challenge updates so concurrent attempts cannot exceed the limits.
+	mu     sync.Mutex
+	otpDAO dao.OTPDAO
+	sender SMSSender
+	issuer TokenIssuer
+	opts   Options
+}
+
+// NewService creates a new Service storing challenges in otpDAO, sending codes with sender and issuing
+// session tokens with issuer.
+func NewService(otpDAO dao.OTPDAO, sender SMSSender, issuer TokenIssuer, opts Options) Service {
+	return &service{otpDAO: otpDAO, sender: sender, issuer: issuer, opts: opts.withDefaults()}
+}
+
+// RequestCode sends a new code to phoneNumber.
+func (s *service) RequestCode(ctx context.Context, phoneNumber string) (time.Time, error) {
+	if ctx.Err() != nil {
+		return time.Time{}, ctx.Err()
+	}
+	if err := (&models.PhoneBook{PhoneNumber: phoneNumber}).Validate(); err != nil {
+		return time.Time{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.opts.Now()
+	challenge, err := s.otpDAO.GetChallenge(ctx, phoneNumber)
+	if err != nil && !errors.Is(err, daoerrors.ErrOTPChallengeNotFound) {
+		return time.Time{}, err
+	}
+	if challenge == nil || !now.Before(challenge.WindowStart.Add(s.opts.SendWindow)) {
+		challenge = &models.OTPChallenge{PhoneNumber: phoneNumber, WindowStart: now}
+	}
+	if wait := challenge.LastSentAt.Add(s.opts.ResendInterval).Sub(now); wait > 0 {
+		return time.Time{}, &ThrottledError{RetryAfter: wait}
+	}
+	if challenge.SendCount >= s.opts.MaxSends {
+		return time.Time{}, &ThrottledError{RetryAfter: challenge.WindowStart.Add(s.opts.SendWindow).Sub(now)}
+	}
+
+	code, err := generateCode(s.opts.CodeLength)
+	if err != nil {
+		return time.Time{}, err
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return time.Time{}, err
+	}
+	challenge.CodeHash = hashCode(salt, code)
+	challenge.Salt = salt
+	challenge.ExpiresAt = now.Add(s.opts.CodeTTL)
+	challenge.Attempts = 0
+	challenge.LastSentAt = now
+	challenge.SendCount++
+
+	if err := s.otpDAO.SaveChallenge(ctx, challenge); err != nil {
+		return time.Time{}, err
+	}
+	message := fmt.Sprintf("Your truecaller-lite verification code is %s. It expires in %s.", code, s.opts.CodeTTL)
+	if err := s.sender.SendSMS(ctx, phoneNumber, message); err != nil {
+		return time.Time{}, fmt.Errorf("send verification code: %w", err)
+	}
+	return challenge.ExpiresAt, nil
+}
+
+// VerifyCode checks code against the pending challenge for phoneNumber.
+func (s *service) VerifyCode(ctx context.Context, phoneNumber, code string) (string, time.Time, error) {
+	if ctx.Err() != nil {
+		return "", time.Time{}, ctx.Err()
+	}
+	if err := (&models.PhoneBook{PhoneNumber: phoneNumber}).Validate(); err != nil {
+		return "", time.Time{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	challenge, err := s.otpDAO.GetChallenge(ctx, phoneNumber)
+	if errors.Is(err, daoerrors.ErrOTPChallengeNotFound) || (err == nil && challenge == nil) {
+		return "", time.Time{}, ErrInvalidCode
+	}
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if !s.opts.Now().Before(challenge.ExpiresAt) {
+		return "", time.Time{}, ErrCodeExpired
+	}
+	if challenge.Attempts >= s.opts.MaxAttempts {
+		return "", time.Time{}, ErrTooManyAttempts
+	}
+	if subtle.ConstantTimeCompare(hashCode(challenge.Salt, code), challenge.CodeHash) != 1 {
+		challenge.Attempts++
+		if err := s.otpDAO.SaveChallenge(ctx, challenge); err != nil {
+			return "", time.Time{}, err
+		}
+		return "", time.Time{}, ErrInvalidCode
+	}
+	// Keep the send window so verifying does not reset resend throttling; only the code is consumed.
+	challenge.CodeHash = nil
+	challenge.ExpiresAt = time.Time{}
+	if err := s.otpDAO.SaveChallenge(ctx, challenge); err != nil {
+		return "", time.Time{}, err
+	}
+	return s.issuer.IssueToken(ctx, phoneNumber)
+}
+
+// generateCode returns a uniformly random numeric code of the given length.
+func generateCode(length int) (string, error) {
+	limit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(length)), nil)
+	n, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", length, n), nil
+}
+
+// hashCode returns the salted SHA-256 hash of code.
+func hashCode(salt []byte, code string) []byte {
+	h := sha256.New()
+	h.Write(salt)
+	h.Write([]byte(code))
+	return h.Sum(nil)
+}
+
+var _ Service = (*service)(nil)
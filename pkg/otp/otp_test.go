@@ -0,0 +1,218 @@
+package otp
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao/mem"
+	"github.com/yourusername/truecaller-lite/pkg/dao/mock"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+var codePattern = regexp.MustCompile(`\b\d{6}\b`)
+
+// testClock is a manually advanced clock.
+type testClock struct{ now time.Time }
+
+func (c *testClock) Now() time.Time          { return c.now }
+func (c *testClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+// newTestService returns a Service backed by in-memory storage and the last code sent to each number.
+func newTestService(clock *testClock, opts Options) (Service, map[string]string) {
+	sent := make(map[string]string)
+	sender := SMSSenderFunc(func(ctx context.Context, phone, message string) error {
+		sent[phone] = codePattern.FindString(message)
+		return nil
+	})
+	opts.Now = clock.Now
+	return NewService(mem.NewOTPMemDAO(), sender, NewSessionIssuer(time.Hour), opts), sent
+}
+
+func TestService_RequestAndVerify(t *testing.T) {
+	ctx := context.Background()
+	clock := &testClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	svc, sent := newTestService(clock, Options{})
+
+	expiresAt, err := svc.RequestCode(ctx, "919876543210")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := clock.now.Add(DefaultCodeTTL); !expiresAt.Equal(want) {
+		t.Errorf("expected expiry %v, got %v", want, expiresAt)
+	}
+	code := sent["919876543210"]
+	if len(code) != DefaultCodeLength {
+		t.Fatalf("expected a %d digit code, got %q", DefaultCodeLength, code)
+	}
+	if _, _, err := svc.VerifyCode(ctx, "919876543210", "wrong"); !errors.Is(err, ErrInvalidCode) {
+		t.Errorf("expected ErrInvalidCode, got %v", err)
+	}
+	token, _, err := svc.VerifyCode(ctx, "919876543210", code)
+	if err != nil || token == "" {
+		t.Fatalf("expected token, got %q (%v)", token, err)
+	}
+	if _, _, err := svc.VerifyCode(ctx, "919876543210", code); err == nil {
+		t.Error("expected a used code to be rejected, got nil")
+	}
+}
+
+func TestService_VerifyCode_Failures(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name    string
+		prepare func(svc Service, clock *testClock, code string)
+		code    func(code string) string
+		wantErr error
+	}{
+		{
+			name:    "no pending challenge",
+			prepare: func(svc Service, clock *testClock, code string) {},
+			code:    func(string) string { return "123456" },
+			wantErr: ErrInvalidCode,
+		},
+		{
+			name: "expired",
+			prepare: func(svc Service, clock *testClock, code string) {
+				clock.Advance(DefaultCodeTTL)
+			},
+			code:    func(code string) string { return code },
+			wantErr: ErrCodeExpired,
+		},
+		{
+			name: "attempt limit reached",
+			prepare: func(svc Service, clock *testClock, code string) {
+				for i := 0; i < DefaultMaxAttempts; i++ {
+					_, _, _ = svc.VerifyCode(ctx, "919876543210", "x")
+				}
+			},
+			code:    func(code string) string { return code },
+			wantErr: ErrTooManyAttempts,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := &testClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+			svc, sent := newTestService(clock, Options{})
+			if tc.name != "no pending challenge" {
+				if _, err := svc.RequestCode(ctx, "919876543210"); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			tc.prepare(svc, clock, sent["919876543210"])
+			if _, _, err := svc.VerifyCode(ctx, "919876543210", tc.code(sent["919876543210"])); !errors.Is(err, tc.wantErr) {
+				t.Errorf("expected %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestService_RequestCode_Throttling(t *testing.T) {
+	ctx := context.Background()
+	clock := &testClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	svc, _ := newTestService(clock, Options{MaxSends: 2})
+
+	if _, err := svc.RequestCode(ctx, "919876543210"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	clock.Advance(10 * time.Second)
+	_, err := svc.RequestCode(ctx, "919876543210")
+	var throttled *ThrottledError
+	if !errors.As(err, &throttled) || !errors.Is(err, ErrResendThrottled) || throttled.RetryAfter != 20*time.Second {
+		t.Fatalf("expected resend throttled for 20s, got %v", err)
+	}
+	clock.Advance(20 * time.Second)
+	if _, err := svc.RequestCode(ctx, "919876543210"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	clock.Advance(DefaultResendInterval)
+	if _, err := svc.RequestCode(ctx, "919876543210"); !errors.As(err, &throttled) || throttled.RetryAfter != DefaultSendWindow-30*time.Second-DefaultResendInterval {
+		t.Fatalf("expected send window limit, got %v", err)
+	}
+	clock.Advance(DefaultSendWindow)
+	if _, err := svc.RequestCode(ctx, "919876543210"); err != nil {
+		t.Errorf("expected a new send window, got %v", err)
+	}
+}
+
+func TestService_RequestCode_Errors(t *testing.T) {
+	ctx := context.Background()
+	sendErr := errors.New("gateway down")
+	sends := 0
+	failing := SMSSenderFunc(func(ctx context.Context, phone, message string) error { sends++; return sendErr })
+	var saved *models.OTPChallenge
+	otpDAO := &mock.OTPDAOMock{OnSaveChallenge: func(ctx context.Context, c *models.OTPChallenge) error { saved = c; return nil }}
+	svc := NewService(otpDAO, failing, NewSessionIssuer(0), Options{})
+	if _, err := svc.RequestCode(ctx, "919876543210"); !errors.Is(err, sendErr) {
+		t.Errorf("expected send error, got %v", err)
+	}
+	if saved == nil || saved.SendCount != 1 {
+		t.Errorf("expected the challenge to be saved before sending, got %+v", saved)
+	}
+
+	saveErr := errors.New("store down")
+	sends = 0
+	otpDAO.OnSaveChallenge = func(ctx context.Context, c *models.OTPChallenge) error { return saveErr }
+	if _, err := svc.RequestCode(ctx, "919876543211"); !errors.Is(err, saveErr) {
+		t.Errorf("expected save error, got %v", err)
+	}
+	if sends != 0 {
+		t.Error("expected no code to be sent when the challenge cannot be saved")
+	}
+	if _, err := svc.RequestCode(ctx, "123"); err == nil {
+		t.Error("expected validation error, got nil")
+	}
+}
+
+func TestFileSender(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sms.log")
+	sender := NewFileSender(path)
+	if err := sender.SendSMS(context.Background(), "919876543210", "code 123456"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(data), "\t919876543210\tcode 123456\n") {
+		t.Errorf("unexpected file contents %q", data)
+	}
+}
+
+func TestSessionIssuer(t *testing.T) {
+	ctx := context.Background()
+	issuer := NewSessionIssuer(time.Minute)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	issuer.now = func() time.Time { return now }
+	token, expiresAt, err := issuer.IssueToken(ctx, "919876543210")
+	if err != nil || !expiresAt.Equal(now.Add(time.Minute)) {
+		t.Fatalf("unexpected result %v (%v)", expiresAt, err)
+	}
+	if phone, err := issuer.VerifyToken(ctx, token); err != nil || phone != "919876543210" {
+		t.Errorf("expected subject 919876543210, got %q (%v)", phone, err)
+	}
+	if _, err := issuer.VerifyToken(ctx, "bogus"); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expected ErrInvalidToken, got %v", err)
+	}
+	now = now.Add(time.Minute)
+	if _, err := issuer.VerifyToken(ctx, token); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expected expired token to be rejected, got %v", err)
+	}
+
+	revoked, _, _ := issuer.IssueToken(ctx, "919876543210")
+	other, _, _ := issuer.IssueToken(ctx, "919123456789")
+	if err := issuer.RevokeTokens(ctx, "919876543210"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := issuer.VerifyToken(ctx, revoked); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expected revoked token to be rejected, got %v", err)
+	}
+	if _, err := issuer.VerifyToken(ctx, other); err != nil {
+		t.Errorf("expected other numbers' tokens to stay valid, got %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package otp
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// SMSSender delivers a text message to a phone number. Production deployments plug in an SMS gateway client.
+type SMSSender interface {
+	// SendSMS sends message to phoneNumber, returning an error if delivery could not be started.
+	SendSMS(ctx context.Context, phoneNumber, message string) error
+}
+
+// SMSSenderFunc adapts a function to the SMSSender interface.
+type SMSSenderFunc func(ctx context.Context, phoneNumber, message string) error
+
+// SendSMS calls f(ctx, phoneNumber, message).
+func (f SMSSenderFunc) SendSMS(ctx context.Context, phoneNumber, message string) error {
+	return f(ctx, phoneNumber, message)
+}
+
+// LogSender writes messages to a logger instead of sending them. Intended for local development.
+type LogSender struct {
+	logger *log.Logger
+}
+
+// NewLogSender creates a LogSender writing to logger, or to the standard logger if logger is nil.
+func NewLogSender(logger *log.Logger) *LogSender {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &LogSender{logger: logger}
+}
+
+// SendSMS logs the message.
+func (s *LogSender) SendSMS(ctx context.Context, phoneNumber, message string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	s.logger.Printf("sms to %s: %s", phoneNumber, message)
+	return nil
+}
+
+// FileSender appends messages to a file, one tab-separated line (time, phone number, message) per message.
+// Intended for development and end-to-end tests that need to read the code back.
+type FileSender struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileSender creates a FileSender appending to the file at path, which is created if missing.
+func NewFileSender(path string) *FileSender {
+	return &FileSender{path: path}
+}
+
+// SendSMS appends the message to the file.
+func (s *FileSender) SendSMS(ctx context.Context, phoneNumber, message string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(f, "%s\t%s\t%s\n", time.Now().UTC().Format(time.RFC3339), phoneNumber, message); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+var (
+	_ SMSSender = (*LogSender)(nil)
+	_ SMSSender = (*FileSender)(nil)
+)
@@ -0,0 +1,123 @@
+package otp
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"sync"
+	"time"
+
+	"github.com/yourusername/truecaller-lite/pkg/apierrors"
+)
+
+// DefaultSessionTTL is how long session tokens issued by SessionIssuer stay valid when no TTL is given.
+const DefaultSessionTTL = 24 * time.Hour
+
+// ErrInvalidToken is returned when a session token is unknown, malformed or expired. It is the same error as
+// auth.ErrInvalidToken (apierrors.ErrInvalidToken).
+var ErrInvalidToken = apierrors.ErrInvalidToken
+
+// TokenIssuer issues session tokens proving that the holder verified ownership of a phone number.
+type TokenIssuer interface {
+	// IssueToken returns a token whose subject is phoneNumber, and when it expires.
+	IssueToken(ctx context.Context, phoneNumber string) (token string, expiresAt time.Time, err error)
+}
+
+// TokenVerifier resolves a session token to the phone number it was issued for.
+type TokenVerifier interface {
+	// VerifyToken returns the token's phone number, or an error wrapping ErrInvalidToken.
+	VerifyToken(ctx context.Context, token string) (phoneNumber string, err error)
+}
+
+// TokenRevoker invalidates the session tokens issued for a phone number, e.g. when its data is erased.
+type TokenRevoker interface {
+	// RevokeTokens invalidates every token issued for phoneNumber so far. Tokens issued afterwards are valid.
+	RevokeTokens(ctx context.Context, phoneNumber string) error
+}
+
+// SessionIssuer issues random opaque session tokens kept in memory. Only token hashes are stored.
+// Tokens do not survive a restart and are not shared between instances.
+type SessionIssuer struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	now      func() time.Time
+	sessions map[[sha256.Size]byte]session
+}
+
+// session is the phone number and expiry bound to an issued token.
+type session struct {
+	phoneNumber string
+	expiresAt   time.Time
+}
+
+// NewSessionIssuer creates a SessionIssuer whose tokens expire after ttl (DefaultSessionTTL if ttl <= 0).
+func NewSessionIssuer(ttl time.Duration) *SessionIssuer {
+	if ttl <= 0 {
+		ttl = DefaultSessionTTL
+	}
+	return &SessionIssuer{ttl: ttl, now: time.Now, sessions: make(map[[sha256.Size]byte]session)}
+}
+
+// IssueToken returns a new random token for phoneNumber.
+func (s *SessionIssuer) IssueToken(ctx context.Context, phoneNumber string) (string, time.Time, error) {
+	if ctx.Err() != nil {
+		return "", time.Time{}, ctx.Err()
+	}
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", time.Time{}, err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	for key, sess := range s.sessions {
+		if !now.Before(sess.expiresAt) {
+			delete(s.sessions, key)
+		}
+	}
+	expiresAt := now.Add(s.ttl)
+	s.sessions[sha256.Sum256([]byte(token))] = session{phoneNumber: phoneNumber, expiresAt: expiresAt}
+	return token, expiresAt, nil
+}
+
+// VerifyToken returns the phone number token was issued for.
+func (s *SessionIssuer) VerifyToken(ctx context.Context, token string) (string, error) {
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := sha256.Sum256([]byte(token))
+	sess, ok := s.sessions[key]
+	if !ok {
+		return "", ErrInvalidToken
+	}
+	if !s.now().Before(sess.expiresAt) {
+		delete(s.sessions, key)
+		return "", ErrInvalidToken
+	}
+	return sess.phoneNumber, nil
+}
+
+// RevokeTokens forgets every session of phoneNumber.
+func (s *SessionIssuer) RevokeTokens(ctx context.Context, phoneNumber string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, sess := range s.sessions {
+		if sess.phoneNumber == phoneNumber {
+			delete(s.sessions, key)
+		}
+	}
+	return nil
+}
+
+var (
+	_ TokenIssuer   = (*SessionIssuer)(nil)
+	_ TokenVerifier = (*SessionIssuer)(nil)
+	_ TokenRevoker  = (*SessionIssuer)(nil)
+)
@@ -0,0 +1,280 @@
+// Package phonebookcsv imports phone books from CSV spreadsheets and exports them back to CSV.
+package phonebookcsv
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+// Default header names used by Export and by Import when no mapping is given.
+const (
+	DefaultOwnerColumn       = "owner_phone_number"
+	DefaultPhoneNumberColumn = "phone_number"
+	DefaultNameColumn        = "name"
+)
+
+// HeaderMode controls whether Import treats the first record as a header row.
+type HeaderMode int
+
+const (
+	// HeaderAuto treats the first record as a header if it names a mapped column (or a Default*Column name), and
+	// as data otherwise.
+	HeaderAuto HeaderMode = iota
+	// HeaderPresent always treats the first record as a header.
+	HeaderPresent
+	// HeaderAbsent treats every record as data.
+	HeaderAbsent
+)
+
+// ColumnMapping identifies the CSV columns holding each field.
+// Each value is either a header name (matched case-insensitively) or a zero-based column index such as "2".
+// Empty values fall back to the Default*Column header names, or, without a header row, to
+// phone number in column 0 and name in column 1.
+type ColumnMapping struct {
+	// Owner is the column holding the uploader's phone number. Optional when ImportOptions.Owner is set.
+	Owner string
+	// PhoneNumber is the column holding the contact's phone number.
+	PhoneNumber string
+	// Name is the column holding the contact's name.
+	Name string
+}
+
+// ImportOptions configures Import.
+type ImportOptions struct {
+	// Mapping identifies the CSV columns; see ColumnMapping.
+	Mapping ColumnMapping
+	// Owner is the uploader's phone number for every row. If set, the owner column is ignored.
+	Owner string
+	// Header controls header row detection.
+	Header HeaderMode
+	// Comma is the field delimiter. Defaults to ','.
+	Comma rune
+}
+
+// RowError reports why a CSV row was not imported.
+type RowError struct {
+	// Index is the zero-based position of the row among data rows (the header row is not counted).
+	Index int `json:"index"`
+	// Line is the 1-based line number of the row in the input.
+	Line int `json:"line"`
+	// Record is the row as read.
+	Record []string `json:"record"`
+	// Reasons lists the problems with the row.
+	Reasons []models.ErrorDetail `json:"reasons"`
+}
+
+// ImportResult is the outcome of Import.
+type ImportResult struct {
+	// PhoneBooks holds one phone book per owner, in order of first appearance, ready for UserService.UploadContacts.
+	PhoneBooks []models.PhoneBook `json:"phone_books"`
+	// Errors lists the rows that were skipped.
+	Errors []RowError `json:"errors"`
+}
+
+// columns holds resolved zero-based column indexes; -1 means absent.
+type columns struct {
+	owner, phone, name int
+}
+
+// Import reads a CSV phone book export. Valid rows are grouped into phone books by owner; a later row for the
+// same owner and contact replaces an earlier one. Invalid rows are skipped and reported in ImportResult.Errors.
+// An error is returned only if the input cannot be read or the column mapping does not match the input.
+func Import(r io.Reader, opts ImportOptions) (*ImportResult, error) {
+	if opts.Owner != "" {
+		if err := (&models.PhoneBook{PhoneNumber: opts.Owner}).Validate(); err != nil {
+			return nil, err
+		}
+	}
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if opts.Comma != 0 {
+		reader.Comma = opts.Comma
+	}
+
+	result := &ImportResult{PhoneBooks: []models.PhoneBook{}, Errors: []RowError{}}
+	books := make(map[string]int)
+	positions := make(map[string]map[string]int)
+	var cols columns
+	first := true
+	index := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				result.Errors = append(result.Errors, RowError{Index: index, Line: parseErr.Line, Reasons: []models.ErrorDetail{{Code: models.CodeMalformed, Message: parseErr.Err.Error()}}})
+				index++
+				continue
+			}
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		if first {
+			first = false
+			var isHeader bool
+			cols, isHeader, err = resolveColumns(opts, record)
+			if err != nil {
+				return nil, err
+			}
+			if isHeader {
+				continue
+			}
+		}
+		owner, contact, reasons := parseRow(opts, cols, record)
+		if len(reasons) > 0 {
+			result.Errors = append(result.Errors, RowError{Index: index, Line: line, Record: record, Reasons: reasons})
+			index++
+			continue
+		}
+		index++
+		bookIndex, ok := books[owner]
+		if !ok {
+			bookIndex = len(result.PhoneBooks)
+			books[owner] = bookIndex
+			positions[owner] = make(map[string]int)
+			result.PhoneBooks = append(result.PhoneBooks, models.PhoneBook{PhoneNumber: owner})
+		}
+		pb := &result.PhoneBooks[bookIndex]
+		if i, ok := positions[owner][contact.PhoneNumber]; ok {
+			pb.Contacts[i] = contact
+			continue
+		}
+		positions[owner][contact.PhoneNumber] = len(pb.Contacts)
+		pb.Contacts = append(pb.Contacts, contact)
+	}
+	return result, nil
+}
+
+// parseRow extracts and validates one data row.
+func parseRow(opts ImportOptions, cols columns, record []string) (string, models.Contact, []models.ErrorDetail) {
+	field := func(i int) string {
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+	var reasons []models.ErrorDetail
+	owner := opts.Owner
+	if owner == "" {
+		owner = models.NormalizePhoneNumber(field(cols.owner))
+		if err := (&models.PhoneBook{PhoneNumber: owner}).Validate(); err != nil {
+			for _, ve := range models.CollectValidationErrors(err) {
+				reasons = append(reasons, models.ErrorDetail{Field: DefaultOwnerColumn, Code: ve.Code, Message: ve.Message})
+			}
+		}
+	}
+	contact := models.Contact{PhoneNumber: models.NormalizePhoneNumber(field(cols.phone)), Name: unescapeCell(field(cols.name))}
+	if err := contact.Validate(); err != nil {
+		reasons = append(reasons, models.NewRejectedContact(0, contact, err).Reasons...)
+	}
+	return owner, contact, reasons
+}
+
+// resolveColumns maps opts.Mapping onto the first record and reports whether that record is a header.
+func resolveColumns(opts ImportOptions, first []string) (columns, bool, error) {
+	header := make(map[string]int, len(first))
+	for i, cell := range first {
+		header[strings.ToLower(strings.TrimSpace(cell))] = i
+	}
+	isHeader := opts.Header == HeaderPresent
+	if opts.Header == HeaderAuto {
+		for _, name := range []string{opts.Mapping.Owner, opts.Mapping.PhoneNumber, opts.Mapping.Name, DefaultPhoneNumberColumn, DefaultNameColumn} {
+			if _, ok := header[strings.ToLower(name)]; ok && name != "" {
+				isHeader = true
+			}
+		}
+	}
+	lookup := func(spec, defaultName string, defaultIndex int) (int, error) {
+		if spec == "" {
+			if isHeader {
+				if i, ok := header[defaultName]; ok {
+					return i, nil
+				}
+				return -1, nil
+			}
+			return defaultIndex, nil
+		}
+		if i, err := strconv.Atoi(spec); err == nil && i >= 0 {
+			return i, nil
+		}
+		if !isHeader {
+			return -1, fmt.Errorf("phonebookcsv: column %q given by name but input has no header row", spec)
+		}
+		i, ok := header[strings.ToLower(spec)]
+		if !ok {
+			return -1, fmt.Errorf("phonebookcsv: column %q not found in header", spec)
+		}
+		return i, nil
+	}
+	var cols columns
+	var err error
+	if cols.phone, err = lookup(opts.Mapping.PhoneNumber, DefaultPhoneNumberColumn, 0); err != nil {
+		return cols, false, err
+	}
+	if cols.name, err = lookup(opts.Mapping.Name, DefaultNameColumn, 1); err != nil {
+		return cols, false, err
+	}
+	if cols.owner, err = lookup(opts.Mapping.Owner, DefaultOwnerColumn, -1); err != nil {
+		return cols, false, err
+	}
+	if cols.phone < 0 || cols.name < 0 {
+		return cols, false, errors.New("phonebookcsv: phone number and name columns are required")
+	}
+	if cols.owner < 0 && opts.Owner == "" {
+		return cols, false, errors.New("phonebookcsv: owner column or ImportOptions.Owner is required")
+	}
+	return cols, isHeader, nil
+}
+
+// formulaPrefixes are the leading characters that make spreadsheet applications evaluate a cell as a formula.
+const formulaPrefixes = "=+-@"
+
+// escapeCell prefixes cells that a spreadsheet would evaluate as a formula with a single quote, so exported
+// names are shown as text.
+func escapeCell(cell string) string {
+	if cell != "" && strings.ContainsRune(formulaPrefixes, rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
+
+// unescapeCell reverses escapeCell.
+func unescapeCell(cell string) string {
+	if len(cell) > 1 && cell[0] == '\'' && strings.ContainsRune(formulaPrefixes, rune(cell[1])) {
+		return cell[1:]
+	}
+	return cell
+}
+
+// Export writes the owner's phone book as CSV with a "phone_number,name" header row. Cells starting with '=',
+// '+', '-' or '@' are prefixed with a single quote so spreadsheets do not run them as formulas; Import removes it.
+// Returns daoerrors.ErrPhoneBookNotFound if the owner has no phone book.
+func Export(ctx context.Context, w io.Writer, phoneBookDAO dao.PhoneBookDAO, ownerPhoneNumber string) error {
+	pb, err := phoneBookDAO.GetPhoneBookByUserPhoneNumber(ctx, ownerPhoneNumber)
+	if err != nil {
+		return err
+	}
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{DefaultPhoneNumberColumn, DefaultNameColumn}); err != nil {
+		return err
+	}
+	for _, c := range pb.GetContacts() {
+		if err := writer.Write([]string{escapeCell(c.GetPhoneNumber()), escapeCell(c.GetName())}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
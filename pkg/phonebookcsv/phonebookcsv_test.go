@@ -0,0 +1,138 @@
+package phonebookcsv
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao/daoerrors"
+	"github.com/yourusername/truecaller-lite/pkg/dao/mem"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+func TestImport(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		opts       ImportOptions
+		wantBooks  []models.PhoneBook
+		wantErrors []int
+		wantErr    bool
+	}{
+		{
+			name:  "default header with owner column",
+			input: "owner_phone_number,phone_number,name\n919876543210,+91 91234 56789,Bob\n919876543210,9123456780,Carol\n919876543211,9123456789,Robert\n",
+			wantBooks: []models.PhoneBook{
+				{PhoneNumber: "919876543210", Contacts: []models.Contact{{PhoneNumber: "919123456789", Name: "Bob"}, {PhoneNumber: "919123456780", Name: "Carol"}}},
+				{PhoneNumber: "919876543211", Contacts: []models.Contact{{PhoneNumber: "919123456789", Name: "Robert"}}},
+			},
+			wantErrors: []int{},
+		},
+		{
+			name:  "custom mapping by header name with row errors",
+			input: "Full Name;Mobile\nBob;9123456789\n;9123456780\nBad;123\nBobby;09123456789\n",
+			opts:  ImportOptions{Owner: "919876543210", Comma: ';', Mapping: ColumnMapping{PhoneNumber: "mobile", Name: "Full Name"}},
+			wantBooks: []models.PhoneBook{
+				{PhoneNumber: "919876543210", Contacts: []models.Contact{{PhoneNumber: "919123456789", Name: "Bobby"}}},
+			},
+			wantErrors: []int{1, 2},
+		},
+		{
+			name:  "headerless input by index",
+			input: "Bob,9123456789\n",
+			opts:  ImportOptions{Owner: "919876543210", Mapping: ColumnMapping{PhoneNumber: "1", Name: "0"}},
+			wantBooks: []models.PhoneBook{
+				{PhoneNumber: "919876543210", Contacts: []models.Contact{{PhoneNumber: "919123456789", Name: "Bob"}}},
+			},
+			wantErrors: []int{},
+		},
+		{
+			name:  "unrecognized header is a row error",
+			input: "Mobile,Who\n9123456789,Bob\n",
+			opts:  ImportOptions{Owner: "919876543210"},
+			wantBooks: []models.PhoneBook{
+				{PhoneNumber: "919876543210", Contacts: []models.Contact{{PhoneNumber: "919123456789", Name: "Bob"}}},
+			},
+			wantErrors: []int{0},
+		},
+		{
+			name:  "first data row without digits is not taken as a header",
+			input: "none,Bob\n9123456789,Carol\n",
+			opts:  ImportOptions{Owner: "919876543210"},
+			wantBooks: []models.PhoneBook{
+				{PhoneNumber: "919876543210", Contacts: []models.Contact{{PhoneNumber: "919123456789", Name: "Carol"}}},
+			},
+			wantErrors: []int{0},
+		},
+		{
+			name:       "invalid owner in row",
+			input:      "owner_phone_number,phone_number,name\n123,9123456789,Bob\n",
+			wantBooks:  []models.PhoneBook{},
+			wantErrors: []int{0},
+		},
+		{
+			name:    "mapped column missing from header",
+			input:   "phone_number,name\n9123456789,Bob\n",
+			opts:    ImportOptions{Owner: "919876543210", Mapping: ColumnMapping{Name: "display_name"}},
+			wantErr: true,
+		},
+		{
+			name:    "no owner",
+			input:   "phone_number,name\n9123456789,Bob\n",
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Import(strings.NewReader(tc.input), tc.opts)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error: %v, got: %v", tc.wantErr, err)
+			}
+			if tc.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got.PhoneBooks, tc.wantBooks) {
+				t.Errorf("expected books %+v, got %+v", tc.wantBooks, got.PhoneBooks)
+			}
+			var gotErrors []int
+			for _, e := range got.Errors {
+				gotErrors = append(gotErrors, e.Index)
+				if len(e.Reasons) == 0 || e.Line == 0 {
+					t.Errorf("expected reasons and line for row error, got %+v", e)
+				}
+			}
+			if len(gotErrors) != len(tc.wantErrors) || (len(gotErrors) > 0 && !reflect.DeepEqual(gotErrors, tc.wantErrors)) {
+				t.Errorf("expected row errors %v, got %v", tc.wantErrors, gotErrors)
+			}
+		})
+	}
+}
+
+func TestExport(t *testing.T) {
+	ctx := context.Background()
+	phoneBookDAO := mem.NewPhoneBookMemDAO()
+	pb := &models.PhoneBook{PhoneNumber: "919876543210", Contacts: []models.Contact{{PhoneNumber: "919123456789", Name: "Rao, Anita"}, {PhoneNumber: "919123456780", Name: "=HYPERLINK(\"http://x\")"}, {PhoneNumber: "919123456781", Name: "@Ravi"}}}
+	if err := phoneBookDAO.CreateOrUpdatePhoneBook(ctx, pb); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := Export(ctx, &buf, phoneBookDAO, "919876543210"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "phone_number,name\n919123456789,\"Rao, Anita\"\n919123456780,\"'=HYPERLINK(\"\"http://x\"\")\"\n919123456781,'@Ravi\n"
+	if buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+
+	roundTrip, err := Import(&buf, ImportOptions{Owner: "919876543210"})
+	if err != nil || !reflect.DeepEqual(roundTrip.PhoneBooks, []models.PhoneBook{*pb}) {
+		t.Errorf("expected round trip to reproduce phone book, got %+v (%v)", roundTrip, err)
+	}
+
+	if err := Export(ctx, &buf, phoneBookDAO, "919999999999"); !errors.Is(err, daoerrors.ErrPhoneBookNotFound) {
+		t.Errorf("expected phone book not found error, got %v", err)
+	}
+}
@@ -0,0 +1,192 @@
+// Package ratelimit provides keyed token-bucket rate limiters with a bounded number of tracked keys.
+package ratelimit
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultMaxKeys bounds the number of keys a Limiter tracks when Options.MaxKeys is zero.
+const DefaultMaxKeys = 100000
+
+// ErrLimited is matched by errors.Is for every *LimitedError.
+var ErrLimited = errors.New("rate limit exceeded")
+
+// LimitedError reports that a request was rate limited and when it may be retried.
+type LimitedError struct {
+	// RetryAfter is how long the caller must wait before the next request is allowed.
+	RetryAfter time.Duration
+}
+
+// Error implements the error interface.
+func (e *LimitedError) Error() string {
+	return fmt.Sprintf("%s: retry after %s", ErrLimited, e.RetryAfter.Round(time.Second))
+}
+
+// Is reports whether target is ErrLimited.
+func (e *LimitedError) Is(target error) bool { return target == ErrLimited }
+
+// Limit is a token-bucket rate: Rate tokens per second are added up to a maximum of Burst.
+type Limit struct {
+	// Rate is the sustained number of requests per second. A Rate <= 0 disables limiting.
+	Rate float64
+	// Burst is the bucket size, i.e. the number of requests allowed at once. Values below 1 are treated as 1.
+	Burst int
+}
+
+// Every returns a Limit allowing n requests per interval, all of which may be made at once.
+func Every(n int, interval time.Duration) Limit {
+	if n <= 0 || interval <= 0 {
+		return Limit{}
+	}
+	return Limit{Rate: float64(n) / interval.Seconds(), Burst: n}
+}
+
+// ParseLimit parses a limit written as "<n>/<interval>", e.g. "60/1m" or "5/1s", as Every(n, interval).
+// "0" or an empty string disables limiting.
+func ParseLimit(s string) (Limit, error) {
+	if s == "" || s == "0" {
+		return Limit{}, nil
+	}
+	count, interval, ok := strings.Cut(s, "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("invalid limit %q: expected <n>/<interval>", s)
+	}
+	n, err := strconv.Atoi(count)
+	if err != nil || n < 0 {
+		return Limit{}, fmt.Errorf("invalid limit %q: bad count", s)
+	}
+	d, err := time.ParseDuration(interval)
+	if err != nil || d <= 0 {
+		return Limit{}, fmt.Errorf("invalid limit %q: bad interval", s)
+	}
+	return Every(n, d), nil
+}
+
+// Options configures a Limiter. Zero fields use the package defaults.
+type Options struct {
+	// MaxKeys is the maximum number of keys tracked. When exceeded, the least recently used key is evicted,
+	// which resets its bucket to full.
+	MaxKeys int
+	// Now returns the current time; time.Now if nil. Intended for tests.
+	Now func() time.Time
+}
+
+// Limiter rate limits requests per key, e.g. per client IP, with one token bucket per key.
+// It is safe for concurrent use.
+type Limiter struct {
+	mu      sync.Mutex
+	limit   Limit
+	maxKeys int
+	now     func() time.Time
+	buckets map[string]*list.Element // values are *bucket
+	lru     *list.List               // front: most recently used
+}
+
+// bucket is the token bucket of one key.
+type bucket struct {
+	key    string
+	tokens float64
+	last   time.Time
+}
+
+// NewLimiter creates a Limiter applying limit to every key.
+func NewLimiter(limit Limit, opts Options) *Limiter {
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+	if opts.MaxKeys <= 0 {
+		opts.MaxKeys = DefaultMaxKeys
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return &Limiter{limit: limit, maxKeys: opts.MaxKeys, now: opts.Now, buckets: make(map[string]*list.Element), lru: list.New()}
+}
+
+// Allow takes one token from key's bucket. It returns nil if the request is allowed, or a *LimitedError
+// telling the caller how long to wait.
+func (l *Limiter) Allow(key string) error {
+	return AllowAll(Charge{Limiter: l, Key: key, Tokens: 1})
+}
+
+// Charge is a number of tokens to take from one key's bucket of a Limiter.
+type Charge struct {
+	Limiter *Limiter
+	Key     string
+	// Tokens is the number of tokens taken. More tokens than the limit's burst are never allowed.
+	Tokens int
+}
+
+// AllowAll takes the tokens of every charge, or none of them: if any bucket is short, the tokens already taken
+// from the others are returned and the *LimitedError of the first short bucket is returned. A request that must
+// pass several limits therefore costs nothing when one of them refuses it.
+func AllowAll(charges ...Charge) error {
+	for i, c := range charges {
+		if err := c.Limiter.take(c.Key, float64(c.Tokens)); err != nil {
+			for _, taken := range charges[:i] {
+				taken.Limiter.refund(taken.Key, float64(taken.Tokens))
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// take takes n tokens from key's bucket if it holds that many.
+func (l *Limiter) take(key string, n float64) error {
+	if l.limit.Rate <= 0 {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	var b *bucket
+	if elem, ok := l.buckets[key]; ok {
+		l.lru.MoveToFront(elem)
+		b = elem.Value.(*bucket)
+		b.tokens = math.Min(float64(l.limit.Burst), b.tokens+now.Sub(b.last).Seconds()*l.limit.Rate)
+		b.last = now
+	} else {
+		b = &bucket{key: key, tokens: float64(l.limit.Burst), last: now}
+		l.buckets[key] = l.lru.PushFront(b)
+		for l.lru.Len() > l.maxKeys {
+			oldest := l.lru.Back()
+			l.lru.Remove(oldest)
+			delete(l.buckets, oldest.Value.(*bucket).key)
+		}
+	}
+	if b.tokens >= n {
+		b.tokens -= n
+		return nil
+	}
+	wait := time.Duration((n - b.tokens) / l.limit.Rate * float64(time.Second))
+	return &LimitedError{RetryAfter: wait}
+}
+
+// refund returns n tokens taken by take to key's bucket. Tokens of an evicted key are dropped, since its bucket
+// starts full again.
+func (l *Limiter) refund(key string, n float64) {
+	if l.limit.Rate <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if elem, ok := l.buckets[key]; ok {
+		b := elem.Value.(*bucket)
+		b.tokens = math.Min(float64(l.limit.Burst), b.tokens+n)
+	}
+}
+
+// Len returns the number of keys currently tracked.
+func (l *Limiter) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.lru.Len()
+}
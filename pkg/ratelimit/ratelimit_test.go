@@ -0,0 +1,113 @@
+package ratelimit
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLimiter_Allow(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := NewLimiter(Every(2, time.Second), Options{Now: func() time.Time { return now }})
+
+	for i := 0; i < 2; i++ {
+		if err := l.Allow("a"); err != nil {
+			t.Fatalf("request %d: unexpected error: %v", i, err)
+		}
+	}
+	err := l.Allow("a")
+	var limited *LimitedError
+	if !errors.As(err, &limited) || !errors.Is(err, ErrLimited) || limited.RetryAfter != 500*time.Millisecond {
+		t.Fatalf("expected limited for 500ms, got %v", err)
+	}
+	if err := l.Allow("b"); err != nil {
+		t.Errorf("expected keys to be limited independently, got %v", err)
+	}
+	now = now.Add(500 * time.Millisecond)
+	if err := l.Allow("a"); err != nil {
+		t.Errorf("expected a refilled token, got %v", err)
+	}
+	if err := l.Allow("a"); err == nil {
+		t.Error("expected limit, got nil")
+	}
+	now = now.Add(time.Hour)
+	for i := 0; i < 2; i++ {
+		if err := l.Allow("a"); err != nil {
+			t.Errorf("expected refill capped at burst, request %d got %v", i, err)
+		}
+	}
+	if err := l.Allow("a"); err == nil {
+		t.Error("expected refill to be capped at burst")
+	}
+}
+
+func TestLimiter_Eviction(t *testing.T) {
+	l := NewLimiter(Every(1, time.Hour), Options{MaxKeys: 2})
+	_ = l.Allow("a")
+	_ = l.Allow("b")
+	_ = l.Allow("a") // a is now most recently used
+	_ = l.Allow("c") // evicts b
+	if l.Len() != 2 {
+		t.Fatalf("expected 2 tracked keys, got %d", l.Len())
+	}
+	if err := l.Allow("a"); err == nil {
+		t.Error("expected a to still be limited")
+	}
+	if err := l.Allow("b"); err != nil {
+		t.Errorf("expected evicted key to start with a full bucket, got %v", err)
+	}
+}
+
+func TestAllowAll(t *testing.T) {
+	a := NewLimiter(Every(2, time.Hour), Options{})
+	b := NewLimiter(Every(1, time.Hour), Options{})
+	if err := AllowAll(Charge{Limiter: a, Key: "k", Tokens: 1}, Charge{Limiter: b, Key: "k", Tokens: 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err := AllowAll(Charge{Limiter: a, Key: "k", Tokens: 1}, Charge{Limiter: b, Key: "k", Tokens: 1})
+	if !errors.Is(err, ErrLimited) {
+		t.Fatalf("expected the second limiter to refuse, got %v", err)
+	}
+	if err := a.Allow("k"); err != nil {
+		t.Errorf("expected the refused request's token to be returned, got %v", err)
+	}
+	if err := AllowAll(Charge{Limiter: b, Key: "other", Tokens: 2}); !errors.Is(err, ErrLimited) {
+		t.Errorf("expected a charge above the burst to be refused, got %v", err)
+	}
+}
+
+func TestLimiter_Disabled(t *testing.T) {
+	l := NewLimiter(Limit{}, Options{})
+	for i := 0; i < 100; i++ {
+		if err := l.Allow("a"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Limit
+		wantErr bool
+	}{
+		{in: "60/1m", want: Limit{Rate: 1, Burst: 60}},
+		{in: "5/1s", want: Limit{Rate: 5, Burst: 5}},
+		{in: "0", want: Limit{}},
+		{in: "", want: Limit{}},
+		{in: "60", wantErr: true},
+		{in: "x/1m", wantErr: true},
+		{in: "60/soon", wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := ParseLimit(tc.in)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error: %v, got: %v", tc.wantErr, err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+// Package search finds phone numbers by name through an inverted index over resolved user names.
+package search
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/yourusername/truecaller-lite/pkg/models"
+	"github.com/yourusername/truecaller-lite/pkg/names"
+)
+
+// Index is a thread-safe in-memory inverted index from name words to phone numbers.
+// Every word of a query must match a word of the name it is a prefix of, so "rah sh" finds "Rahul Sharma".
+// Devanagari words are also indexed in Latin letters, so "rahul" finds "राहुल" and the other way round.
+// Query words of names spelled differently also match: words that sound the same (names.PhoneticKey), so
+// "muhammad" finds "Mohammed", and words with a typo or two (names.MaxEdits), so "sharmaa" finds "Sharma".
+// Results are ranked by how they match, direct matches first, then by uploader count, most widely saved first.
+type Index struct {
+	mu       sync.RWMutex
+	docs     map[string]models.SearchResult // key: phone number
+	postings map[string]map[string]struct{} // key: word -> phone numbers
+	words    []string                       // sorted vocabulary, for prefix matching
+	byLength map[int][]string               // key: rune count -> vocabulary words of that length, for typo matching
+	phonetic map[string]map[string]struct{} // key: phonetic key of a word -> phone numbers
+}
+
+// How a query word matched a name, from best to worst.
+const (
+	directMatch  = iota // prefix of a name word or of its transliteration
+	similarMatch        // same phonetic key as a name word, or a name word with a typo
+)
+
+// minPhoneticKeyLen is the shortest phonetic key matched by sound; shorter keys, such as "r" for "rh", match
+// too many names.
+const minPhoneticKeyLen = 2
+
+// NewIndex creates an empty Index.
+func NewIndex() *Index {
+	return &Index{
+		docs:     make(map[string]models.SearchResult),
+		postings: make(map[string]map[string]struct{}),
+		byLength: make(map[int][]string),
+		phonetic: make(map[string]map[string]struct{}),
+	}
+}
+
+// Put adds or replaces the indexed entry for doc.PhoneNumber.
+func (ix *Index) Put(doc models.SearchResult) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.remove(doc.PhoneNumber)
+	ix.docs[doc.PhoneNumber] = doc
+	for _, w := range indexTerms(doc.Name) {
+		phones, ok := ix.postings[w]
+		if !ok {
+			phones = make(map[string]struct{})
+			ix.postings[w] = phones
+			i := sort.SearchStrings(ix.words, w)
+			ix.words = append(ix.words, "")
+			copy(ix.words[i+1:], ix.words[i:])
+			ix.words[i] = w
+			n := utf8.RuneCountInString(w)
+			ix.byLength[n] = append(ix.byLength[n], w)
+		}
+		phones[doc.PhoneNumber] = struct{}{}
+	}
+	for _, key := range phoneticKeys(doc.Name) {
+		phones, ok := ix.phonetic[key]
+		if !ok {
+			phones = make(map[string]struct{})
+			ix.phonetic[key] = phones
+		}
+		phones[doc.PhoneNumber] = struct{}{}
+	}
+}
+
+// Remove deletes the indexed entry for phoneNumber, if any.
+func (ix *Index) Remove(phoneNumber string) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.remove(phoneNumber)
+}
+
+// remove deletes the indexed entry for phoneNumber. Callers must hold the write lock.
+func (ix *Index) remove(phoneNumber string) {
+	doc, ok := ix.docs[phoneNumber]
+	if !ok {
+		return
+	}
+	delete(ix.docs, phoneNumber)
+	for _, w := range indexTerms(doc.Name) {
+		phones := ix.postings[w]
+		delete(phones, phoneNumber)
+		if len(phones) == 0 {
+			delete(ix.postings, w)
+			i := sort.SearchStrings(ix.words, w)
+			ix.words = append(ix.words[:i], ix.words[i+1:]...)
+			n := utf8.RuneCountInString(w)
+			if ix.byLength[n] = slices.DeleteFunc(ix.byLength[n], func(v string) bool { return v == w }); len(ix.byLength[n]) == 0 {
+				delete(ix.byLength, n)
+			}
+		}
+	}
+	for _, key := range phoneticKeys(doc.Name) {
+		phones := ix.phonetic[key]
+		delete(phones, phoneNumber)
+		if len(phones) == 0 {
+			delete(ix.phonetic, key)
+		}
+	}
+}
+
+// Len returns the number of indexed phone numbers.
+func (ix *Index) Len() int {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return len(ix.docs)
+}
+
+// Search returns every entry matching query, ranked by how the query matched, then by uploader count, then by
+// name and phone number. A query without words matches nothing.
+func (ix *Index) Search(query string) []models.SearchResult {
+	terms := Words(query)
+	if len(terms) == 0 {
+		return nil
+	}
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	var matches map[string]int
+	for _, term := range terms {
+		matches = intersect(matches, ix.matches(term))
+		if len(matches) == 0 {
+			return nil
+		}
+	}
+	results := make([]models.SearchResult, 0, len(matches))
+	for phone := range matches {
+		results = append(results, ix.docs[phone])
+	}
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if ma, mb := matches[a.PhoneNumber], matches[b.PhoneNumber]; ma != mb {
+			return ma < mb
+		}
+		if a.Uploaders != b.Uploaders {
+			return a.Uploaders > b.Uploaders
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.PhoneNumber < b.PhoneNumber
+	})
+	return results
+}
+
+// matches returns the phone numbers of names matching a query word, with how each matched.
+// Callers must hold the read lock.
+func (ix *Index) matches(term string) map[string]int {
+	matches := make(map[string]int)
+	add := func(phones map[string]struct{}, match int) {
+		for phone := range phones {
+			if m, ok := matches[phone]; !ok || match < m {
+				matches[phone] = match
+			}
+		}
+	}
+	latin := names.Transliterate(term)
+	for _, prefix := range []string{term, latin} {
+		for i := sort.SearchStrings(ix.words, prefix); i < len(ix.words) && strings.HasPrefix(ix.words[i], prefix); i++ {
+			add(ix.postings[ix.words[i]], directMatch)
+		}
+	}
+	if key := names.PhoneticKey(term); utf8.RuneCountInString(key) >= minPhoneticKeyLen {
+		add(ix.phonetic[key], similarMatch)
+	}
+	// Only words within the edit budget in length can be close enough, so the rest of the vocabulary is skipped
+	n := utf8.RuneCountInString(latin)
+	edits := names.MaxEdits(n)
+	for length := n - edits; edits > 0 && length <= n+edits; length++ {
+		for _, w := range ix.byLength[length] {
+			if names.EditDistance(latin, w) <= edits {
+				add(ix.postings[w], similarMatch)
+			}
+		}
+	}
+	return matches
+}
+
+// intersect returns the phone numbers in both a and b, each with the worse of its two matches; a nil a stands for
+// every phone number.
+func intersect(a, b map[string]int) map[string]int {
+	if a == nil {
+		return b
+	}
+	for phone, match := range a {
+		m, ok := b[phone]
+		if !ok {
+			delete(a, phone)
+		} else if m > match {
+			a[phone] = m
+		}
+	}
+	return a
+}
+
+// Words returns the distinct searchable words of a name: its comparison key (names.Key) split on spaces, with
+// punctuation trimmed from each word.
+func Words(name string) []string {
+	var words []string
+	seen := make(map[string]struct{})
+	for _, w := range strings.Fields(names.Key(name)) {
+		w = strings.TrimFunc(w, unicode.IsPunct)
+		if _, ok := seen[w]; ok || w == "" {
+			continue
+		}
+		seen[w] = struct{}{}
+		words = append(words, w)
+	}
+	return words
+}
+
+// indexTerms returns the words of a name indexed for prefix matching: its Words and, for words in Devanagari,
+// their transliteration to Latin letters.
+func indexTerms(name string) []string {
+	terms := Words(name)
+	for _, w := range terms {
+		if latin := names.Transliterate(w); latin != w && !slices.Contains(terms, latin) {
+			terms = append(terms, latin)
+		}
+	}
+	return terms
+}
+
+// phoneticKeys returns the distinct phonetic keys of the words of a name.
+func phoneticKeys(name string) []string {
+	var keys []string
+	for _, w := range Words(name) {
+		if key := names.PhoneticKey(w); key != "" && !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,66 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+func TestIndex_Search(t *testing.T) {
+	ix := NewIndex()
+	ix.Put(models.SearchResult{PhoneNumber: "919000000001", Name: "Rahul Sharma", Uploaders: 3})
+	ix.Put(models.SearchResult{PhoneNumber: "919000000002", Name: "Rahul Verma", Uploaders: 7})
+	ix.Put(models.SearchResult{PhoneNumber: "919000000003", Name: "Dr. Priya Sharma", Uploaders: 3})
+	ix.Put(models.SearchResult{PhoneNumber: "919000000004", Name: "राहुल शर्मा", Uploaders: 1})
+	ix.Put(models.SearchResult{PhoneNumber: "919000000005", Name: "Mohammed Khan", Uploaders: 2})
+	ix.Put(models.SearchResult{PhoneNumber: "919000000006", Name: "Muhammad Ali", Uploaders: 9})
+	ix.Put(models.SearchResult{PhoneNumber: "919000000007", Name: "Sonal Gupta", Uploaders: 1})
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{name: "token query ranked by uploaders", query: "rahul", want: []string{"919000000002", "919000000001", "919000000004"}},
+		{name: "prefix query", query: "shar", want: []string{"919000000003", "919000000001", "919000000004"}},
+		{name: "every word must match", query: "RAH sh", want: []string{"919000000001", "919000000004"}},
+		{name: "punctuation ignored", query: "dr", want: []string{"919000000003"}},
+		{name: "devanagari query finds latin names", query: "राहुल", want: []string{"919000000002", "919000000001", "919000000004"}},
+		{name: "latin query finds devanagari names", query: "rahul शर्मा", want: []string{"919000000001", "919000000004"}},
+		{name: "sound-alike ranked after direct match", query: "muhammad", want: []string{"919000000006", "919000000005"}},
+		{name: "typo tolerated", query: "mohamed khan", want: []string{"919000000005"}},
+		{name: "typo in a longer word", query: "sharmaa", want: []string{"919000000003", "919000000001", "919000000004"}},
+		{name: "different vowels do not sound alike", query: "sunil", want: nil},
+		{name: "no match", query: "rahul kumar", want: nil},
+		{name: "empty query", query: " 🙂 ", want: nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []string
+			for _, r := range ix.Search(tc.query) {
+				got = append(got, r.PhoneNumber)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Search(%q) = %v, want %v", tc.query, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIndex_PutRemove(t *testing.T) {
+	ix := NewIndex()
+	ix.Put(models.SearchResult{PhoneNumber: "919000000001", Name: "Rahul Sharma"})
+	ix.Put(models.SearchResult{PhoneNumber: "919000000001", Name: "Robert"})
+	if got := ix.Search("rahul"); len(got) != 0 {
+		t.Errorf("expected the old name to be unindexed, got %+v", got)
+	}
+	if got := ix.Search("rob"); len(got) != 1 || got[0].Name != "Robert" {
+		t.Errorf("expected the new name to be indexed, got %+v", got)
+	}
+	ix.Remove("919000000001")
+	ix.Remove("919000000001")
+	if ix.Len() != 0 || len(ix.words) != 0 || len(ix.postings) != 0 || len(ix.byLength) != 0 || len(ix.phonetic) != 0 {
+		t.Errorf("expected an empty index, got %d docs, words %v", ix.Len(), ix.words)
+	}
+}
@@ -0,0 +1,109 @@
+package search
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao"
+	"github.com/yourusername/truecaller-lite/pkg/dao/daoerrors"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+// IndexedUserDAO is a dao.UserDAO decorator that keeps an Index in step with every write to the wrapped DAO.
+// Each user is indexed under their preferred name (models.User.PreferredName), with the number of phone books
+// holding their number as uploader count.
+type IndexedUserDAO struct {
+	dao.UserDAO
+	phoneBookDAO dao.PhoneBookDAO
+	index        *Index
+}
+
+// NewIndexedUserDAO wraps userDAO so that its writes update index. Uploader counts are read from phoneBookDAO.
+func NewIndexedUserDAO(userDAO dao.UserDAO, phoneBookDAO dao.PhoneBookDAO, index *Index) *IndexedUserDAO {
+	return &IndexedUserDAO{UserDAO: userDAO, phoneBookDAO: phoneBookDAO, index: index}
+}
+
+// CreateOrUpdateUser creates or updates a user and indexes their name.
+func (d *IndexedUserDAO) CreateOrUpdateUser(ctx context.Context, user *models.User) error {
+	if err := d.UserDAO.CreateOrUpdateUser(ctx, user); err != nil {
+		return err
+	}
+	return d.put(ctx, user)
+}
+
+// UpdateSpamStatus updates a user's spam status and the indexed entry.
+func (d *IndexedUserDAO) UpdateSpamStatus(ctx context.Context, phoneNumber string, isSpam bool) error {
+	if err := d.UserDAO.UpdateSpamStatus(ctx, phoneNumber, isSpam); err != nil {
+		return err
+	}
+	return d.reindex(ctx, phoneNumber)
+}
+
+// UpdateName updates a user's crowd-sourced name and the indexed entry.
+func (d *IndexedUserDAO) UpdateName(ctx context.Context, phoneNumber, name string) error {
+	if err := d.UserDAO.UpdateName(ctx, phoneNumber, name); err != nil {
+		return err
+	}
+	return d.reindex(ctx, phoneNumber)
+}
+
+// UpdateDisplayName updates a user's display name and the indexed entry.
+func (d *IndexedUserDAO) UpdateDisplayName(ctx context.Context, phoneNumber, displayName string) error {
+	if err := d.UserDAO.UpdateDisplayName(ctx, phoneNumber, displayName); err != nil {
+		return err
+	}
+	return d.reindex(ctx, phoneNumber)
+}
+
+// DeleteUser deletes a user and removes them from the index.
+func (d *IndexedUserDAO) DeleteUser(ctx context.Context, phoneNumber string) error {
+	err := d.UserDAO.DeleteUser(ctx, phoneNumber)
+	if err == nil || errors.Is(err, daoerrors.ErrUserNotFound) {
+		d.index.Remove(phoneNumber)
+	}
+	return err
+}
+
+// Reindex rebuilds the index entries of every stored user, e.g. after the index was created over existing data.
+func (d *IndexedUserDAO) Reindex(ctx context.Context) error {
+	users, err := d.UserDAO.GetAllUsers(ctx)
+	if err != nil {
+		return err
+	}
+	for _, user := range users {
+		if err := d.put(ctx, user); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reindex re-reads the stored user for phoneNumber and updates or removes their index entry.
+func (d *IndexedUserDAO) reindex(ctx context.Context, phoneNumber string) error {
+	user, err := d.UserDAO.GetUserByPhoneNumber(ctx, phoneNumber)
+	if errors.Is(err, daoerrors.ErrUserNotFound) {
+		d.index.Remove(phoneNumber)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return d.put(ctx, user)
+}
+
+// put indexes user with the current uploader count of their number.
+func (d *IndexedUserDAO) put(ctx context.Context, user *models.User) error {
+	entries, err := d.phoneBookDAO.GetContactEntriesByPhoneNumber(ctx, user.GetPhoneNumber())
+	if err != nil {
+		return err
+	}
+	d.index.Put(models.SearchResult{
+		PhoneNumber: user.GetPhoneNumber(),
+		Name:        user.PreferredName(),
+		IsSpam:      user.GetIsSpam(),
+		Uploaders:   len(entries),
+	})
+	return nil
+}
+
+var _ dao.UserDAO = (*IndexedUserDAO)(nil)
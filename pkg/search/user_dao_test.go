@@ -0,0 +1,71 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao/mem"
+	"github.com/yourusername/truecaller-lite/pkg/dao/mock"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+func TestIndexedUserDAO(t *testing.T) {
+	ctx := context.Background()
+	userDAO, phoneBookDAO, index := mem.NewUserMemDAO(), mem.NewPhoneBookMemDAO(), NewIndex()
+	bob := "919123456789"
+	_ = userDAO.CreateOrUpdateUser(ctx, &models.User{PhoneNumber: "919000000001", Name: "Existing User"})
+	_ = phoneBookDAO.CreateOrUpdatePhoneBook(ctx, &models.PhoneBook{PhoneNumber: "919876543210", Contacts: []models.Contact{{PhoneNumber: bob, Name: "Bob"}}})
+	_ = phoneBookDAO.CreateOrUpdatePhoneBook(ctx, &models.PhoneBook{PhoneNumber: "919876543211", Contacts: []models.Contact{{PhoneNumber: bob, Name: "Bobby"}}})
+	d := NewIndexedUserDAO(userDAO, phoneBookDAO, index)
+
+	if err := d.Reindex(ctx); err != nil || len(index.Search("existing")) != 1 {
+		t.Fatalf("expected existing users to be indexed, got %v", err)
+	}
+	if err := d.CreateOrUpdateUser(ctx, &models.User{PhoneNumber: bob, Name: "Bob"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := index.Search("bob"); len(got) != 1 || got[0].Uploaders != 2 {
+		t.Errorf("expected Bob with 2 uploaders, got %+v", got)
+	}
+	// The preferred name is indexed
+	_ = d.CreateOrUpdateUser(ctx, &models.User{PhoneNumber: bob, Name: "Bob", DisplayName: "Robert Fernandes"})
+	if got := index.Search("bob"); len(got) != 0 {
+		t.Errorf("expected the crowd name to be replaced by the display name, got %+v", got)
+	}
+	if err := d.UpdateSpamStatus(ctx, bob, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := index.Search("fernandes"); len(got) != 1 || !got[0].IsSpam {
+		t.Errorf("expected spam status to be indexed, got %+v", got)
+	}
+	if err := d.UpdateDisplayName(ctx, bob, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := index.Search("bob"); len(got) != 1 || !got[0].IsSpam {
+		t.Errorf("expected the crowd name to be indexed again, got %+v", got)
+	}
+	_ = d.UpdateDisplayName(ctx, bob, "Robert Fernandes")
+	if err := d.DeleteUser(ctx, bob); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := index.Search("robert"); len(got) != 0 {
+		t.Errorf("expected deleted user to be unindexed, got %+v", got)
+	}
+	_ = d.UpdateName(ctx, bob, "Bob")
+	if err := d.UpdateName(ctx, bob, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := index.Search("bob"); len(got) != 0 {
+		t.Errorf("expected a user without names to be unindexed, got %+v", got)
+	}
+
+	// Failed writes leave the index alone
+	failing := NewIndexedUserDAO(&mock.UserDAOMock{OnCreateOrUpdateUser: func(context.Context, *models.User) error { return errors.New("boom") }}, phoneBookDAO, index)
+	if err := failing.CreateOrUpdateUser(ctx, &models.User{PhoneNumber: bob, Name: "Bob"}); err == nil {
+		t.Error("expected error, got nil")
+	}
+	if got := index.Search("bob"); len(got) != 0 {
+		t.Errorf("expected nothing indexed after a failed write, got %+v", got)
+	}
+}
@@ -0,0 +1,12 @@
+package service
+
+import "errors"
+
+// ErrRequestLimitExceeded is returned when a single upload contains more contacts than allowed.
+var ErrRequestLimitExceeded = errors.New("upload exceeds per-request contact limit")
+
+// ErrOwnerLimitExceeded is returned when an upload would grow a phone book beyond the per-owner limit.
+var ErrOwnerLimitExceeded = errors.New("phone book exceeds per-owner contact limit")
+
+// ErrUnlisted is returned by lookups of a phone number whose owner has opted out of caller ID.
+var ErrUnlisted = errors.New("phone number is unlisted")
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao"
+	"github.com/yourusername/truecaller-lite/pkg/dao/daoerrors"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+// Defaults applied to zero LookupLogOptions fields.
+const (
+	DefaultLookupLogRetention    = 30 * 24 * time.Hour
+	DefaultLookupLogMaxPerNumber = 1000
+	DefaultViewerPageSize        = 20
+	MaxViewerPageSize            = 100
+)
+
+// LookupLogOptions configures the retention of the lookup log.
+type LookupLogOptions struct {
+	// Retention is how long lookups are kept; PruneLookupLog removes older ones.
+	Retention time.Duration
+	// MaxPerNumber is how many of the most recent lookups of one number are kept.
+	MaxPerNumber int
+	// Now returns the current time; time.Now if nil. Intended for tests.
+	Now func() time.Time
+}
+
+// LookupLogService defines the business logic contract for the "who viewed me" feature.
+// All methods accept a context for timeouts and cancellations, and return errors for validation or business rule violations.
+type LookupLogService interface {
+	// RecordLookup logs that a verified user looked up a phone number. Looking up one's own number is not logged.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   requesterPhoneNumber: the verified phone number of the user making the lookup
+	//   phoneNumber: the looked-up phone number
+	// Returns:
+	//   error: if validation fails or storage error occurs
+	// Example:
+	//   err := service.RecordLookup(ctx, "919123456789", "919876543210")
+	RecordLookup(ctx context.Context, requesterPhoneNumber, phoneNumber string) error
+
+	// ListViewers returns a page of the users who looked up a phone number, most recent first, within the
+	// retention period. Callers must ensure the request comes from the verified owner of the number.
+	// Each viewer appears once, with their most recent lookup. Viewers who opted out or are unlisted are left out,
+	// so an unlisted number is not revealed to the numbers it looks up.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   phoneNumber: the owner's phone number
+	//   offset: the number of viewers to skip
+	//   limit: the page size (DefaultViewerPageSize if <= 0, at most MaxViewerPageSize)
+	// Returns:
+	//   page: the viewers on the page and pagination details
+	//   error: if validation fails or storage error occurs
+	// Example:
+	//   page, err := service.ListViewers(ctx, "919876543210", 0, 20)
+	ListViewers(ctx context.Context, phoneNumber string, offset, limit int) (*models.ViewerPage, error)
+
+	// SetOptOut hides (optOut true) or shows a phone number's lookups in other users' viewer lists. Hiding
+	// applies to past lookups too.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   phoneNumber: the owner's phone number
+	//   optOut: true to hide, false to show
+	// Returns:
+	//   error: if validation fails or storage error occurs
+	// Example:
+	//   err := service.SetOptOut(ctx, "919123456789", true)
+	SetOptOut(ctx context.Context, phoneNumber string, optOut bool) error
+
+	// PruneLookupLog removes lookups older than the retention period. Intended for a periodic job.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	// Returns:
+	//   deleted: the number of lookups removed
+	//   error: if storage error occurs
+	// Example:
+	//   deleted, err := service.PruneLookupLog(ctx)
+	PruneLookupLog(ctx context.Context) (int, error)
+}
+
+// Error handling pattern: All methods return error for validation, storage or business rule errors. Use errors.Is for type checks.
+
+// lookupLogService implements LookupLogService interface.
+type lookupLogService struct {
+	lookupLogDAO dao.LookupLogDAO
+	userDAO      dao.UserDAO
+	privacyDAO   dao.PrivacyDAO
+	opts         LookupLogOptions
+}
+
+// NewLookupLogService creates a new LookupLogService instance.
+func NewLookupLogService(lookupLogDAO dao.LookupLogDAO, userDAO dao.UserDAO, privacyDAO dao.PrivacyDAO, opts LookupLogOptions) LookupLogService {
+	if opts.Retention <= 0 {
+		opts.Retention = DefaultLookupLogRetention
+	}
+	if opts.MaxPerNumber <= 0 {
+		opts.MaxPerNumber = DefaultLookupLogMaxPerNumber
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return &lookupLogService{lookupLogDAO: lookupLogDAO, userDAO: userDAO, privacyDAO: privacyDAO, opts: opts}
+}
+
+// RecordLookup logs a lookup and trims the number's log to MaxPerNumber entries.
+func (s *lookupLogService) RecordLookup(ctx context.Context, requesterPhoneNumber, phoneNumber string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if requesterPhoneNumber == phoneNumber {
+		return nil
+	}
+	record := &models.LookupRecord{RequesterPhoneNumber: requesterPhoneNumber, PhoneNumber: phoneNumber, LookedUpAt: s.opts.Now().UTC()}
+	if err := s.lookupLogDAO.AddLookup(ctx, record); err != nil {
+		return err
+	}
+	return s.lookupLogDAO.TrimLookups(ctx, phoneNumber, s.opts.MaxPerNumber)
+}
+
+// ListViewers returns a page of the users who looked up a phone number.
+func (s *lookupLogService) ListViewers(ctx context.Context, phoneNumber string, offset, limit int) (*models.ViewerPage, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err := (&models.PhoneBook{PhoneNumber: phoneNumber}).Validate(); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = DefaultViewerPageSize
+	}
+	limit = min(limit, MaxViewerPageSize)
+	offset = max(offset, 0)
+
+	records, err := s.lookupLogDAO.GetLookupsByPhoneNumber(ctx, phoneNumber)
+	if err != nil {
+		return nil, err
+	}
+	visible, err := visibleLookups(ctx, s.privacyDAO, records, s.opts.Now().Add(-s.opts.Retention))
+	if err != nil {
+		return nil, err
+	}
+	visible = latestPerRequester(visible)
+	page := &models.ViewerPage{Viewers: []models.Viewer{}, Total: len(visible), Offset: offset, Limit: limit}
+	if offset >= len(visible) {
+		return page, nil
+	}
+	end := min(offset+limit, len(visible))
+	page.HasMore = end < len(visible)
+	for _, r := range visible[offset:end] {
+		name, err := s.viewerName(ctx, r.RequesterPhoneNumber)
+		if err != nil {
+			return nil, err
+		}
+		page.Viewers = append(page.Viewers, models.Viewer{PhoneNumber: r.RequesterPhoneNumber, Name: name, ViewedAt: r.LookedUpAt})
+	}
+	return page, nil
+}
+
+// viewerName returns the name a lookup of the viewer would show (their display name if set, else the resolved
+// name), or "" if unknown.
+func (s *lookupLogService) viewerName(ctx context.Context, phoneNumber string) (string, error) {
+	user, err := s.userDAO.GetUserByPhoneNumber(ctx, phoneNumber)
+	if errors.Is(err, daoerrors.ErrUserNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return user.PreferredName(), nil
+}
+
+// SetOptOut hides or shows a phone number's lookups in other users' viewer lists.
+func (s *lookupLogService) SetOptOut(ctx context.Context, phoneNumber string, optOut bool) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err := (&models.PhoneBook{PhoneNumber: phoneNumber}).Validate(); err != nil {
+		return err
+	}
+	return s.privacyDAO.SetLookupLogOptOut(ctx, phoneNumber, optOut)
+}
+
+// PruneLookupLog removes lookups older than the retention period.
+func (s *lookupLogService) PruneLookupLog(ctx context.Context) (int, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+	return s.lookupLogDAO.DeleteLookupsBefore(ctx, s.opts.Now().Add(-s.opts.Retention))
+}
+
+// visibleLookups returns the records made at or after cutoff by requesters who have neither opted out nor are
+// unlisted.
+func visibleLookups(ctx context.Context, privacyDAO dao.PrivacyDAO, records []*models.LookupRecord, cutoff time.Time) ([]*models.LookupRecord, error) {
+	hiddenRequesters := make(map[string]bool)
+	var result []*models.LookupRecord
+	for _, r := range records {
+		if r.LookedUpAt.Before(cutoff) {
+			continue
+		}
+		hidden, ok := hiddenRequesters[r.RequesterPhoneNumber]
+		if !ok {
+			var err error
+			if hidden, err = privacyDAO.IsLookupLogOptOut(ctx, r.RequesterPhoneNumber); err != nil {
+				return nil, err
+			}
+			if !hidden {
+				if hidden, err = privacyDAO.IsUnlisted(ctx, r.RequesterPhoneNumber); err != nil {
+					return nil, err
+				}
+			}
+			hiddenRequesters[r.RequesterPhoneNumber] = hidden
+		}
+		if !hidden {
+			result = append(result, r)
+		}
+	}
+	return result, nil
+}
+
+// latestPerRequester keeps the first record of each requester in records, which are ordered most recent first.
+func latestPerRequester(records []*models.LookupRecord) []*models.LookupRecord {
+	seen := make(map[string]bool, len(records))
+	result := records[:0:0]
+	for _, r := range records {
+		if seen[r.RequesterPhoneNumber] {
+			continue
+		}
+		seen[r.RequesterPhoneNumber] = true
+		result = append(result, r)
+	}
+	return result
+}
+
+var _ LookupLogService = (*lookupLogService)(nil)
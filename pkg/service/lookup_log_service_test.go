@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao/mem"
+	"github.com/yourusername/truecaller-lite/pkg/dao/mock"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+func TestLookupLogService_ListViewers(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	userDAO, privacyDAO, lookupLogDAO := mem.NewUserMemDAO(), mem.NewPrivacyMemDAO(), mem.NewLookupLogMemDAO()
+	svc := NewLookupLogService(lookupLogDAO, userDAO, privacyDAO, LookupLogOptions{Retention: 24 * time.Hour, Now: func() time.Time { return now }})
+	owner, bob, carol, dave := "919876543210", "919123456789", "919123456780", "919123456781"
+	_ = userDAO.CreateOrUpdateUser(ctx, &models.User{PhoneNumber: bob, Name: "Bob", DisplayName: "Robert"})
+	_ = userDAO.CreateOrUpdateUser(ctx, &models.User{PhoneNumber: dave, Name: "Dave"})
+	_ = privacyDAO.SetUnlisted(ctx, dave, true)
+
+	for _, requester := range []string{bob, owner, carol, dave, bob} {
+		now = now.Add(time.Minute)
+		if err := svc.RecordLookup(ctx, requester, owner); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		optOut      []string
+		advance     time.Duration
+		offset      int
+		limit       int
+		wantViewers []models.Viewer
+		wantTotal   int
+		wantHasMore bool
+	}{
+		{
+			name:        "first page",
+			limit:       1,
+			offset:      0,
+			wantViewers: []models.Viewer{{PhoneNumber: bob, Name: "Robert", ViewedAt: now}},
+			wantTotal:   2,
+			wantHasMore: true,
+		},
+		{
+			name:        "repeat viewer appears once with the latest lookup",
+			limit:       2,
+			offset:      1,
+			wantViewers: []models.Viewer{{PhoneNumber: carol, ViewedAt: now.Add(-2 * time.Minute)}},
+			wantTotal:   2,
+		},
+		{
+			name:        "unlisted viewer is hidden",
+			wantViewers: []models.Viewer{{PhoneNumber: bob, Name: "Robert", ViewedAt: now}, {PhoneNumber: carol, ViewedAt: now.Add(-2 * time.Minute)}},
+			wantTotal:   2,
+		},
+		{
+			name:        "opted-out viewer is hidden",
+			optOut:      []string{bob},
+			wantViewers: []models.Viewer{{PhoneNumber: carol, ViewedAt: now.Add(-2 * time.Minute)}},
+			wantTotal:   1,
+		},
+		{
+			name:        "lookups past retention are hidden",
+			advance:     24*time.Hour - 90*time.Second,
+			wantViewers: []models.Viewer{{PhoneNumber: bob, Name: "Robert", ViewedAt: now}},
+			wantTotal:   1,
+		},
+		{
+			name:        "offset past the end",
+			offset:      10,
+			wantViewers: []models.Viewer{},
+			wantTotal:   2,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, phone := range tc.optOut {
+				_ = svc.SetOptOut(ctx, phone, true)
+				defer svc.SetOptOut(ctx, phone, false)
+			}
+			saved := now
+			now = now.Add(tc.advance)
+			defer func() { now = saved }()
+			page, err := svc.ListViewers(ctx, owner, tc.offset, tc.limit)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if page.Total != tc.wantTotal || page.HasMore != tc.wantHasMore || len(page.Viewers) != len(tc.wantViewers) {
+				t.Fatalf("unexpected page %+v", page)
+			}
+			for i, want := range tc.wantViewers {
+				if got := page.Viewers[i]; got.PhoneNumber != want.PhoneNumber || got.Name != want.Name || !got.ViewedAt.Equal(want.ViewedAt) {
+					t.Errorf("viewer %d: expected %+v, got %+v", i, want, got)
+				}
+			}
+		})
+	}
+
+	if _, err := svc.ListViewers(ctx, "123", 0, 0); err == nil {
+		t.Error("expected validation error, got nil")
+	}
+}
+
+func TestLookupLogService_Retention(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	lookupLogDAO := mem.NewLookupLogMemDAO()
+	svc := NewLookupLogService(lookupLogDAO, mem.NewUserMemDAO(), mem.NewPrivacyMemDAO(), LookupLogOptions{Retention: time.Hour, MaxPerNumber: 2, Now: func() time.Time { return now }})
+	for _, requester := range []string{"919123456780", "919123456781", "919123456782"} {
+		now = now.Add(time.Minute)
+		_ = svc.RecordLookup(ctx, requester, "919876543210")
+	}
+	if records, _ := lookupLogDAO.GetLookupsByPhoneNumber(ctx, "919876543210"); len(records) != 2 || records[1].RequesterPhoneNumber != "919123456781" {
+		t.Fatalf("expected the 2 most recent lookups to be kept, got %+v", records)
+	}
+	now = now.Add(time.Hour - 30*time.Second)
+	deleted, err := svc.PruneLookupLog(ctx)
+	if err != nil || deleted != 1 {
+		t.Errorf("expected 1 expired lookup pruned, got %d (%v)", deleted, err)
+	}
+}
+
+func TestLookupLogService_RecordLookup_Errors(t *testing.T) {
+	daoErr := errors.New("dao error")
+	lookupLogDAO := &mock.LookupLogDAOMock{OnAddLookup: func(ctx context.Context, r *models.LookupRecord) error { return daoErr }}
+	svc := NewLookupLogService(lookupLogDAO, &mock.UserDAOMock{}, &mock.PrivacyDAOMock{}, LookupLogOptions{})
+	if err := svc.RecordLookup(context.Background(), "919123456789", "919876543210"); !errors.Is(err, daoErr) {
+		t.Errorf("expected DAO error, got %v", err)
+	}
+	if err := svc.RecordLookup(context.Background(), "919876543210", "919876543210"); err != nil {
+		t.Errorf("expected self-lookup to be ignored, got %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := svc.RecordLookup(ctx, "919123456789", "919876543210"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao"
+	"github.com/yourusername/truecaller-lite/pkg/dao/daoerrors"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+	"github.com/yourusername/truecaller-lite/pkg/moderation"
+	"github.com/yourusername/truecaller-lite/pkg/names"
+)
+
+// nameResolver maintains the crowd-sourced User records returned by lookups.
+// A number's name is derived from every phone book that holds it: the most recently saved name wins, compared
+// and returned in normalized form (see package names). Entries whose name normalizes to nothing or is a
+// relationship word or placeholder in the lexicon ("Mom", "Unknown") are ignored. Names flagged by the moderation
+// filter are never chosen; they are recorded as the number's "name suppressed" state for moderator review.
+// The owner's display name is never touched by resolution.
+type nameResolver struct {
+	userDAO       dao.UserDAO
+	phoneBookDAO  dao.PhoneBookDAO
+	privacyDAO    dao.PrivacyDAO    // optional; nil disables erasure tombstones
+	lexicon       *names.Lexicon    // optional; nil keeps relationship words and placeholders
+	filter        moderation.Filter // optional; nil lets every name through
+	moderationDAO dao.ModerationDAO // optional; nil does not record suppressed names
+}
+
+// resolve recomputes the User record for phoneNumber from the current phone books.
+// Phone books are ignored once the number has been erased. The record is removed when it has neither a crowd
+// name nor a display name; the display name and spam status of an existing record are preserved. Only the name
+// is written, so concurrent display name and spam status updates are not lost.
+func (r *nameResolver) resolve(ctx context.Context, phoneNumber string) error {
+	entries, err := r.entries(ctx, phoneNumber)
+	if err != nil {
+		return err
+	}
+	if err := r.recordSuppressed(ctx, phoneNumber, entries); err != nil {
+		return err
+	}
+	return r.userDAO.UpdateName(ctx, phoneNumber, r.mostRecentName(entries))
+}
+
+// recordSuppressed saves the offensive names among entries as the number's moderation state, or deletes the
+// state if there are none.
+func (r *nameResolver) recordSuppressed(ctx context.Context, phoneNumber string, entries []models.ContactEntry) error {
+	if r.moderationDAO == nil {
+		return nil
+	}
+	var suppressed []models.SuppressedName
+	for _, e := range entries {
+		if r.offensive(e.Contact) {
+			suppressed = append(suppressed, models.SuppressedName{Name: e.Contact.GetName(), UploaderPhoneNumber: e.OwnerPhoneNumber, SavedAt: e.SavedAt})
+		}
+	}
+	if len(suppressed) == 0 {
+		return r.moderationDAO.DeleteNameModeration(ctx, phoneNumber)
+	}
+	sort.SliceStable(suppressed, func(i, j int) bool { return suppressed[i].SavedAt.After(suppressed[j].SavedAt) })
+	return r.moderationDAO.SaveNameModeration(ctx, &models.NameModeration{PhoneNumber: phoneNumber, Suppressed: suppressed, UpdatedAt: time.Now().UTC()})
+}
+
+// offensive reports whether the moderation filter flags the contact's name.
+func (r *nameResolver) offensive(c models.Contact) bool {
+	return r.filter != nil && r.filter.Offensive(c.GetName())
+}
+
+// entries returns the phone book entries that hold phoneNumber, or none if the number has been erased.
+func (r *nameResolver) entries(ctx context.Context, phoneNumber string) ([]models.ContactEntry, error) {
+	if r.privacyDAO != nil {
+		erased, err := r.privacyDAO.IsTombstoned(ctx, phoneNumber)
+		if err != nil || erased {
+			return nil, err
+		}
+	}
+	return r.phoneBookDAO.GetContactEntriesByPhoneNumber(ctx, phoneNumber)
+}
+
+// resolveAll resolves every distinct phone number in contacts.
+func (r *nameResolver) resolveAll(ctx context.Context, contacts []models.Contact) error {
+	seen := make(map[string]struct{}, len(contacts))
+	for _, c := range contacts {
+		if _, ok := seen[c.GetPhoneNumber()]; ok {
+			continue
+		}
+		seen[c.GetPhoneNumber()] = struct{}{}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := r.resolve(ctx, c.GetPhoneNumber()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// erase deletes the User record and moderation state of phoneNumber.
+func (r *nameResolver) erase(ctx context.Context, phoneNumber string) error {
+	if err := r.remove(ctx, phoneNumber); err != nil {
+		return err
+	}
+	if r.moderationDAO != nil {
+		return r.moderationDAO.DeleteNameModeration(ctx, phoneNumber)
+	}
+	return nil
+}
+
+// remove deletes the User record for phoneNumber if there is one.
+func (r *nameResolver) remove(ctx context.Context, phoneNumber string) error {
+	if err := r.userDAO.DeleteUser(ctx, phoneNumber); err != nil && !errors.Is(err, daoerrors.ErrUserNotFound) {
+		return err
+	}
+	return nil
+}
+
+// mostRecentName returns the crowd name from the most recently saved entry that has one; ties keep the earlier
+// entry. Returns empty string if no entry has a name.
+func (r *nameResolver) mostRecentName(entries []models.ContactEntry) string {
+	var best *models.ContactEntry
+	for i, e := range entries {
+		if r.crowdName(e.Contact) == "" {
+			continue
+		}
+		if best == nil || e.SavedAt.After(best.SavedAt) {
+			best = &entries[i]
+		}
+	}
+	if best == nil {
+		return ""
+	}
+	return r.crowdName(best.Contact)
+}
+
+// crowdName returns the name a contact contributes to resolution: its normalized name, computed from the raw name
+// for contacts stored before normalization existed. Returns empty string for names in the lexicon and offensive names.
+func (r *nameResolver) crowdName(c models.Contact) string {
+	if r.offensive(c) {
+		return ""
+	}
+	name := c.GetNormalizedName()
+	if name == "" {
+		name = names.Normalize(c.GetName())
+	}
+	if r.lexicon.Contains(name) {
+		return ""
+	}
+	return name
+}
+
+// nameCandidates groups entries by name and returns up to n groups, ordered by distinct uploaders, then by most
+// recent use, then by name. Spellings of the same name are grouped, under their most recent normalized spelling:
+// names with the same phonetic key (names.PhoneticKey, which also covers Devanagari and Latin forms of a name) or
+// with a typo or two in some of their words (names.Similar). Entries without a crowd name are ignored.
+func (r *nameResolver) nameCandidates(entries []models.ContactEntry, n int) []models.NameCandidate {
+	type group struct {
+		name      string // the name that started the group
+		key       string
+		latest    models.ContactEntry
+		uploaders map[string]struct{}
+	}
+	var groups []*group
+	for _, e := range entries {
+		name := r.crowdName(e.Contact)
+		if name == "" {
+			continue
+		}
+		key := names.PhoneticKey(name)
+		var g *group
+		for _, candidate := range groups {
+			if candidate.key == key || names.Similar(candidate.name, name) {
+				g = candidate
+				break
+			}
+		}
+		if g == nil {
+			g = &group{name: name, key: key, latest: e, uploaders: make(map[string]struct{})}
+			groups = append(groups, g)
+		} else if e.SavedAt.After(g.latest.SavedAt) {
+			g.latest = e
+		}
+		g.uploaders[e.OwnerPhoneNumber] = struct{}{}
+	}
+	sorted := groups
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if len(a.uploaders) != len(b.uploaders) {
+			return len(a.uploaders) > len(b.uploaders)
+		}
+		if !a.latest.SavedAt.Equal(b.latest.SavedAt) {
+			return a.latest.SavedAt.After(b.latest.SavedAt)
+		}
+		return r.crowdName(a.latest.Contact) < r.crowdName(b.latest.Contact)
+	})
+	candidates := make([]models.NameCandidate, 0, min(n, len(sorted)))
+	for _, g := range sorted[:min(n, len(sorted))] {
+		candidates = append(candidates, models.NameCandidate{Name: r.crowdName(g.latest.Contact), Uploaders: len(g.uploaders)})
+	}
+	return candidates
+}
@@ -0,0 +1,371 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao/daoerrors"
+	"github.com/yourusername/truecaller-lite/pkg/dao/mem"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+	"github.com/yourusername/truecaller-lite/pkg/names"
+)
+
+func TestUserService_UploadResolvesNames(t *testing.T) {
+	ctx := context.Background()
+	userDAO, phoneBookDAO, privacyDAO := mem.NewUserMemDAO(), mem.NewPhoneBookMemDAO(), mem.NewPrivacyMemDAO()
+	svc := NewUserService(userDAO, phoneBookDAO, WithPrivacyDAO(privacyDAO))
+	bob := "919123456789"
+
+	lookup := func() (string, error) {
+		name, _, err := svc.LookupUser(ctx, bob)
+		return name, err
+	}
+
+	if err := svc.UploadContacts(ctx, "919876543210", []models.Contact{{PhoneNumber: bob, Name: "Bob"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name, err := lookup(); err != nil || name != "Bob" {
+		t.Fatalf("expected Bob, got %q (%v)", name, err)
+	}
+
+	// The most recent upload wins, and spam status is preserved across resolutions
+	_ = userDAO.UpdateSpamStatus(ctx, bob, true)
+	if _, err := svc.UploadContactsPartial(ctx, "919876543211", []models.Contact{{PhoneNumber: bob, Name: "Robert"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name, _ := lookup(); name != "Robert" {
+		t.Errorf("expected Robert, got %q", name)
+	}
+	if _, isSpam, _ := svc.LookupUser(ctx, bob); !isSpam {
+		t.Error("expected spam status to be preserved")
+	}
+
+	// Removing a contact retracts that uploader's name
+	if err := svc.UploadContacts(ctx, "919876543211", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name, _ := lookup(); name != "Bob" {
+		t.Errorf("expected Bob after retraction, got %q", name)
+	}
+	if err := svc.UploadContacts(ctx, "919876543210", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name, isSpam, err := svc.LookupUser(ctx, bob); err != nil || name != "" || !isSpam {
+		t.Errorf("expected the spam flag to outlive the names, got %q, %v (%v)", name, isSpam, err)
+	}
+
+	// Erased numbers are never resolved again
+	_ = privacyDAO.CreateTombstone(ctx, bob, time.Now())
+	if err := svc.UploadContacts(ctx, "919876543212", []models.Contact{{PhoneNumber: bob, Name: "Bob"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name, err := lookup(); err != nil || name != "" {
+		t.Errorf("expected erased number to stay unresolved, got %q (%v)", name, err)
+	}
+}
+
+func TestUserService_SetDisplayName(t *testing.T) {
+	ctx := context.Background()
+	userDAO, phoneBookDAO, privacyDAO := mem.NewUserMemDAO(), mem.NewPhoneBookMemDAO(), mem.NewPrivacyMemDAO()
+	svc := NewUserService(userDAO, phoneBookDAO, WithPrivacyDAO(privacyDAO))
+	ramesh, uploader := "919876543210", "919123456789"
+	lookup := func() string {
+		name, _, _ := svc.LookupUser(ctx, ramesh)
+		return name
+	}
+
+	_ = svc.UploadContacts(ctx, uploader, []models.Contact{{PhoneNumber: ramesh, Name: "Plumber Ramesh"}})
+	if err := svc.SetDisplayName(ctx, ramesh, "Ramesh Kumar"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := lookup(); got != "Ramesh Kumar" {
+		t.Errorf("expected display name to win, got %q", got)
+	}
+	if user, _ := userDAO.GetUserByPhoneNumber(ctx, ramesh); user.GetName() != "Plumber Ramesh" {
+		t.Errorf("expected crowd name to be kept, got %+v", user)
+	}
+
+	// Later uploads update the crowd name but not the display name
+	_ = svc.UploadContacts(ctx, uploader, []models.Contact{{PhoneNumber: ramesh, Name: "Ramesh Plumbing"}})
+	if user, _ := userDAO.GetUserByPhoneNumber(ctx, ramesh); user.GetDisplayName() != "Ramesh Kumar" || user.GetName() != "Ramesh Plumbing" {
+		t.Errorf("expected display name preserved and crowd name updated, got %+v", user)
+	}
+
+	// A display name keeps the record alive when no phone book holds the number
+	_ = svc.UploadContacts(ctx, uploader, []models.Contact{})
+	if got := lookup(); got != "Ramesh Kumar" {
+		t.Errorf("expected display-name-only record, got %q", got)
+	}
+	if err := svc.SetDisplayName(ctx, ramesh, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := userDAO.GetUserByPhoneNumber(ctx, ramesh); !errors.Is(err, daoerrors.ErrUserNotFound) {
+		t.Errorf("expected record without any name to be removed, got %v", err)
+	}
+
+	if err := svc.SetDisplayName(ctx, ramesh, "   "); err == nil {
+		t.Error("expected validation error, got nil")
+	}
+
+	// Concurrent uploads re-resolving the crowd name never drop the display name, or vice versa
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_ = svc.UploadContacts(ctx, uploader, []models.Contact{{PhoneNumber: ramesh, Name: "Plumber Ramesh"}})
+		}()
+		go func() { defer wg.Done(); _ = svc.SetDisplayName(ctx, ramesh, "Ramesh Kumar") }()
+	}
+	wg.Wait()
+	if user, _ := userDAO.GetUserByPhoneNumber(ctx, ramesh); user.GetDisplayName() != "Ramesh Kumar" || user.GetName() != "Plumber Ramesh" {
+		t.Errorf("expected both names after concurrent updates, got %+v", user)
+	}
+
+	if err := svc.SetDisplayName(ctx, "123", "Ramesh"); err == nil {
+		t.Error("expected validation error, got nil")
+	}
+}
+
+func TestUserService_LookupUserDetailed(t *testing.T) {
+	ctx := context.Background()
+	userDAO, phoneBookDAO, privacyDAO := mem.NewUserMemDAO(), mem.NewPhoneBookMemDAO(), mem.NewPrivacyMemDAO()
+	svc := NewUserService(userDAO, phoneBookDAO, WithPrivacyDAO(privacyDAO))
+	bob := "919123456789"
+	uploads := []struct{ owner, name string }{
+		{"919876543210", "Bob"},
+		{"919876543211", "Robert"},
+		{"919876543212", "bob 🙂"},
+		{"919876543213", "Bob Plumber"},
+	}
+	for _, u := range uploads {
+		if err := svc.UploadContacts(ctx, u.owner, []models.Contact{{PhoneNumber: bob, Name: u.name}}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name         string
+		alternatives int
+		want         []models.NameCandidate
+	}{
+		{name: "none requested", alternatives: 0, want: []models.NameCandidate{}},
+		{name: "top one", alternatives: 1, want: []models.NameCandidate{{Name: "bob", Uploaders: 2}}},
+		{name: "all", alternatives: 5, want: []models.NameCandidate{{Name: "bob", Uploaders: 2}, {Name: "Bob Plumber", Uploaders: 1}, {Name: "Robert", Uploaders: 1}}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := svc.LookupUserDetailed(ctx, bob, tc.alternatives)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Name != "Bob Plumber" {
+				t.Errorf("expected resolved name Bob Plumber, got %q", result.Name)
+			}
+			if !reflect.DeepEqual(result.Alternatives, tc.want) {
+				t.Errorf("expected alternatives %v, got %v", tc.want, result.Alternatives)
+			}
+		})
+	}
+
+	if _, err := svc.LookupUserDetailed(ctx, "919000000000", 3); !errors.Is(err, daoerrors.ErrUserNotFound) {
+		t.Errorf("expected ErrUserNotFound, got %v", err)
+	}
+	_ = privacyDAO.SetUnlisted(ctx, bob, true)
+	if _, err := svc.LookupUserDetailed(ctx, bob, 3); !errors.Is(err, ErrUnlisted) {
+		t.Errorf("expected ErrUnlisted, got %v", err)
+	}
+}
+
+func TestUserService_NormalizesNames(t *testing.T) {
+	ctx := context.Background()
+	userDAO, phoneBookDAO := mem.NewUserMemDAO(), mem.NewPhoneBookMemDAO()
+	svc := NewUserService(userDAO, phoneBookDAO)
+	rahul, owner := "919123456789", "919876543210"
+
+	if err := svc.UploadContacts(ctx, owner, []models.Contact{{PhoneNumber: rahul, Name: "  Rahul   (Office) 🙂"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pb, _ := phoneBookDAO.GetPhoneBookByUserPhoneNumber(ctx, owner)
+	if got := pb.Contacts[0]; got.Name != "  Rahul   (Office) 🙂" || got.NormalizedName != "Rahul" {
+		t.Errorf("expected original and normalized names to be stored, got %+v", got)
+	}
+	if name, _, _ := svc.LookupUser(ctx, rahul); name != "Rahul" {
+		t.Errorf("expected normalized name Rahul, got %q", name)
+	}
+
+	// A name with nothing left after normalization does not replace the crowd name
+	if _, err := svc.UploadContactsPartial(ctx, "919876543211", []models.Contact{{PhoneNumber: rahul, Name: "🙂🙂"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name, _, _ := svc.LookupUser(ctx, rahul); name != "Rahul" {
+		t.Errorf("expected Rahul to be kept, got %q", name)
+	}
+	result, _ := svc.LookupUserDetailed(ctx, rahul, 5)
+	if want := []models.NameCandidate{{Name: "Rahul", Uploaders: 1}}; !reflect.DeepEqual(result.GetAlternatives(), want) {
+		t.Errorf("expected alternatives %v, got %v", want, result.GetAlternatives())
+	}
+
+	// A number saved only under such names has no crowd name
+	_ = svc.UploadContacts(ctx, owner, []models.Contact{{PhoneNumber: "919000000001", Name: "🙂"}})
+	if _, _, err := svc.LookupUser(ctx, "919000000001"); !errors.Is(err, daoerrors.ErrUserNotFound) {
+		t.Errorf("expected ErrUserNotFound, got %v", err)
+	}
+}
+
+func TestUserService_ParsesNames(t *testing.T) {
+	ctx := context.Background()
+	phoneBookDAO := mem.NewPhoneBookMemDAO()
+	svc := NewUserService(mem.NewUserMemDAO(), phoneBookDAO)
+	anita, owner := "919123456789", "919876543210"
+
+	if err := svc.UploadContacts(ctx, owner, []models.Contact{{PhoneNumber: anita, Name: "Dr. Anita Rao - Apollo 🏥"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := &models.PersonName{Honorific: "Dr.", GivenName: "Anita", FamilyName: "Rao", Organization: "Apollo"}
+	pb, _ := phoneBookDAO.GetPhoneBookByUserPhoneNumber(ctx, owner)
+	if got := pb.Contacts[0]; got.Name != "Dr. Anita Rao - Apollo 🏥" || !reflect.DeepEqual(got.ParsedName, want) {
+		t.Errorf("expected the raw name and its parts to be stored, got %+v", got)
+	}
+	result, err := svc.LookupUserDetailed(ctx, anita, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Name != "Dr. Anita Rao - Apollo" || !reflect.DeepEqual(result.GetPerson(), want) {
+		t.Errorf("expected the resolved name and its parts, got %+v", result)
+	}
+	if got := result.GetPerson().FullName(); got != "Anita Rao" {
+		t.Errorf("expected clean name Anita Rao, got %q", got)
+	}
+
+	// The owner's display name is parsed instead of the crowd name
+	_ = svc.SetDisplayName(ctx, anita, "Anita R.")
+	result, _ = svc.LookupUserDetailed(ctx, anita, 0)
+	if want := (&models.PersonName{GivenName: "Anita", FamilyName: "R."}); !reflect.DeepEqual(result.GetPerson(), want) {
+		t.Errorf("expected parts %+v, got %+v", want, result.GetPerson())
+	}
+}
+
+func TestUserService_GroupsNameSpellings(t *testing.T) {
+	ctx := context.Background()
+	svc := NewUserService(mem.NewUserMemDAO(), mem.NewPhoneBookMemDAO())
+	bob := "919123456789"
+	uploads := []string{"Mohammed", "Muhammad", "Bob", "मोहम्मद", "Mohamad"}
+	for i, name := range uploads {
+		uploader := "91987654321" + string(rune('0'+i))
+		if err := svc.UploadContacts(ctx, uploader, []models.Contact{{PhoneNumber: bob, Name: name}}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	result, err := svc.LookupUserDetailed(ctx, bob, 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Uploads saved in the same instant may tie, so only the grouping is checked, not which spelling leads it
+	got := result.GetAlternatives()
+	if len(got) != 2 || got[0].Uploaders != 4 || got[1] != (models.NameCandidate{Name: "Bob", Uploaders: 1}) {
+		t.Errorf("expected the four spellings grouped ahead of Bob, got %v", got)
+	}
+}
+
+func TestUserService_KeepsDifferentNamesApart(t *testing.T) {
+	ctx := context.Background()
+	svc := NewUserService(mem.NewUserMemDAO(), mem.NewPhoneBookMemDAO())
+	bob := "919123456789"
+	uploads := []string{"Anil Kumar", "Sunil Kumar", "Raj Kumar", "Ram Kumar", "Sonal", "Sunil", "Rohan", "Rehana"}
+	for i, name := range uploads {
+		uploader := "91987654321" + string(rune('0'+i))
+		if err := svc.UploadContacts(ctx, uploader, []models.Contact{{PhoneNumber: bob, Name: name}}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	result, err := svc.LookupUserDetailed(ctx, bob, MaxAlternatives)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := result.GetAlternatives(); len(got) != len(uploads) {
+		t.Errorf("expected every name as its own candidate, got %v", got)
+	}
+}
+
+func TestUserService_IgnoresPlaceholderNames(t *testing.T) {
+	ctx := context.Background()
+	bob, owner := "919123456789", "919876543210"
+	tests := []struct {
+		name     string
+		opts     []UserServiceOption
+		uploads  []string
+		wantName string
+		wantErr  error
+	}{
+		{name: "relationship word ignored", uploads: []string{"Bob", "Papa"}, wantName: "Bob"},
+		{name: "only placeholders", uploads: []string{"Unknown", "Don't pick"}, wantErr: daoerrors.ErrUserNotFound},
+		{name: "custom lexicon", opts: []UserServiceOption{WithNameLexicon(names.NewLexicon("Bob"))}, uploads: []string{"Papa", "Bob"}, wantName: "Papa"},
+		{name: "lexicon disabled", opts: []UserServiceOption{WithNameLexicon(nil)}, uploads: []string{"Bob", "Mom"}, wantName: "Mom"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			phoneBookDAO := mem.NewPhoneBookMemDAO()
+			svc := NewUserService(mem.NewUserMemDAO(), phoneBookDAO, tc.opts...)
+			for i, name := range tc.uploads {
+				uploader := "91987654321" + string(rune('0'+i))
+				if err := svc.UploadContacts(ctx, uploader, []models.Contact{{PhoneNumber: bob, Name: name}}); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			name, _, err := svc.LookupUser(ctx, bob)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if name != tc.wantName {
+				t.Errorf("expected name %q, got %q", tc.wantName, name)
+			}
+			// The uploader's own phone book keeps the entry as saved
+			pb, _ := phoneBookDAO.GetPhoneBookByUserPhoneNumber(ctx, owner)
+			if got := pb.GetContacts()[0].Name; got != tc.uploads[0] {
+				t.Errorf("expected phone book to keep %q, got %q", tc.uploads[0], got)
+			}
+		})
+	}
+}
+
+func TestUserService_SuppressesOffensiveNames(t *testing.T) {
+	ctx := context.Background()
+	userDAO, phoneBookDAO, moderationDAO := mem.NewUserMemDAO(), mem.NewPhoneBookMemDAO(), mem.NewModerationMemDAO()
+	svc := NewUserService(userDAO, phoneBookDAO, WithModerationDAO(moderationDAO))
+	bob := "919123456789"
+
+	_ = svc.UploadContacts(ctx, "919876543210", []models.Contact{{PhoneNumber: bob, Name: "Bob"}})
+	_ = svc.UploadContacts(ctx, "919876543211", []models.Contact{{PhoneNumber: bob, Name: "Bob the Ch00tiya"}})
+	if name, _, _ := svc.LookupUser(ctx, bob); name != "Bob" {
+		t.Errorf("expected offensive name to be skipped, got %q", name)
+	}
+	result, _ := svc.LookupUserDetailed(ctx, bob, 5)
+	if want := []models.NameCandidate{{Name: "Bob", Uploaders: 1}}; !reflect.DeepEqual(result.GetAlternatives(), want) {
+		t.Errorf("expected offensive name to be left out of alternatives, got %v", result.GetAlternatives())
+	}
+	moderation, err := moderationDAO.GetNameModeration(ctx, bob)
+	if err != nil || moderation == nil || len(moderation.Suppressed) != 1 || moderation.Suppressed[0].Name != "Bob the Ch00tiya" {
+		t.Fatalf("expected the offensive name to be kept for review, got %+v (%v)", moderation, err)
+	}
+
+	// The state goes away with the last offensive name
+	_ = svc.UploadContacts(ctx, "919876543211", []models.Contact{{PhoneNumber: bob, Name: "Robert"}})
+	if moderation, _ := moderationDAO.GetNameModeration(ctx, bob); moderation != nil {
+		t.Errorf("expected moderation state to be cleared, got %+v", moderation)
+	}
+
+	// Offensive display names are rejected
+	err = svc.SetDisplayName(ctx, bob, "B1tch please")
+	var ve *models.ValidationError
+	if !errors.As(err, &ve) || ve.Code != models.CodeOffensive {
+		t.Errorf("expected offensive validation error, got %v", err)
+	}
+	if err := NewUserService(userDAO, phoneBookDAO, WithNameFilter(nil)).SetDisplayName(ctx, bob, "B1tch please"); err != nil {
+		t.Errorf("expected a nil filter to allow any name, got %v", err)
+	}
+}
@@ -0,0 +1,300 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao"
+	"github.com/yourusername/truecaller-lite/pkg/dao/daoerrors"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+	"github.com/yourusername/truecaller-lite/pkg/otp"
+)
+
+// PrivacyService defines the business logic contract for data subject requests (DPDP/GDPR style).
+// Callers must ensure the request comes from the verified owner of the phone number.
+// All methods accept a context for timeouts and cancellations, and return errors for validation or business rule violations.
+type PrivacyService interface {
+	// ExportUserData assembles everything held about a phone number: its own phone book, the names other users
+	// saved it under (with uploaders anonymized), its unlisted state, the lookups it made and received (if a
+	// lookup log is configured), its spam status and history, and the spam reports it filed.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   phoneNumber: the owner's phone number (must be 12 digits, starts with 91)
+	// Returns:
+	//   export: the assembled data; sections with no data are empty, not nil
+	//   error: if validation fails or storage error occurs
+	// Example:
+	//   export, err := service.ExportUserData(ctx, "919876543210")
+	ExportUserData(ctx context.Context, phoneNumber string) (*models.UserDataExport, error)
+
+	// DeleteUserData erases a phone number's data: its phone book, its contributions to other numbers'
+	// resolved names, its User record, the spam reports it filed and the lookups it made or received. Its session
+	// tokens are revoked if a token revoker is configured. A tombstone is left so that the number, when uploaded
+	// later by other users, is not resolved into a new User record until PruneTombstones removes the tombstone
+	// after the tombstone retention.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   phoneNumber: the owner's phone number (must be 12 digits, starts with 91)
+	// Returns:
+	//   error: if validation fails or storage error occurs; erasing a number with no data is not an error
+	// Example:
+	//   err := service.DeleteUserData(ctx, "919876543210")
+	DeleteUserData(ctx context.Context, phoneNumber string) error
+
+	// SetUnlisted removes a phone number from caller ID (unlisted true) or restores it (unlisted false).
+	// While unlisted, UserService.LookupUser returns ErrUnlisted with the spam status but no name; the number
+	// still appears in other users' phone books and keeps its resolved name for when it is relisted.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   phoneNumber: the owner's phone number (must be 12 digits, starts with 91)
+	//   unlisted: true to unlist, false to relist
+	// Returns:
+	//   error: if validation fails or storage error occurs
+	// Example:
+	//   err := service.SetUnlisted(ctx, "919876543210", true)
+	SetUnlisted(ctx context.Context, phoneNumber string, unlisted bool) error
+
+	// PruneTombstones removes the tombstones of erasures older than the tombstone retention, so the numbers get
+	// crowd-sourced names again, e.g. once they have been reassigned to new subscribers. Intended for a periodic job.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	// Returns:
+	//   deleted: the number of tombstones removed
+	//   error: if storage error occurs
+	// Example:
+	//   deleted, err := service.PruneTombstones(ctx)
+	PruneTombstones(ctx context.Context) (int, error)
+}
+
+// DefaultTombstoneRetention is how long an erasure keeps a number from being resolved again when no retention is
+// configured. Operators reassign numbers that have been disconnected for a few months, and a year-old erasure is
+// unlikely to still speak for the number's subscriber.
+const DefaultTombstoneRetention = 365 * 24 * time.Hour
+
+// Error handling pattern: All methods return error for validation, storage or business rule errors. Use errors.Is for type checks.
+
+// privacyService implements PrivacyService interface.
+type privacyService struct {
+	userDAO       dao.UserDAO
+	phoneBookDAO  dao.PhoneBookDAO
+	spamReportDAO dao.SpamReportDAO
+	privacyDAO    dao.PrivacyDAO
+	lookupLogDAO  dao.LookupLogDAO
+	tokenRevoker  otp.TokenRevoker
+	retention     time.Duration
+	nameOptions   []UserServiceOption
+	resolver      *nameResolver
+}
+
+// PrivacyServiceOption configures optional PrivacyService dependencies.
+type PrivacyServiceOption func(*privacyService)
+
+// WithLookupLogDAO includes the lookup log in data exports and erasures.
+func WithLookupLogDAO(lookupLogDAO dao.LookupLogDAO) PrivacyServiceOption {
+	return func(s *privacyService) { s.lookupLogDAO = lookupLogDAO }
+}
+
+// WithTokenRevoker makes erasures revoke the erased number's session tokens.
+func WithTokenRevoker(revoker otp.TokenRevoker) PrivacyServiceOption {
+	return func(s *privacyService) { s.tokenRevoker = revoker }
+}
+
+// WithTombstoneRetention sets how long erasure tombstones are kept; DefaultTombstoneRetention if d <= 0.
+func WithTombstoneRetention(d time.Duration) PrivacyServiceOption {
+	return func(s *privacyService) { s.retention = d }
+}
+
+// WithNameResolution makes erasures re-resolve names the same way as a UserService created with opts, e.g. with
+// WithNameLexicon, WithNameFilter and WithModerationDAO. Options unrelated to name resolution have no effect.
+func WithNameResolution(opts ...UserServiceOption) PrivacyServiceOption {
+	return func(s *privacyService) { s.nameOptions = append(s.nameOptions, opts...) }
+}
+
+// NewPrivacyService creates a new PrivacyService instance.
+func NewPrivacyService(userDAO dao.UserDAO, phoneBookDAO dao.PhoneBookDAO, spamReportDAO dao.SpamReportDAO, privacyDAO dao.PrivacyDAO, opts ...PrivacyServiceOption) PrivacyService {
+	s := &privacyService{
+		userDAO:       userDAO,
+		phoneBookDAO:  phoneBookDAO,
+		spamReportDAO: spamReportDAO,
+		privacyDAO:    privacyDAO,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.retention <= 0 {
+		s.retention = DefaultTombstoneRetention
+	}
+	s.resolver = newUserService(userDAO, phoneBookDAO, append(s.nameOptions, WithPrivacyDAO(privacyDAO))...).resolver
+	return s
+}
+
+// ExportUserData assembles everything held about a phone number.
+func (s *privacyService) ExportUserData(ctx context.Context, phoneNumber string) (*models.UserDataExport, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err := (&models.PhoneBook{PhoneNumber: phoneNumber}).Validate(); err != nil {
+		return nil, err
+	}
+	export := &models.UserDataExport{
+		PhoneNumber:       phoneNumber,
+		GeneratedAt:       time.Now().UTC(),
+		PhoneBook:         []models.Contact{},
+		SavedAs:           []models.SavedName{},
+		LookupsMade:       []models.LookupRecord{},
+		ViewedBy:          []models.Viewer{},
+		SpamStatusHistory: []models.SpamStatusChange{},
+		SpamReportsFiled:  []models.SpamReport{},
+	}
+
+	user, err := s.userDAO.GetUserByPhoneNumber(ctx, phoneNumber)
+	switch {
+	case err == nil:
+		export.Profile = user
+	case !errors.Is(err, daoerrors.ErrUserNotFound):
+		return nil, err
+	}
+
+	pb, err := s.phoneBookDAO.GetPhoneBookByUserPhoneNumber(ctx, phoneNumber)
+	switch {
+	case err == nil:
+		export.PhoneBook = append(export.PhoneBook, pb.GetContacts()...)
+	case !errors.Is(err, daoerrors.ErrPhoneBookNotFound):
+		return nil, err
+	}
+
+	entries, err := s.phoneBookDAO.GetContactEntriesByPhoneNumber(ctx, phoneNumber)
+	if err != nil {
+		return nil, err
+	}
+	export.SavedAs = anonymizeSavedNames(entries)
+
+	if export.Unlisted, err = s.privacyDAO.IsUnlisted(ctx, phoneNumber); err != nil {
+		return nil, err
+	}
+
+	if s.lookupLogDAO != nil {
+		if err := s.exportLookups(ctx, export); err != nil {
+			return nil, err
+		}
+	}
+
+	history, err := s.spamReportDAO.GetSpamStatusHistory(ctx, phoneNumber)
+	if err != nil {
+		return nil, err
+	}
+	for _, change := range history {
+		export.SpamStatusHistory = append(export.SpamStatusHistory, *change)
+	}
+
+	reports, err := s.spamReportDAO.GetSpamReportsByReporter(ctx, phoneNumber)
+	if err != nil {
+		return nil, err
+	}
+	for _, report := range reports {
+		export.SpamReportsFiled = append(export.SpamReportsFiled, *report)
+	}
+	return export, nil
+}
+
+// DeleteUserData erases a phone number's data, leaves a tombstone and revokes the number's sessions.
+// The tombstone is written first so uploads racing with the erasure cannot recreate the User record. Sessions are
+// revoked last, so an erasure that fails partway can be retried with the same token.
+func (s *privacyService) DeleteUserData(ctx context.Context, phoneNumber string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err := (&models.PhoneBook{PhoneNumber: phoneNumber}).Validate(); err != nil {
+		return err
+	}
+	if err := s.privacyDAO.CreateTombstone(ctx, phoneNumber, time.Now().UTC()); err != nil {
+		return err
+	}
+	pb, err := s.phoneBookDAO.GetPhoneBookByUserPhoneNumber(ctx, phoneNumber)
+	switch {
+	case err == nil:
+		if err := s.phoneBookDAO.DeletePhoneBook(ctx, phoneNumber); err != nil && !errors.Is(err, daoerrors.ErrPhoneBookNotFound) {
+			return err
+		}
+		// Retract this owner's contributions to the names of everyone in the deleted phone book
+		if err := s.resolver.resolveAll(ctx, pb.GetContacts()); err != nil {
+			return err
+		}
+	case !errors.Is(err, daoerrors.ErrPhoneBookNotFound):
+		return err
+	}
+	if err := s.resolver.erase(ctx, phoneNumber); err != nil {
+		return err
+	}
+	if s.lookupLogDAO != nil {
+		if err := s.lookupLogDAO.DeleteLookupsByPhoneNumber(ctx, phoneNumber); err != nil {
+			return err
+		}
+	}
+	if err := s.spamReportDAO.DeleteSpamReportsByReporter(ctx, phoneNumber); err != nil {
+		return err
+	}
+	if s.tokenRevoker != nil {
+		return s.tokenRevoker.RevokeTokens(ctx, phoneNumber)
+	}
+	return nil
+}
+
+// exportLookups adds the lookups made and received by export.PhoneNumber to export.
+func (s *privacyService) exportLookups(ctx context.Context, export *models.UserDataExport) error {
+	made, err := s.lookupLogDAO.GetLookupsByRequester(ctx, export.PhoneNumber)
+	if err != nil {
+		return err
+	}
+	for _, r := range made {
+		export.LookupsMade = append(export.LookupsMade, *r)
+	}
+	received, err := s.lookupLogDAO.GetLookupsByPhoneNumber(ctx, export.PhoneNumber)
+	if err != nil {
+		return err
+	}
+	visible, err := visibleLookups(ctx, s.privacyDAO, received, time.Time{})
+	if err != nil {
+		return err
+	}
+	for _, r := range visible {
+		export.ViewedBy = append(export.ViewedBy, models.Viewer{PhoneNumber: r.RequesterPhoneNumber, ViewedAt: r.LookedUpAt})
+	}
+	return nil
+}
+
+// SetUnlisted removes a phone number from caller ID or restores it.
+func (s *privacyService) SetUnlisted(ctx context.Context, phoneNumber string, unlisted bool) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err := (&models.PhoneBook{PhoneNumber: phoneNumber}).Validate(); err != nil {
+		return err
+	}
+	return s.privacyDAO.SetUnlisted(ctx, phoneNumber, unlisted)
+}
+
+// PruneTombstones removes the tombstones of erasures older than the tombstone retention.
+func (s *privacyService) PruneTombstones(ctx context.Context) (int, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+	return s.privacyDAO.DeleteTombstonesBefore(ctx, time.Now().Add(-s.retention))
+}
+
+// anonymizeSavedNames replaces uploader phone numbers with aliases. Entries are sorted by name first so
+// alias numbering does not reveal the uploaders' phone number order.
+func anonymizeSavedNames(entries []models.ContactEntry) []models.SavedName {
+	sorted := append([]models.ContactEntry(nil), entries...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Contact.GetName() < sorted[j].Contact.GetName() })
+	result := make([]models.SavedName, 0, len(sorted))
+	for i, e := range sorted {
+		result = append(result, models.SavedName{Uploader: "uploader-" + strconv.Itoa(i+1), Name: e.Contact.GetName()})
+	}
+	return result
+}
+
+var _ PrivacyService = (*privacyService)(nil)
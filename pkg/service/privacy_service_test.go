@@ -0,0 +1,319 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao/daoerrors"
+	"github.com/yourusername/truecaller-lite/pkg/dao/mem"
+	"github.com/yourusername/truecaller-lite/pkg/dao/mock"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+	"github.com/yourusername/truecaller-lite/pkg/names"
+	"github.com/yourusername/truecaller-lite/pkg/otp"
+)
+
+// Test cases for PrivacyService.ExportUserData
+func TestPrivacyService_ExportUserData(t *testing.T) {
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		phone       string
+		mockSetup   func(u *mock.UserDAOMock, pb *mock.PhoneBookDAOMock, sr *mock.SpamReportDAOMock)
+		wantErr     bool
+		wantProfile bool
+		wantBook    int
+		wantSavedAs []models.SavedName
+		wantHistory int
+		wantReports int
+	}{
+		{
+			name:  "assembles all sections",
+			ctx:   context.Background(),
+			phone: "919876543210",
+			mockSetup: func(u *mock.UserDAOMock, pb *mock.PhoneBookDAOMock, sr *mock.SpamReportDAOMock) {
+				u.OnGetUserByPhoneNumber = func(ctx context.Context, phone string) (*models.User, error) {
+					return &models.User{PhoneNumber: phone, Name: "Alice", IsSpam: true}, nil
+				}
+				pb.OnGetPhoneBookByUserPhoneNumber = func(ctx context.Context, phone string) (*models.PhoneBook, error) {
+					return &models.PhoneBook{PhoneNumber: phone, Contacts: []models.Contact{{PhoneNumber: "919123456789", Name: "Bob"}}}, nil
+				}
+				pb.OnGetContactEntriesByPhoneNumber = func(ctx context.Context, phone string) ([]models.ContactEntry, error) {
+					return []models.ContactEntry{
+						{OwnerPhoneNumber: "919000000001", Contact: models.Contact{PhoneNumber: phone, Name: "Plumber Alice"}},
+						{OwnerPhoneNumber: "919000000002", Contact: models.Contact{PhoneNumber: phone, Name: "Alice"}},
+					}, nil
+				}
+				sr.OnGetSpamStatusHistory = func(ctx context.Context, phone string) ([]*models.SpamStatusChange, error) {
+					return []*models.SpamStatusChange{{PhoneNumber: phone, IsSpam: true}}, nil
+				}
+				sr.OnGetSpamReportsByReporter = func(ctx context.Context, phone string) ([]*models.SpamReport, error) {
+					return []*models.SpamReport{{ReporterPhoneNumber: phone, PhoneNumber: "919123456789"}}, nil
+				}
+			},
+			wantProfile: true,
+			wantBook:    1,
+			wantSavedAs: []models.SavedName{{Uploader: "uploader-1", Name: "Alice"}, {Uploader: "uploader-2", Name: "Plumber Alice"}},
+			wantHistory: 1,
+			wantReports: 1,
+		},
+		{
+			name:  "number with no data",
+			ctx:   context.Background(),
+			phone: "919876543210",
+			mockSetup: func(u *mock.UserDAOMock, pb *mock.PhoneBookDAOMock, sr *mock.SpamReportDAOMock) {
+				u.OnGetUserByPhoneNumber = func(ctx context.Context, phone string) (*models.User, error) {
+					return nil, daoerrors.ErrUserNotFound
+				}
+				pb.OnGetPhoneBookByUserPhoneNumber = func(ctx context.Context, phone string) (*models.PhoneBook, error) {
+					return nil, daoerrors.ErrPhoneBookNotFound
+				}
+			},
+			wantSavedAs: []models.SavedName{},
+		},
+		{
+			name:      "invalid phone number",
+			ctx:       context.Background(),
+			phone:     "123",
+			mockSetup: func(u *mock.UserDAOMock, pb *mock.PhoneBookDAOMock, sr *mock.SpamReportDAOMock) {},
+			wantErr:   true,
+		},
+		{
+			name:  "DAO returns error",
+			ctx:   context.Background(),
+			phone: "919876543210",
+			mockSetup: func(u *mock.UserDAOMock, pb *mock.PhoneBookDAOMock, sr *mock.SpamReportDAOMock) {
+				u.OnGetUserByPhoneNumber = func(ctx context.Context, phone string) (*models.User, error) {
+					return nil, errors.New("dao error")
+				}
+			},
+			wantErr: true,
+		},
+		{
+			name:      "context canceled",
+			ctx:       func() context.Context { c, cancel := context.WithCancel(context.Background()); cancel(); return c }(),
+			phone:     "919876543210",
+			mockSetup: func(u *mock.UserDAOMock, pb *mock.PhoneBookDAOMock, sr *mock.SpamReportDAOMock) {},
+			wantErr:   true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			userDAO, phoneBookDAO, spamReportDAO := &mock.UserDAOMock{}, &mock.PhoneBookDAOMock{}, &mock.SpamReportDAOMock{}
+			tc.mockSetup(userDAO, phoneBookDAO, spamReportDAO)
+			svc := NewPrivacyService(userDAO, phoneBookDAO, spamReportDAO, &mock.PrivacyDAOMock{})
+			export, err := svc.ExportUserData(tc.ctx, tc.phone)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error: %v, got: %v", tc.wantErr, err)
+			}
+			if tc.wantErr {
+				return
+			}
+			if (export.Profile != nil) != tc.wantProfile {
+				t.Errorf("expected profile: %v, got %+v", tc.wantProfile, export.Profile)
+			}
+			if len(export.PhoneBook) != tc.wantBook || len(export.SpamStatusHistory) != tc.wantHistory || len(export.SpamReportsFiled) != tc.wantReports {
+				t.Errorf("unexpected export sections %+v", export)
+			}
+			if len(export.SavedAs) != len(tc.wantSavedAs) {
+				t.Fatalf("expected saved names %+v, got %+v", tc.wantSavedAs, export.SavedAs)
+			}
+			for i, want := range tc.wantSavedAs {
+				if export.SavedAs[i] != want {
+					t.Errorf("saved name %d: expected %+v, got %+v", i, want, export.SavedAs[i])
+				}
+			}
+		})
+	}
+}
+
+// Test cases for PrivacyService.DeleteUserData
+func TestPrivacyService_DeleteUserData(t *testing.T) {
+	ctx := context.Background()
+	userDAO, phoneBookDAO, spamReportDAO, privacyDAO := mem.NewUserMemDAO(), mem.NewPhoneBookMemDAO(), mem.NewSpamReportMemDAO(), mem.NewPrivacyMemDAO()
+	moderationDAO := mem.NewModerationMemDAO()
+	userService := NewUserService(userDAO, phoneBookDAO, WithPrivacyDAO(privacyDAO), WithModerationDAO(moderationDAO))
+	lookupLogDAO := mem.NewLookupLogMemDAO()
+	sessions := otp.NewSessionIssuer(time.Hour)
+	privacyService := NewPrivacyService(userDAO, phoneBookDAO, spamReportDAO, privacyDAO, WithLookupLogDAO(lookupLogDAO), WithNameResolution(WithModerationDAO(moderationDAO)), WithTokenRevoker(sessions))
+	alice, bob, carol, dave := "919876543210", "919123456789", "919123456780", "919123456781"
+	token, _, _ := sessions.IssueToken(ctx, alice)
+
+	_ = userService.UploadContacts(ctx, carol, []models.Contact{{PhoneNumber: alice, Name: "Alice"}, {PhoneNumber: bob, Name: "Bob"}})
+	_ = userService.UploadContacts(ctx, alice, []models.Contact{{PhoneNumber: bob, Name: "Bobby"}, {PhoneNumber: carol, Name: "Carol the idiot"}})
+	_ = userService.UploadContacts(ctx, dave, []models.Contact{{PhoneNumber: alice, Name: "Idiot"}})
+	_ = spamReportDAO.CreateSpamReport(ctx, &models.SpamReport{ReporterPhoneNumber: alice, PhoneNumber: bob})
+	_ = lookupLogDAO.AddLookup(ctx, &models.LookupRecord{RequesterPhoneNumber: alice, PhoneNumber: bob})
+	_ = lookupLogDAO.AddLookup(ctx, &models.LookupRecord{RequesterPhoneNumber: carol, PhoneNumber: alice})
+
+	if err := privacyService.DeleteUserData(ctx, alice); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := phoneBookDAO.GetPhoneBookByUserPhoneNumber(ctx, alice); !errors.Is(err, daoerrors.ErrPhoneBookNotFound) {
+		t.Errorf("expected phone book to be deleted, got %v", err)
+	}
+	if _, err := userDAO.GetUserByPhoneNumber(ctx, alice); !errors.Is(err, daoerrors.ErrUserNotFound) {
+		t.Errorf("expected user record to be deleted, got %v", err)
+	}
+	if name, _, _ := userService.LookupUser(ctx, bob); name != "Bob" {
+		t.Errorf("expected Alice's name for Bob to be retracted, got %q", name)
+	}
+	if moderation, _ := moderationDAO.GetNameModeration(ctx, alice); moderation != nil {
+		t.Errorf("expected moderation state of the erased number to be deleted, got %+v", moderation)
+	}
+	if moderation, _ := moderationDAO.GetNameModeration(ctx, carol); moderation != nil {
+		t.Errorf("expected names suppressed from the erased phone book to be retracted, got %+v", moderation)
+	}
+	if reports, _ := spamReportDAO.GetSpamReportsByReporter(ctx, alice); len(reports) != 0 {
+		t.Errorf("expected spam reports to be deleted, got %+v", reports)
+	}
+	if viewers, _ := lookupLogDAO.GetLookupsByPhoneNumber(ctx, bob); len(viewers) != 0 {
+		t.Errorf("expected lookups made by the erased number to be deleted, got %+v", viewers)
+	}
+	if made, _ := lookupLogDAO.GetLookupsByRequester(ctx, carol); len(made) != 0 {
+		t.Errorf("expected lookups of the erased number to be deleted, got %+v", made)
+	}
+	if _, err := sessions.VerifyToken(ctx, token); !errors.Is(err, otp.ErrInvalidToken) {
+		t.Errorf("expected the erased number's session to be revoked, got %v", err)
+	}
+	// Re-uploads by others respect the erasure
+	_ = userService.UploadContacts(ctx, bob, []models.Contact{{PhoneNumber: alice, Name: "Alice"}})
+	if _, _, err := userService.LookupUser(ctx, alice); !errors.Is(err, daoerrors.ErrUserNotFound) {
+		t.Errorf("expected erased number to stay unresolved, got %v", err)
+	}
+	// Erasing a number with no data succeeds
+	if err := privacyService.DeleteUserData(ctx, "919000000000"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestPrivacyService_PruneTombstones(t *testing.T) {
+	ctx := context.Background()
+	userDAO, phoneBookDAO, privacyDAO := mem.NewUserMemDAO(), mem.NewPhoneBookMemDAO(), mem.NewPrivacyMemDAO()
+	userService := NewUserService(userDAO, phoneBookDAO, WithPrivacyDAO(privacyDAO))
+	privacyService := NewPrivacyService(userDAO, phoneBookDAO, mem.NewSpamReportMemDAO(), privacyDAO, WithTombstoneRetention(time.Hour))
+	old, recent := "919876543210", "919123456789"
+	_ = privacyDAO.CreateTombstone(ctx, old, time.Now().Add(-2*time.Hour))
+	if err := privacyService.DeleteUserData(ctx, recent); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if deleted, err := privacyService.PruneTombstones(ctx); err != nil || deleted != 1 {
+		t.Fatalf("expected 1 tombstone pruned, got %d (%v)", deleted, err)
+	}
+	_ = userService.UploadContacts(ctx, "919000000001", []models.Contact{{PhoneNumber: old, Name: "Asha"}, {PhoneNumber: recent, Name: "Bob"}})
+	if name, _, err := userService.LookupUser(ctx, old); err != nil || name != "Asha" {
+		t.Errorf("expected the number to be resolved once its tombstone is pruned, got %q (%v)", name, err)
+	}
+	if _, _, err := userService.LookupUser(ctx, recent); !errors.Is(err, daoerrors.ErrUserNotFound) {
+		t.Errorf("expected a recent erasure to be kept, got %v", err)
+	}
+}
+
+func TestPrivacyService_DeleteUserData_UsesNameLexicon(t *testing.T) {
+	ctx := context.Background()
+	userDAO, phoneBookDAO, privacyDAO := mem.NewUserMemDAO(), mem.NewPhoneBookMemDAO(), mem.NewPrivacyMemDAO()
+	nameOptions := []UserServiceOption{WithNameLexicon(names.DefaultLexicon().With("Boss"))}
+	userService := NewUserService(userDAO, phoneBookDAO, append(nameOptions, WithPrivacyDAO(privacyDAO))...)
+	privacyService := NewPrivacyService(userDAO, phoneBookDAO, mem.NewSpamReportMemDAO(), privacyDAO, WithNameResolution(nameOptions...))
+	alice, bob, carol := "919876543210", "919123456789", "919123456780"
+
+	_ = userService.UploadContacts(ctx, carol, []models.Contact{{PhoneNumber: bob, Name: "Boss"}})
+	_ = userService.UploadContacts(ctx, alice, []models.Contact{{PhoneNumber: bob, Name: "Bobby"}})
+	if err := privacyService.DeleteUserData(ctx, alice); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name, _, _ := userService.LookupUser(ctx, bob); name == "Boss" {
+		t.Error("expected erasure to re-resolve names with the custom lexicon, got \"Boss\"")
+	}
+}
+
+func TestPrivacyService_DeleteUserData_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		phone     string
+		mockSetup func(pb *mock.PhoneBookDAOMock, p *mock.PrivacyDAOMock)
+	}{
+		{name: "invalid phone number", ctx: context.Background(), phone: "123", mockSetup: func(pb *mock.PhoneBookDAOMock, p *mock.PrivacyDAOMock) {}},
+		{
+			name:  "tombstone error",
+			ctx:   context.Background(),
+			phone: "919876543210",
+			mockSetup: func(pb *mock.PhoneBookDAOMock, p *mock.PrivacyDAOMock) {
+				p.OnCreateTombstone = func(ctx context.Context, phone string, erasedAt time.Time) error { return errors.New("dao error") }
+			},
+		},
+		{
+			name:  "phone book error",
+			ctx:   context.Background(),
+			phone: "919876543210",
+			mockSetup: func(pb *mock.PhoneBookDAOMock, p *mock.PrivacyDAOMock) {
+				pb.OnGetPhoneBookByUserPhoneNumber = func(ctx context.Context, phone string) (*models.PhoneBook, error) {
+					return nil, errors.New("dao error")
+				}
+			},
+		},
+		{
+			name:      "context canceled",
+			ctx:       func() context.Context { c, cancel := context.WithCancel(context.Background()); cancel(); return c }(),
+			phone:     "919876543210",
+			mockSetup: func(pb *mock.PhoneBookDAOMock, p *mock.PrivacyDAOMock) {},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			phoneBookDAO, privacyDAO := &mock.PhoneBookDAOMock{}, &mock.PrivacyDAOMock{}
+			tc.mockSetup(phoneBookDAO, privacyDAO)
+			svc := NewPrivacyService(&mock.UserDAOMock{}, phoneBookDAO, &mock.SpamReportDAOMock{}, privacyDAO)
+			if err := svc.DeleteUserData(tc.ctx, tc.phone); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+// Test cases for PrivacyService.SetUnlisted
+func TestPrivacyService_SetUnlisted(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		phone    string
+		unlisted bool
+		daoErr   error
+		wantErr  bool
+	}{
+		{name: "unlist", ctx: context.Background(), phone: "919876543210", unlisted: true},
+		{name: "relist", ctx: context.Background(), phone: "919876543210", unlisted: false},
+		{name: "invalid phone number", ctx: context.Background(), phone: "123", unlisted: true, wantErr: true},
+		{name: "DAO returns error", ctx: context.Background(), phone: "919876543210", unlisted: true, daoErr: errors.New("dao error"), wantErr: true},
+		{
+			name:     "context canceled",
+			ctx:      func() context.Context { c, cancel := context.WithCancel(context.Background()); cancel(); return c }(),
+			phone:    "919876543210",
+			unlisted: true,
+			wantErr:  true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var got *bool
+			privacyDAO := &mock.PrivacyDAOMock{
+				OnSetUnlisted: func(ctx context.Context, phone string, unlisted bool) error {
+					got = &unlisted
+					return tc.daoErr
+				},
+			}
+			svc := NewPrivacyService(&mock.UserDAOMock{}, &mock.PhoneBookDAOMock{}, &mock.SpamReportDAOMock{}, privacyDAO)
+			err := svc.SetUnlisted(tc.ctx, tc.phone, tc.unlisted)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error: %v, got: %v", tc.wantErr, err)
+			}
+			if !tc.wantErr && (got == nil || *got != tc.unlisted) {
+				t.Errorf("expected DAO to be called with %v, got %v", tc.unlisted, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+	"github.com/yourusername/truecaller-lite/pkg/search"
+)
+
+// Search limits.
+const (
+	DefaultSearchPageSize = 20
+	MaxSearchPageSize     = 50
+	// MaxSearchResults is the most results a query can page through, so paging cannot list every match.
+	MaxSearchResults = 200
+	// MinSearchQueryLength is the fewest letters a query must have, so a short prefix cannot list every number.
+	MinSearchQueryLength = 3
+)
+
+// SearchService defines the business logic contract for finding phone numbers by name.
+// All methods accept a context for timeouts and cancellations, and return errors for validation or business rule violations.
+type SearchService interface {
+	// SearchUsers returns a page of the numbers whose resolved name matches query, direct matches first and then
+	// most widely saved first. Every word of the query must be a prefix of a word of the name, in Latin or Devanagari
+	// letters, sound like one or be one with a typo (see search.Index). Unlisted numbers are left out. Only the
+	// first MaxSearchResults matches can be paged through; Total counts at most that many.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   query: the name or name prefix to search for (at least MinSearchQueryLength letters)
+	//   offset: the number of results to skip
+	//   limit: the page size (DefaultSearchPageSize if <= 0, at most MaxSearchPageSize)
+	// Returns:
+	//   page: the results on the page and pagination details
+	//   error: if validation fails or storage error occurs
+	// Example:
+	//   page, err := service.SearchUsers(ctx, "rahul sh", 0, 20)
+	SearchUsers(ctx context.Context, query string, offset, limit int) (*models.SearchPage, error)
+}
+
+// searchService implements SearchService interface.
+type searchService struct {
+	index      *search.Index
+	privacyDAO dao.PrivacyDAO
+}
+
+// NewSearchService creates a new SearchService over index. A nil privacyDAO disables the unlisted check.
+func NewSearchService(index *search.Index, privacyDAO dao.PrivacyDAO) SearchService {
+	return &searchService{index: index, privacyDAO: privacyDAO}
+}
+
+// SearchUsers returns a page of the numbers whose resolved name matches query.
+func (s *searchService) SearchUsers(ctx context.Context, query string, offset, limit int) (*models.SearchPage, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	letters := 0
+	for _, w := range search.Words(query) {
+		letters += utf8.RuneCountInString(w)
+	}
+	if letters < MinSearchQueryLength {
+		return nil, &models.ValidationError{Field: "q", Index: models.NoIndex, Code: models.CodeInvalidFormat, Message: "search query must have at least " + strconv.Itoa(MinSearchQueryLength) + " letters"}
+	}
+	if limit <= 0 {
+		limit = DefaultSearchPageSize
+	}
+	limit = min(limit, MaxSearchPageSize)
+	offset = max(offset, 0)
+
+	matches := s.index.Search(query)
+	visible := matches[:0]
+	for _, m := range matches {
+		if s.privacyDAO != nil {
+			unlisted, err := s.privacyDAO.IsUnlisted(ctx, m.PhoneNumber)
+			if err != nil {
+				return nil, err
+			}
+			if unlisted {
+				continue
+			}
+		}
+		visible = append(visible, m)
+		if len(visible) == MaxSearchResults {
+			break
+		}
+	}
+	page := &models.SearchPage{Results: []models.SearchResult{}, Total: len(visible), Offset: offset, Limit: limit}
+	if offset >= len(visible) {
+		return page, nil
+	}
+	end := min(offset+limit, len(visible))
+	page.HasMore = end < len(visible)
+	page.Results = append(page.Results, visible[offset:end]...)
+	return page, nil
+}
+
+var _ SearchService = (*searchService)(nil)
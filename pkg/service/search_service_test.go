@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao/mem"
+	"github.com/yourusername/truecaller-lite/pkg/dao/mock"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+	"github.com/yourusername/truecaller-lite/pkg/search"
+)
+
+func TestSearchService_SearchUsers(t *testing.T) {
+	ctx := context.Background()
+	index := search.NewIndex()
+	for i, phone := range []string{"919000000001", "919000000002", "919000000003", "919000000004"} {
+		index.Put(models.SearchResult{PhoneNumber: phone, Name: "Rahul", Uploaders: 10 - i})
+	}
+	privacyDAO := mem.NewPrivacyMemDAO()
+	_ = privacyDAO.SetUnlisted(ctx, "919000000002", true)
+	svc := NewSearchService(index, privacyDAO)
+
+	tests := []struct {
+		name        string
+		query       string
+		offset      int
+		limit       int
+		privacyDAO  *mock.PrivacyDAOMock
+		wantErr     bool
+		wantCode    string
+		wantPhones  []string
+		wantTotal   int
+		wantHasMore bool
+	}{
+		{name: "first page without unlisted", query: "rahul", limit: 2, wantPhones: []string{"919000000001", "919000000003"}, wantTotal: 3, wantHasMore: true},
+		{name: "last page", query: "rahul", offset: 2, limit: 2, wantPhones: []string{"919000000004"}, wantTotal: 3},
+		{name: "past the end", query: "rahul", offset: 9, wantPhones: nil, wantTotal: 3},
+		{name: "no match", query: "priya", wantPhones: nil},
+		{name: "query too short", query: "ra", wantErr: true, wantCode: models.CodeInvalidFormat},
+		{name: "emoji only query", query: "🙂🙂🙂", wantErr: true, wantCode: models.CodeInvalidFormat},
+		{name: "privacy error", query: "rahul", privacyDAO: &mock.PrivacyDAOMock{OnIsUnlisted: func(context.Context, string) (bool, error) { return false, errors.New("boom") }}, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := svc
+			if tc.privacyDAO != nil {
+				s = NewSearchService(index, tc.privacyDAO)
+			}
+			page, err := s.SearchUsers(ctx, tc.query, tc.offset, tc.limit)
+			if tc.wantErr {
+				var ve *models.ValidationError
+				if err == nil || (tc.wantCode != "" && (!errors.As(err, &ve) || ve.Code != tc.wantCode)) {
+					t.Fatalf("expected error with code %q, got %v", tc.wantCode, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var phones []string
+			for _, r := range page.Results {
+				phones = append(phones, r.PhoneNumber)
+			}
+			if !reflect.DeepEqual(phones, tc.wantPhones) || page.Total != tc.wantTotal || page.HasMore != tc.wantHasMore {
+				t.Errorf("expected %v (total %d, more %v), got %v (total %d, more %v)", tc.wantPhones, tc.wantTotal, tc.wantHasMore, phones, page.Total, page.HasMore)
+			}
+		})
+	}
+}
+
+func TestSearchService_SearchUsers_ResultWindow(t *testing.T) {
+	index := search.NewIndex()
+	for i := 0; i < MaxSearchResults+10; i++ {
+		index.Put(models.SearchResult{PhoneNumber: fmt.Sprintf("919%09d", i), Name: "Rahul", Uploaders: 1})
+	}
+	svc := NewSearchService(index, nil)
+	page, err := svc.SearchUsers(context.Background(), "rahul", MaxSearchResults-10, MaxSearchPageSize)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if page.Total != MaxSearchResults || len(page.Results) != 10 || page.HasMore {
+		t.Errorf("expected the last 10 results of the window, got total %d, %d results, more %v", page.Total, len(page.Results), page.HasMore)
+	}
+	if page, err := svc.SearchUsers(context.Background(), "rahul", MaxSearchResults, 1); err != nil || len(page.Results) != 0 {
+		t.Errorf("expected no results past the window, got %+v (%v)", page, err)
+	}
+}
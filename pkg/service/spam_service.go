@@ -2,8 +2,11 @@ package service
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/yourusername/truecaller-lite/pkg/dao"
+	"github.com/yourusername/truecaller-lite/pkg/models"
 )
 
 // SpamService defines the business logic contract for spam status updates.
@@ -14,44 +17,108 @@ type SpamService interface {
 	// Params:
 	//   ctx: context for timeout/cancellation
 	// Returns:
+	//   changes: the changes applied, in order; on error, those applied before it (empty if none)
 	//   error: if update fails due to storage or business rule errors
 	// Example:
-	//   err := service.UpdateSpamStatus(ctx)
-	UpdateSpamStatus(ctx context.Context) error
+	//   changes, err := service.UpdateSpamStatus(ctx)
+	UpdateSpamStatus(ctx context.Context) ([]*models.SpamStatusChange, error)
+
+	// PlanSpamStatusUpdate returns the spam status changes UpdateSpamStatus would make now, without applying them.
+	// It lets operators dry-run the nightly job.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	// Returns:
+	//   changes: the changes the job would make (empty if none)
+	//   error: if storage error occurs
+	// Example:
+	//   changes, err := service.PlanSpamStatusUpdate(ctx)
+	PlanSpamStatusUpdate(ctx context.Context) ([]*models.SpamStatusChange, error)
+
+	// ReportSpam records a user's report that a phone number is spam.
+	// Reports are stored as part of the reporter's personal data; the nightly job does not read them yet.
+	// Params:
+	//   ctx: context for timeout/cancellation
+	//   reporterPhoneNumber: the phone number of the user filing the report
+	//   phoneNumber: the reported phone number
+	//   reason: optional free-text reason
+	// Returns:
+	//   error: if validation fails or storage error occurs
+	// Example:
+	//   err := service.ReportSpam(ctx, "919876543210", "919123456789", "loan scam")
+	ReportSpam(ctx context.Context, reporterPhoneNumber, phoneNumber, reason string) error
 }
 
 // Error handling pattern: All methods return error for storage or business rule errors. Use errors.Is for type checks.
 
 // spamService implements SpamService interface.
 type spamService struct {
-	userDAO dao.UserDAO
+	userDAO       dao.UserDAO
+	spamReportDAO dao.SpamReportDAO
 }
 
 // NewSpamService creates a new SpamService instance.
-func NewSpamService(userDAO dao.UserDAO) SpamService {
-	return &spamService{userDAO: userDAO}
+func NewSpamService(userDAO dao.UserDAO, spamReportDAO dao.SpamReportDAO) SpamService {
+	return &spamService{userDAO: userDAO, spamReportDAO: spamReportDAO}
 }
 
 // UpdateSpamStatus updates the spam status for all users based on a simple rule (simulate DS model).
-func (s *spamService) UpdateSpamStatus(ctx context.Context) error {
+// Every status change is appended to the number's spam status history. The changes are planned and applied in one
+// pass, and only those actually applied are returned.
+func (s *spamService) UpdateSpamStatus(ctx context.Context) ([]*models.SpamStatusChange, error) {
+	changes, err := s.PlanSpamStatusUpdate(ctx)
+	if err != nil {
+		return []*models.SpamStatusChange{}, err
+	}
+	applied := make([]*models.SpamStatusChange, 0, len(changes))
+	for _, change := range changes {
+		if ctx.Err() != nil {
+			return applied, ctx.Err()
+		}
+		if err := s.userDAO.UpdateSpamStatus(ctx, change.PhoneNumber, change.IsSpam); err != nil {
+			return applied, err
+		}
+		if err := s.spamReportDAO.AddSpamStatusChange(ctx, change); err != nil {
+			return applied, err
+		}
+		applied = append(applied, change)
+	}
+	return applied, nil
+}
+
+// PlanSpamStatusUpdate returns the status changes UpdateSpamStatus would make, without applying them.
+func (s *spamService) PlanSpamStatusUpdate(ctx context.Context) ([]*models.SpamStatusChange, error) {
 	if ctx.Err() != nil {
-		return ctx.Err()
+		return nil, ctx.Err()
 	}
 	users, err := s.userDAO.GetAllUsers(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	changes := []*models.SpamStatusChange{}
+	now := time.Now()
 	for _, user := range users {
-		if ctx.Err() != nil {
-			return ctx.Err()
-		}
 		if !user.GetIsSpam() {
-			if err := s.userDAO.UpdateSpamStatus(ctx, user.GetPhoneNumber(), true); err != nil {
-				return err
-			}
+			changes = append(changes, &models.SpamStatusChange{PhoneNumber: user.GetPhoneNumber(), IsSpam: true, ChangedAt: now})
 		}
 	}
-	return nil
+	return changes, nil
+}
+
+// ReportSpam records a user's report that a phone number is spam.
+func (s *spamService) ReportSpam(ctx context.Context, reporterPhoneNumber, phoneNumber, reason string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	report := &models.SpamReport{
+		ReporterPhoneNumber: reporterPhoneNumber,
+		PhoneNumber:         phoneNumber,
+		Reason:              strings.TrimSpace(reason),
+		ReportedAt:          time.Now(),
+	}
+	if err := report.Validate(); err != nil {
+		return err
+	}
+	return s.spamReportDAO.CreateSpamReport(ctx, report)
 }
 
 var _ SpamService = (*spamService)(nil)
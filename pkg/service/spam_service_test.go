@@ -11,11 +11,12 @@ import (
 
 func TestSpamService_UpdateSpamStatus(t *testing.T) {
 	tests := []struct {
-		name      string
-		ctx       context.Context
-		users     []*models.User
-		mockSetup func(m *mock.SpamUserDAOMock)
-		wantErr   bool
+		name        string
+		ctx         context.Context
+		users       []*models.User
+		mockSetup   func(m *mock.SpamUserDAOMock)
+		wantErr     bool
+		wantApplied int
 	}{
 		{
 			name:  "happy path - marks spam for some users",
@@ -27,7 +28,8 @@ func TestSpamService_UpdateSpamStatus(t *testing.T) {
 					return []*models.User{{PhoneNumber: "919876543210", Name: "Alice", IsSpam: false}}, nil
 				}
 			},
-			wantErr: false,
+			wantErr:     false,
+			wantApplied: 1,
 		},
 		{
 			name:  "no users",
@@ -66,6 +68,24 @@ func TestSpamService_UpdateSpamStatus(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name:  "DAO error after some updates returns the applied changes",
+			ctx:   context.Background(),
+			users: []*models.User{{PhoneNumber: "919876543210", Name: "Alice"}, {PhoneNumber: "919876543211", Name: "Bob"}},
+			mockSetup: func(m *mock.SpamUserDAOMock) {
+				m.OnGetAllUsers = func(ctx context.Context) ([]*models.User, error) {
+					return []*models.User{{PhoneNumber: "919876543210", Name: "Alice"}, {PhoneNumber: "919876543211", Name: "Bob"}}, nil
+				}
+				m.OnUpdateSpamStatus = func(ctx context.Context, phone string, isSpam bool) error {
+					if phone == "919876543211" {
+						return errors.New("dao error")
+					}
+					return nil
+				}
+			},
+			wantErr:     true,
+			wantApplied: 1,
+		},
 		{
 			name:  "all users already spam",
 			ctx:   context.Background(),
@@ -86,11 +106,106 @@ func TestSpamService_UpdateSpamStatus(t *testing.T) {
 			if tc.mockSetup != nil {
 				tc.mockSetup(userDAO)
 			}
-			svc := NewSpamService(userDAO)
-			err := svc.UpdateSpamStatus(tc.ctx)
+			svc := NewSpamService(userDAO, &mock.SpamReportDAOMock{})
+			applied, err := svc.UpdateSpamStatus(tc.ctx)
 			if (err != nil) != tc.wantErr {
 				t.Errorf("expected error: %v, got: %v", tc.wantErr, err)
 			}
+			if applied == nil || len(applied) != tc.wantApplied {
+				t.Errorf("expected %d applied changes, got %+v", tc.wantApplied, applied)
+			}
 		})
 	}
 }
+
+// Test cases for SpamService.ReportSpam
+func TestSpamService_ReportSpam(t *testing.T) {
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		reporter  string
+		phone     string
+		reason    string
+		createErr error
+		wantErr   bool
+	}{
+		{name: "valid report", ctx: context.Background(), reporter: "919876543210", phone: "919123456789", reason: " loan scam ", wantErr: false},
+		{name: "invalid reported number", ctx: context.Background(), reporter: "919876543210", phone: "123", wantErr: true},
+		{name: "self report", ctx: context.Background(), reporter: "919876543210", phone: "919876543210", wantErr: true},
+		{name: "DAO returns error", ctx: context.Background(), reporter: "919876543210", phone: "919123456789", createErr: errors.New("dao error"), wantErr: true},
+		{
+			name:     "context canceled",
+			ctx:      func() context.Context { c, cancel := context.WithCancel(context.Background()); cancel(); return c }(),
+			reporter: "919876543210",
+			phone:    "919123456789",
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var stored *models.SpamReport
+			reportDAO := &mock.SpamReportDAOMock{
+				OnCreateSpamReport: func(ctx context.Context, report *models.SpamReport) error {
+					stored = report
+					return tc.createErr
+				},
+			}
+			svc := NewSpamService(&mock.SpamUserDAOMock{}, reportDAO)
+			err := svc.ReportSpam(tc.ctx, tc.reporter, tc.phone, tc.reason)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error: %v, got: %v", tc.wantErr, err)
+			}
+			if !tc.wantErr && (stored.GetReason() != "loan scam" || stored.ReportedAt.IsZero()) {
+				t.Errorf("unexpected stored report %+v", stored)
+			}
+		})
+	}
+}
+
+func TestSpamService_UpdateSpamStatus_RecordsHistory(t *testing.T) {
+	userDAO := &mock.SpamUserDAOMock{
+		OnGetAllUsers: func(ctx context.Context) ([]*models.User, error) {
+			return []*models.User{{PhoneNumber: "919876543210", Name: "Alice"}, {PhoneNumber: "919876543211", Name: "Bob", IsSpam: true}}, nil
+		},
+	}
+	var changes []*models.SpamStatusChange
+	reportDAO := &mock.SpamReportDAOMock{
+		OnAddSpamStatusChange: func(ctx context.Context, change *models.SpamStatusChange) error {
+			changes = append(changes, change)
+			return nil
+		},
+	}
+	applied, err := NewSpamService(userDAO, reportDAO).UpdateSpamStatus(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(changes) != 1 || changes[0].PhoneNumber != "919876543210" || !changes[0].IsSpam {
+		t.Errorf("expected one recorded change for Alice, got %+v", changes)
+	}
+	if len(applied) != 1 || applied[0] != changes[0] {
+		t.Errorf("expected the recorded change to be returned, got %+v", applied)
+	}
+}
+
+func TestSpamService_PlanSpamStatusUpdate(t *testing.T) {
+	userDAO := &mock.SpamUserDAOMock{
+		OnGetAllUsers: func(ctx context.Context) ([]*models.User, error) {
+			return []*models.User{{PhoneNumber: "919876543210", Name: "Alice"}, {PhoneNumber: "919876543211", Name: "Bob", IsSpam: true}}, nil
+		},
+		OnUpdateSpamStatus: func(ctx context.Context, phoneNumber string, isSpam bool) error {
+			t.Errorf("expected no status update, got %s", phoneNumber)
+			return nil
+		},
+	}
+	reportDAO := &mock.SpamReportDAOMock{
+		OnAddSpamStatusChange: func(ctx context.Context, change *models.SpamStatusChange) error {
+			t.Errorf("expected no recorded change, got %+v", change)
+			return nil
+		},
+	}
+	changes, err := NewSpamService(userDAO, reportDAO).PlanSpamStatusUpdate(context.Background())
+	if err != nil || len(changes) != 1 || changes[0].PhoneNumber != "919876543210" || !changes[0].IsSpam || changes[0].ChangedAt.IsZero() {
+		t.Errorf("expected one planned change for Alice, got %+v (%v)", changes, err)
+	}
+}
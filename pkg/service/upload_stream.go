@@ -0,0 +1,129 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao/daoerrors"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+// DefaultStreamChunkSize is the number of contacts stored per DAO write when StreamUploadOptions.ChunkSize is unset.
+const DefaultStreamChunkSize = 500
+
+// maxStreamLineBytes bounds a single NDJSON line; a contact is far smaller than this.
+const maxStreamLineBytes = 64 * 1024
+
+// StreamUploadOptions configures UploadContactsStream.
+type StreamUploadOptions struct {
+	// ChunkSize is the number of valid contacts stored per DAO write. Defaults to DefaultStreamChunkSize.
+	ChunkSize int
+	// MaxContactsPerRequest caps the number of contacts read from one stream. Zero means no limit.
+	MaxContactsPerRequest int
+	// MaxContactsPerOwner caps the number of distinct contacts in the owner's phone book. Zero means no limit.
+	MaxContactsPerOwner int
+	// OnProgress, if set, is called after each chunk is stored and once more when the stream ends.
+	OnProgress func(models.UploadProgress)
+}
+
+// UploadContactsStream reads newline-delimited JSON contacts from r and merges them into the owner's phone book
+// in chunks, so the whole upload never has to be held in memory.
+// Unlike UploadContacts, existing contacts are kept: each contact replaces the entry with the same phone number.
+// Malformed or invalid lines are skipped and reported by index (blank lines are ignored and not counted).
+// If a limit is exceeded or r fails, the error is returned together with the report of what was already stored.
+// A line longer than 64 KiB ends the upload with a *models.ValidationError carrying the line's contact index.
+func (s *userService) UploadContactsStream(ctx context.Context, ownerPhoneNumber string, r io.Reader, opts StreamUploadOptions) (*models.UploadReport, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err := (&models.PhoneBook{PhoneNumber: ownerPhoneNumber}).Validate(); err != nil {
+		return nil, err
+	}
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultStreamChunkSize
+	}
+	existing, err := s.phoneBookDAO.GetPhoneBookByUserPhoneNumber(ctx, ownerPhoneNumber)
+	if err != nil && !errors.Is(err, daoerrors.ErrPhoneBookNotFound) {
+		return nil, err
+	}
+	known := make(map[string]struct{}, len(existing.GetContacts()))
+	for _, c := range existing.GetContacts() {
+		known[c.GetPhoneNumber()] = struct{}{}
+	}
+
+	report := &models.UploadReport{Rejected: []models.RejectedContact{}}
+	chunk := make([]models.Contact, 0, chunkSize)
+	flush := func() error {
+		if len(chunk) > 0 {
+			for _, c := range chunk {
+				known[c.GetPhoneNumber()] = struct{}{}
+			}
+			if opts.MaxContactsPerOwner > 0 && len(known) > opts.MaxContactsPerOwner {
+				return ErrOwnerLimitExceeded
+			}
+			if err := s.phoneBookDAO.UpsertContacts(ctx, ownerPhoneNumber, chunk); err != nil {
+				return err
+			}
+			if err := s.resolver.resolveAll(ctx, chunk); err != nil {
+				return err
+			}
+			report.Accepted += len(chunk)
+			chunk = chunk[:0]
+		}
+		if opts.OnProgress != nil {
+			opts.OnProgress(models.UploadProgress{Processed: report.Total, Accepted: report.Accepted, Rejected: len(report.Rejected)})
+		}
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxStreamLineBytes)
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return report, ctx.Err()
+		}
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		index := report.Total
+		if opts.MaxContactsPerRequest > 0 && index >= opts.MaxContactsPerRequest {
+			return report, ErrRequestLimitExceeded
+		}
+		report.Total++
+		var c models.Contact
+		if err := json.Unmarshal(line, &c); err != nil {
+			report.Rejected = append(report.Rejected, models.RejectedContact{
+				Index:   index,
+				Reasons: []models.ErrorDetail{{Code: models.CodeMalformed, Message: "malformed contact: " + err.Error()}},
+			})
+			continue
+		}
+		if err := c.Validate(); err != nil {
+			report.Rejected = append(report.Rejected, models.NewRejectedContact(index, c, err))
+			continue
+		}
+		chunk = append(chunk, normalizeContact(c))
+		if len(chunk) >= chunkSize {
+			if err := flush(); err != nil {
+				return report, err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return report, &models.ValidationError{Field: "contacts", Index: report.Total, Code: models.CodeTooLong, Message: fmt.Sprintf("line exceeds %d bytes", maxStreamLineBytes)}
+		}
+		return report, err
+	}
+	if err := flush(); err != nil {
+		return report, err
+	}
+	return report, nil
+}
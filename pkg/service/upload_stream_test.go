@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao/daoerrors"
+	"github.com/yourusername/truecaller-lite/pkg/dao/mem"
+	"github.com/yourusername/truecaller-lite/pkg/dao/mock"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+// Test cases for UserService.UploadContactsStream
+func TestUserService_UploadContactsStream(t *testing.T) {
+	validStream := strings.Join([]string{
+		`{"phone_number":"919123456781","name":"A"}`,
+		`{"phone_number":"919123456782","name":"B"}`,
+		``,
+		`not json`,
+		`{"phone_number":"123","name":"C"}`,
+		`{"phone_number":"919123456783","name":"D"}`,
+	}, "\n")
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		owner        string
+		body         string
+		opts         StreamUploadOptions
+		existing     *models.PhoneBook
+		upsertErr    error
+		wantTotal    int
+		wantAccepted int
+		wantRejected []int
+		wantChunks   int
+		wantErr      error
+	}{
+		{
+			name:         "stores valid contacts in chunks and reports rejected lines",
+			ctx:          context.Background(),
+			owner:        "919876543210",
+			body:         validStream,
+			opts:         StreamUploadOptions{ChunkSize: 2},
+			wantTotal:    5,
+			wantAccepted: 3,
+			wantRejected: []int{2, 3},
+			wantChunks:   2,
+		},
+		{
+			name:         "per-request limit exceeded",
+			ctx:          context.Background(),
+			owner:        "919876543210",
+			body:         validStream,
+			opts:         StreamUploadOptions{ChunkSize: 1, MaxContactsPerRequest: 2},
+			wantTotal:    2,
+			wantAccepted: 2,
+			wantRejected: []int{},
+			wantChunks:   2,
+			wantErr:      ErrRequestLimitExceeded,
+		},
+		{
+			name:         "per-owner limit counts existing contacts",
+			ctx:          context.Background(),
+			owner:        "919876543210",
+			body:         validStream,
+			opts:         StreamUploadOptions{ChunkSize: 1, MaxContactsPerOwner: 2},
+			existing:     &models.PhoneBook{PhoneNumber: "919876543210", Contacts: []models.Contact{{PhoneNumber: "919000000001", Name: "Old"}}},
+			wantTotal:    2,
+			wantAccepted: 1,
+			wantRejected: []int{},
+			wantChunks:   1,
+			wantErr:      ErrOwnerLimitExceeded,
+		},
+		{
+			name:         "re-uploading existing contacts does not count against owner limit",
+			ctx:          context.Background(),
+			owner:        "919876543210",
+			body:         `{"phone_number":"919000000001","name":"New"}`,
+			opts:         StreamUploadOptions{MaxContactsPerOwner: 1},
+			existing:     &models.PhoneBook{PhoneNumber: "919876543210", Contacts: []models.Contact{{PhoneNumber: "919000000001", Name: "Old"}}},
+			wantTotal:    1,
+			wantAccepted: 1,
+			wantRejected: []int{},
+			wantChunks:   1,
+		},
+		{
+			name:         "over-long line",
+			ctx:          context.Background(),
+			owner:        "919876543210",
+			body:         `{"phone_number":"919123456781","name":"A"}` + "\n" + strings.Repeat("x", maxStreamLineBytes+1),
+			opts:         StreamUploadOptions{ChunkSize: 1},
+			wantTotal:    1,
+			wantAccepted: 1,
+			wantChunks:   1,
+			wantErr:      errors.New("validation error"),
+		},
+		{
+			name:      "DAO returns error",
+			ctx:       context.Background(),
+			owner:     "919876543210",
+			body:      validStream,
+			upsertErr: errors.New("dao error"),
+			wantTotal: 5,
+			wantErr:   errors.New("dao error"),
+		},
+		{
+			name:    "invalid owner phone number",
+			ctx:     context.Background(),
+			owner:   "123",
+			body:    validStream,
+			wantErr: errors.New("validation error"),
+		},
+		{
+			name:    "context canceled",
+			ctx:     func() context.Context { c, cancel := context.WithCancel(context.Background()); cancel(); return c }(),
+			owner:   "919876543210",
+			body:    validStream,
+			wantErr: context.Canceled,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			chunks := 0
+			phoneBookDAO := &mock.PhoneBookDAOMock{
+				OnGetPhoneBookByUserPhoneNumber: func(ctx context.Context, phoneNumber string) (*models.PhoneBook, error) {
+					if tc.existing == nil {
+						return nil, daoerrors.ErrPhoneBookNotFound
+					}
+					return tc.existing, nil
+				},
+				OnUpsertContacts: func(ctx context.Context, owner string, contacts []models.Contact) error {
+					if tc.upsertErr != nil {
+						return tc.upsertErr
+					}
+					chunks++
+					return nil
+				},
+			}
+			var progress []models.UploadProgress
+			opts := tc.opts
+			opts.OnProgress = func(p models.UploadProgress) { progress = append(progress, p) }
+			svc := NewUserService(&mock.UserDAOMock{}, phoneBookDAO)
+			report, err := svc.UploadContactsStream(tc.ctx, tc.owner, strings.NewReader(tc.body), opts)
+			if (err != nil) != (tc.wantErr != nil) {
+				t.Fatalf("expected error: %v, got: %v", tc.wantErr, err)
+			}
+			if tc.wantErr == ErrRequestLimitExceeded || tc.wantErr == ErrOwnerLimitExceeded || tc.wantErr == context.Canceled {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("expected %v, got %v", tc.wantErr, err)
+				}
+			}
+			if report == nil {
+				return
+			}
+			if report.GetTotal() != tc.wantTotal || report.GetAccepted() != tc.wantAccepted {
+				t.Errorf("expected total %d accepted %d, got total %d accepted %d", tc.wantTotal, tc.wantAccepted, report.GetTotal(), report.GetAccepted())
+			}
+			if tc.wantErr == nil {
+				if len(report.GetRejected()) != len(tc.wantRejected) {
+					t.Fatalf("expected rejected %v, got %+v", tc.wantRejected, report.GetRejected())
+				}
+				for i, idx := range tc.wantRejected {
+					if report.GetRejected()[i].Index != idx {
+						t.Errorf("expected rejection at index %d, got %d", idx, report.GetRejected()[i].Index)
+					}
+				}
+				last := progress[len(progress)-1]
+				if last.Processed != tc.wantTotal || last.Accepted != tc.wantAccepted {
+					t.Errorf("unexpected final progress %+v", last)
+				}
+			}
+			if chunks != tc.wantChunks {
+				t.Errorf("expected %d chunks stored, got %d", tc.wantChunks, chunks)
+			}
+		})
+	}
+}
+
+func TestUserService_UploadContactsStream_LineTooLong(t *testing.T) {
+	body := `{"phone_number":"919123456781","name":"A"}` + "\n\n" + strings.Repeat("x", maxStreamLineBytes+1)
+	svc := NewUserService(mem.NewUserMemDAO(), mem.NewPhoneBookMemDAO())
+	_, err := svc.UploadContactsStream(context.Background(), "919876543210", strings.NewReader(body), StreamUploadOptions{})
+	var ve *models.ValidationError
+	if !errors.As(err, &ve) || ve.Index != 1 || ve.Code != models.CodeTooLong {
+		t.Errorf("expected a too_long validation error for contact 1, got %v", err)
+	}
+}
@@ -3,68 +3,251 @@ package service
 import (
 	"context"
 	"errors"
+	"io"
 
 	"github.com/yourusername/truecaller-lite/pkg/dao"
 	"github.com/yourusername/truecaller-lite/pkg/dao/daoerrors"
 	"github.com/yourusername/truecaller-lite/pkg/models"
+	"github.com/yourusername/truecaller-lite/pkg/moderation"
+	"github.com/yourusername/truecaller-lite/pkg/names"
 )
 
 // UserService defines the business logic contract for user-related operations.
 // All methods accept a context for timeouts and cancellations, and return errors for validation or business rule violations.
 type UserService interface {
 	UploadContacts(ctx context.Context, ownerPhoneNumber string, contacts []models.Contact) error
+	UploadContactsPartial(ctx context.Context, ownerPhoneNumber string, contacts []models.Contact) (*models.UploadReport, error)
+	UploadContactsStream(ctx context.Context, ownerPhoneNumber string, r io.Reader, opts StreamUploadOptions) (*models.UploadReport, error)
 	LookupUser(ctx context.Context, phoneNumber string) (name string, isSpam bool, err error)
+	LookupUserDetailed(ctx context.Context, phoneNumber string, alternatives int) (*models.LookupResult, error)
+	SetDisplayName(ctx context.Context, phoneNumber, displayName string) error
 }
 
+// MaxAlternatives caps the number of alternative names a detailed lookup returns.
+const MaxAlternatives = 10
+
 // userService implements UserService interface.
 type userService struct {
-	userDAO      dao.UserDAO
-	phoneBookDAO dao.PhoneBookDAO
+	userDAO       dao.UserDAO
+	phoneBookDAO  dao.PhoneBookDAO
+	privacyDAO    dao.PrivacyDAO
+	lexicon       *names.Lexicon
+	filter        moderation.Filter
+	moderationDAO dao.ModerationDAO
+	resolver      *nameResolver
+}
+
+// UserServiceOption configures optional UserService dependencies.
+type UserServiceOption func(*userService)
+
+// WithPrivacyDAO makes the service honour per-number privacy state, such as erasure tombstones and unlisting.
+func WithPrivacyDAO(privacyDAO dao.PrivacyDAO) UserServiceOption {
+	return func(s *userService) { s.privacyDAO = privacyDAO }
+}
+
+// WithNameLexicon sets the relationship words and placeholders excluded from name resolution, replacing
+// names.DefaultLexicon. A nil lexicon excludes nothing. Such contacts are still kept in the uploader's phone book.
+func WithNameLexicon(lexicon *names.Lexicon) UserServiceOption {
+	return func(s *userService) { s.lexicon = lexicon }
+}
+
+// WithNameFilter sets the moderation filter for crowd names and display names, replacing moderation.DefaultWordList.
+// Flagged crowd names are never chosen and flagged display names are rejected. A nil filter flags nothing.
+func WithNameFilter(filter moderation.Filter) UserServiceOption {
+	return func(s *userService) { s.filter = filter }
+}
+
+// WithModerationDAO records the crowd names suppressed by the moderation filter for moderator review.
+func WithModerationDAO(moderationDAO dao.ModerationDAO) UserServiceOption {
+	return func(s *userService) { s.moderationDAO = moderationDAO }
 }
 
 // NewUserService creates a new UserService instance.
-func NewUserService(userDAO dao.UserDAO, phoneBookDAO dao.PhoneBookDAO) UserService {
-	return &userService{userDAO: userDAO, phoneBookDAO: phoneBookDAO}
+func NewUserService(userDAO dao.UserDAO, phoneBookDAO dao.PhoneBookDAO, opts ...UserServiceOption) UserService {
+	return newUserService(userDAO, phoneBookDAO, opts...)
+}
+
+// newUserService creates a userService with the default lexicon and moderation filter, then applies opts.
+func newUserService(userDAO dao.UserDAO, phoneBookDAO dao.PhoneBookDAO, opts ...UserServiceOption) *userService {
+	s := &userService{userDAO: userDAO, phoneBookDAO: phoneBookDAO, lexicon: names.DefaultLexicon(), filter: moderation.DefaultWordList()}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.resolver = &nameResolver{
+		userDAO:       s.userDAO,
+		phoneBookDAO:  s.phoneBookDAO,
+		privacyDAO:    s.privacyDAO,
+		lexicon:       s.lexicon,
+		filter:        s.filter,
+		moderationDAO: s.moderationDAO,
+	}
+	return s
 }
 
 // UploadContacts uploads a list of contacts for a user (by phone number).
+// All invalid contacts are reported together as models.ValidationErrors; nothing is stored if any contact is invalid.
+// The names of every added or removed contact are re-resolved.
 func (s *userService) UploadContacts(ctx context.Context, ownerPhoneNumber string, contacts []models.Contact) error {
 	if ctx.Err() != nil {
 		return ctx.Err()
 	}
-	owner := &models.User{PhoneNumber: ownerPhoneNumber, Name: "dummy"}
-	if err := owner.Validate(); err != nil {
+	pb := &models.PhoneBook{PhoneNumber: ownerPhoneNumber, Contacts: contacts}
+	if err := pb.Validate(); err != nil {
 		return err
 	}
+	pb.Contacts = make([]models.Contact, 0, len(contacts))
 	for _, c := range contacts {
-		if err := c.Validate(); err != nil {
-			return err
-		}
+		pb.Contacts = append(pb.Contacts, normalizeContact(c))
+	}
+	return s.replacePhoneBook(ctx, pb)
+}
+
+// normalizeContact returns c with its NormalizedName and ParsedName set from its name.
+func normalizeContact(c models.Contact) models.Contact {
+	c.NormalizedName = names.Normalize(c.Name)
+	c.ParsedName = names.Parse(c.Name)
+	return c
+}
+
+// replacePhoneBook stores pb in place of the owner's current phone book and re-resolves the names of
+// contacts in either version.
+func (s *userService) replacePhoneBook(ctx context.Context, pb *models.PhoneBook) error {
+	previous, err := s.phoneBookDAO.GetPhoneBookByUserPhoneNumber(ctx, pb.GetPhoneNumber())
+	if err != nil && !errors.Is(err, daoerrors.ErrPhoneBookNotFound) {
+		return err
 	}
-	pb := &models.PhoneBook{PhoneNumber: ownerPhoneNumber, Contacts: contacts}
 	if err := s.phoneBookDAO.CreateOrUpdatePhoneBook(ctx, pb); err != nil {
 		return err
 	}
-	return nil
+	affected := append(append([]models.Contact(nil), previous.GetContacts()...), pb.GetContacts()...)
+	return s.resolver.resolveAll(ctx, affected)
+}
+
+// UploadContactsPartial uploads a list of contacts for a user, storing every valid contact and
+// reporting invalid ones by index instead of rejecting the whole upload.
+// An invalid owner phone number is still returned as an error and nothing is stored. If no contact is accepted,
+// the existing phone book is left untouched; use UploadContacts with no contacts to clear it.
+func (s *userService) UploadContactsPartial(ctx context.Context, ownerPhoneNumber string, contacts []models.Contact) (*models.UploadReport, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err := (&models.PhoneBook{PhoneNumber: ownerPhoneNumber}).Validate(); err != nil {
+		return nil, err
+	}
+	report := &models.UploadReport{Total: len(contacts), Rejected: []models.RejectedContact{}}
+	accepted := make([]models.Contact, 0, len(contacts))
+	for i, c := range contacts {
+		if err := c.Validate(); err != nil {
+			report.Rejected = append(report.Rejected, models.NewRejectedContact(i, c, err))
+			continue
+		}
+		accepted = append(accepted, normalizeContact(c))
+	}
+	if len(accepted) == 0 {
+		return report, nil
+	}
+	pb := &models.PhoneBook{PhoneNumber: ownerPhoneNumber, Contacts: accepted}
+	if err := s.replacePhoneBook(ctx, pb); err != nil {
+		return nil, err
+	}
+	report.Accepted = len(accepted)
+	return report, nil
 }
 
 // LookupUser looks up a user by phone number and returns their name and spam status.
+// The name is the owner's display name if set, otherwise the crowd-sourced name.
+// Returns ErrUnlisted if the number's owner has opted out of caller ID, whether or not a name is known; unlisting
+// hides only the name, so the spam status is still returned alongside ErrUnlisted.
 func (s *userService) LookupUser(ctx context.Context, phoneNumber string) (string, bool, error) {
+	user, err := s.lookup(ctx, phoneNumber)
+	if errors.Is(err, ErrUnlisted) {
+		return "", user.GetIsSpam(), err
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return user.PreferredName(), user.GetIsSpam(), nil
+}
+
+// LookupUserDetailed looks up a user like LookupUser and also returns up to alternatives candidate names
+// (at most MaxAlternatives), each with the number of distinct uploaders who saved the number under it. Spellings of
+// the same name, such as "Mohammed", "Muhammad" and "मोहम्मद", count as one candidate.
+// The resolved name is also returned split into its parts (names.Parse), so the organization can be shown apart.
+// For unlisted numbers it returns ErrUnlisted together with a result holding only the phone number and spam status.
+func (s *userService) LookupUserDetailed(ctx context.Context, phoneNumber string, alternatives int) (*models.LookupResult, error) {
+	user, err := s.lookup(ctx, phoneNumber)
+	if errors.Is(err, ErrUnlisted) {
+		return &models.LookupResult{PhoneNumber: phoneNumber, IsSpam: user.GetIsSpam(), Alternatives: []models.NameCandidate{}}, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := &models.LookupResult{PhoneNumber: phoneNumber, Name: user.PreferredName(), IsSpam: user.GetIsSpam(), Person: names.Parse(user.PreferredName()), Alternatives: []models.NameCandidate{}}
+	if alternatives = min(alternatives, MaxAlternatives); alternatives <= 0 {
+		return result, nil
+	}
+	entries, err := s.resolver.entries(ctx, phoneNumber)
+	if err != nil {
+		return nil, err
+	}
+	result.Alternatives = s.resolver.nameCandidates(entries, alternatives)
+	return result, nil
+}
+
+// lookup returns the User record for phoneNumber. If the owner has opted out of caller ID it returns ErrUnlisted
+// together with a User carrying only the phone number and spam status (nil if the number is not known).
+func (s *userService) lookup(ctx context.Context, phoneNumber string) (*models.User, error) {
 	if ctx.Err() != nil {
-		return "", false, ctx.Err()
+		return nil, ctx.Err()
 	}
 	tmp := &models.User{PhoneNumber: phoneNumber, Name: "dummy"}
 	if err := tmp.Validate(); err != nil {
-		return "", false, err
+		return nil, err
 	}
+	if s.privacyDAO != nil {
+		unlisted, err := s.privacyDAO.IsUnlisted(ctx, phoneNumber)
+		if err != nil {
+			return nil, err
+		}
+		if unlisted {
+			return s.unlistedUser(ctx, phoneNumber)
+		}
+	}
+	return s.userDAO.GetUserByPhoneNumber(ctx, phoneNumber)
+}
+
+// unlistedUser returns the spam status of an unlisted number without its names, with ErrUnlisted.
+func (s *userService) unlistedUser(ctx context.Context, phoneNumber string) (*models.User, error) {
 	user, err := s.userDAO.GetUserByPhoneNumber(ctx, phoneNumber)
+	if errors.Is(err, daoerrors.ErrUserNotFound) {
+		return nil, ErrUnlisted
+	}
 	if err != nil {
-		if errors.Is(err, daoerrors.ErrUserNotFound) {
-			return "", false, err
+		return nil, err
+	}
+	return &models.User{PhoneNumber: phoneNumber, IsSpam: user.GetIsSpam()}, ErrUnlisted
+}
+
+// SetDisplayName sets the name the number's owner chose for themselves; an empty displayName clears it.
+// Callers must ensure the request comes from the verified owner of the number. The crowd-sourced name is kept,
+// even if it is re-resolved concurrently.
+// Display names flagged by the moderation filter are rejected with a models.CodeOffensive validation error.
+func (s *userService) SetDisplayName(ctx context.Context, phoneNumber, displayName string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err := (&models.PhoneBook{PhoneNumber: phoneNumber}).Validate(); err != nil {
+		return err
+	}
+	if displayName != "" {
+		if err := (&models.User{PhoneNumber: phoneNumber, DisplayName: displayName}).Validate(); err != nil {
+			return err
+		}
+		if s.filter != nil && s.filter.Offensive(displayName) {
+			return &models.ValidationError{Field: "display_name", Index: models.NoIndex, Code: models.CodeOffensive, Message: "display name contains offensive words"}
 		}
-		return "", false, err
 	}
-	return user.GetName(), user.GetIsSpam(), nil
+	return s.userDAO.UpdateDisplayName(ctx, phoneNumber, displayName)
 }
 
 var _ UserService = (*userService)(nil)
@@ -82,6 +82,126 @@ func TestUserService_UploadContacts(t *testing.T) {
 	}
 }
 
+// Test cases for UserService.UploadContactsPartial
+func TestUserService_UploadContactsPartial(t *testing.T) {
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		owner        string
+		contacts     []models.Contact
+		mockSetup    func(pb *mock.PhoneBookDAOMock, stored *[]models.Contact)
+		wantAccepted int
+		wantRejected []int
+		wantErr      bool
+	}{
+		{
+			name:  "mixed valid and invalid contacts",
+			ctx:   context.Background(),
+			owner: "919876543210",
+			contacts: []models.Contact{
+				{PhoneNumber: "919123456789", Name: "Bob"},
+				{PhoneNumber: "123", Name: "Bad Number"},
+				{PhoneNumber: "919123456780", Name: "Carol"},
+				{PhoneNumber: "919123456781", Name: ""},
+			},
+			mockSetup: func(pb *mock.PhoneBookDAOMock, stored *[]models.Contact) {
+				pb.OnCreateOrUpdatePhoneBook = func(ctx context.Context, phoneBook *models.PhoneBook) error {
+					*stored = phoneBook.GetContacts()
+					return nil
+				}
+			},
+			wantAccepted: 2,
+			wantRejected: []int{1, 3},
+			wantErr:      false,
+		},
+		{
+			name:     "all contacts valid",
+			ctx:      context.Background(),
+			owner:    "919876543210",
+			contacts: []models.Contact{{PhoneNumber: "919123456789", Name: "Bob"}},
+			mockSetup: func(pb *mock.PhoneBookDAOMock, stored *[]models.Contact) {
+				pb.OnCreateOrUpdatePhoneBook = func(ctx context.Context, phoneBook *models.PhoneBook) error {
+					*stored = phoneBook.GetContacts()
+					return nil
+				}
+			},
+			wantAccepted: 1,
+			wantRejected: []int{},
+			wantErr:      false,
+		},
+		{
+			name:     "all contacts rejected leaves the phone book untouched",
+			ctx:      context.Background(),
+			owner:    "919876543210",
+			contacts: []models.Contact{{PhoneNumber: "123", Name: "Bad Number"}, {PhoneNumber: "919123456781", Name: ""}},
+			mockSetup: func(pb *mock.PhoneBookDAOMock, stored *[]models.Contact) {
+				pb.OnCreateOrUpdatePhoneBook = func(ctx context.Context, phoneBook *models.PhoneBook) error {
+					return errors.New("phone book must not be replaced")
+				}
+			},
+			wantAccepted: 0,
+			wantRejected: []int{0, 1},
+			wantErr:      false,
+		},
+		{
+			name:      "invalid owner phone number",
+			ctx:       context.Background(),
+			owner:     "123",
+			contacts:  []models.Contact{{PhoneNumber: "919123456789", Name: "Bob"}},
+			mockSetup: func(pb *mock.PhoneBookDAOMock, stored *[]models.Contact) {},
+			wantErr:   true,
+		},
+		{
+			name:      "context canceled",
+			ctx:       func() context.Context { c, cancel := context.WithCancel(context.Background()); cancel(); return c }(),
+			owner:     "919876543210",
+			contacts:  []models.Contact{{PhoneNumber: "919123456789", Name: "Bob"}},
+			mockSetup: func(pb *mock.PhoneBookDAOMock, stored *[]models.Contact) {},
+			wantErr:   true,
+		},
+		{
+			name:     "DAO returns error",
+			ctx:      context.Background(),
+			owner:    "919876543210",
+			contacts: []models.Contact{{PhoneNumber: "919123456789", Name: "Bob"}},
+			mockSetup: func(pb *mock.PhoneBookDAOMock, stored *[]models.Contact) {
+				pb.OnCreateOrUpdatePhoneBook = func(ctx context.Context, phoneBook *models.PhoneBook) error { return errors.New("dao error") }
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			phoneBookDAO := &mock.PhoneBookDAOMock{}
+			var stored []models.Contact
+			if tc.mockSetup != nil {
+				tc.mockSetup(phoneBookDAO, &stored)
+			}
+			svc := NewUserService(&mock.UserDAOMock{}, phoneBookDAO)
+			report, err := svc.UploadContactsPartial(tc.ctx, tc.owner, tc.contacts)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error: %v, got: %v", tc.wantErr, err)
+			}
+			if tc.wantErr {
+				return
+			}
+			if report.GetAccepted() != tc.wantAccepted || len(stored) != tc.wantAccepted {
+				t.Errorf("expected %d accepted, got report %d stored %d", tc.wantAccepted, report.GetAccepted(), len(stored))
+			}
+			if len(report.GetRejected()) != len(tc.wantRejected) {
+				t.Fatalf("expected %d rejected, got %+v", len(tc.wantRejected), report.GetRejected())
+			}
+			for i, idx := range tc.wantRejected {
+				rejected := report.GetRejected()[i]
+				if rejected.Index != idx || len(rejected.Reasons) == 0 {
+					t.Errorf("expected rejection at index %d with reasons, got %+v", idx, rejected)
+				}
+			}
+		})
+	}
+}
+
 // Test cases for UserService.LookupUser
 func TestUserService_LookupUser(t *testing.T) {
 	tests := []struct {
@@ -172,3 +292,29 @@ func TestUserService_LookupUser(t *testing.T) {
 		})
 	}
 }
+
+func TestUserService_LookupUser_Unlisted(t *testing.T) {
+	userDAO := &mock.UserDAOMock{
+		OnGetUserByPhoneNumber: func(ctx context.Context, phone string) (*models.User, error) {
+			return &models.User{PhoneNumber: phone, Name: "Alice", IsSpam: true}, nil
+		},
+	}
+	privacyDAO := &mock.PrivacyDAOMock{
+		OnIsUnlisted: func(ctx context.Context, phone string) (bool, error) { return phone == "919876543210", nil },
+	}
+	svc := NewUserService(userDAO, nil, WithPrivacyDAO(privacyDAO))
+	if name, spam, err := svc.LookupUser(context.Background(), "919876543210"); !errors.Is(err, ErrUnlisted) || name != "" || !spam {
+		t.Errorf("expected ErrUnlisted with the spam status but without a name, got %q %v (%v)", name, spam, err)
+	}
+	result, err := svc.LookupUserDetailed(context.Background(), "919876543210", 3)
+	if !errors.Is(err, ErrUnlisted) || result.Name != "" || result.Person != nil || !result.IsSpam {
+		t.Errorf("expected ErrUnlisted with only the spam status, got %+v (%v)", result, err)
+	}
+	if name, _, err := svc.LookupUser(context.Background(), "919876543211"); err != nil || name != "Alice" {
+		t.Errorf("expected Alice for a listed number, got %q (%v)", name, err)
+	}
+	privacyDAO.OnIsUnlisted = func(ctx context.Context, phone string) (bool, error) { return false, errors.New("dao error") }
+	if _, _, err := svc.LookupUser(context.Background(), "919876543210"); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
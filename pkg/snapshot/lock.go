@@ -0,0 +1,43 @@
+package snapshot
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// LockFileName is the name of the lock file in a data directory.
+const LockFileName = "LOCK"
+
+// ErrLocked is returned by Lock when another process holds the lock of a data directory.
+var ErrLocked = errors.New("data directory is in use by another process")
+
+// DirLock is the held lock of a data directory. Only one process at a time may hold it, so a server and
+// truecallerctl never load and save the same directory concurrently, losing each other's changes.
+type DirLock struct {
+	f *os.File
+}
+
+// Lock takes the lock of dir, creating the directory if needed. It fails with ErrLocked if another process holds
+// the lock. The lock is released by Unlock, or by the operating system when the process exits, however it exits.
+// On systems without flock Lock always succeeds.
+func Lock(dir string) (*DirLock, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(filepath.Join(dir, LockFileName), os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	if err := lockFile(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("locking %s: %w", dir, err)
+	}
+	return &DirLock{f: f}, nil
+}
+
+// Unlock releases the lock. The lock file is left in place; its presence alone does not lock the directory.
+func (l *DirLock) Unlock() error {
+	return l.f.Close()
+}
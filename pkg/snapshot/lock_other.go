@@ -0,0 +1,13 @@
+//go:build !unix
+
+package snapshot
+
+import "os"
+
+// canLock reports whether lockFile locks.
+const canLock = false
+
+// lockFile does nothing: this system has no flock, so data directories are not locked.
+func lockFile(f *os.File) error {
+	return nil
+}
@@ -0,0 +1,21 @@
+//go:build unix
+
+package snapshot
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// canLock reports whether lockFile locks.
+const canLock = true
+
+// lockFile takes an exclusive, non-blocking flock on f, returning ErrLocked if another process holds it.
+func lockFile(f *os.File) error {
+	err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	if errors.Is(err, syscall.EWOULDBLOCK) {
+		return ErrLocked
+	}
+	return err
+}
@@ -0,0 +1,220 @@
+// Package snapshot copies the durable state behind the DAO interfaces to and from a single JSON document, and
+// keeps that document in a data directory so in-memory storage can outlive the process.
+//
+// A snapshot holds users, phone books, spam reports and status history, privacy settings and name moderation
+// state. Short-lived state (OTP challenges, the lookup log, enumeration offenders) is left out.
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+// Version is the snapshot format version written by this package.
+const Version = 1
+
+// FileName is the name of the snapshot file in a data directory.
+const FileName = "snapshot.json"
+
+// ErrUnsupportedVersion is returned by Read for snapshots written in a newer or unknown format.
+var ErrUnsupportedVersion = errors.New("unsupported snapshot version")
+
+// Snapshot is a point-in-time copy of the durable state.
+type Snapshot struct {
+	// Version is the format version; see Version.
+	Version int `json:"version"`
+	// CreatedAt is when the snapshot was taken.
+	CreatedAt time.Time `json:"created_at"`
+	// Users lists the resolved user records, ordered by phone number.
+	Users []models.User `json:"users"`
+	// PhoneBooks lists the uploaded phone books, ordered by owner.
+	PhoneBooks []models.PhoneBook `json:"phone_books"`
+	// SpamReports lists the filed spam reports, ordered by reporter.
+	SpamReports []models.SpamReport `json:"spam_reports"`
+	// SpamStatusHistory lists the spam status changes, ordered by phone number, then oldest first.
+	SpamStatusHistory []models.SpamStatusChange `json:"spam_status_history"`
+	// Privacy lists the numbers that are erased, unlisted or opted out of lookup logs.
+	Privacy []models.PrivacySettings `json:"privacy"`
+	// NameModerations lists the numbers with suppressed names.
+	NameModerations []models.NameModeration `json:"name_moderations"`
+}
+
+// Stores are the DAOs a snapshot is taken from or restored into.
+type Stores struct {
+	Users       dao.UserDAO
+	PhoneBooks  dao.PhoneBookDAO
+	SpamReports dao.SpamReportDAO
+	Privacy     dao.PrivacyDAO
+	Moderation  dao.ModerationDAO
+}
+
+// Take copies the current contents of stores into a new snapshot.
+func Take(ctx context.Context, stores Stores) (*Snapshot, error) {
+	snap := &Snapshot{Version: Version, CreatedAt: time.Now().UTC()}
+	users, err := stores.Users.GetAllUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing users: %w", err)
+	}
+	snap.Users = values(users)
+	sort.Slice(snap.Users, func(i, j int) bool { return snap.Users[i].PhoneNumber < snap.Users[j].PhoneNumber })
+	phoneBooks, err := stores.PhoneBooks.ListPhoneBooks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing phone books: %w", err)
+	}
+	snap.PhoneBooks = values(phoneBooks)
+	reports, err := stores.SpamReports.ListSpamReports(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing spam reports: %w", err)
+	}
+	snap.SpamReports = values(reports)
+	changes, err := stores.SpamReports.ListSpamStatusChanges(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing spam status history: %w", err)
+	}
+	snap.SpamStatusHistory = values(changes)
+	settings, err := stores.Privacy.ListPrivacySettings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing privacy settings: %w", err)
+	}
+	snap.Privacy = values(settings)
+	moderations, err := stores.Moderation.ListNameModerations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing name moderations: %w", err)
+	}
+	snap.NameModerations = values(moderations)
+	return snap, nil
+}
+
+// Restore writes every record in snap into stores, validating each as the DAOs do. It is meant for empty
+// stores: existing users, phone books and moderation state with the same keys are replaced, but spam reports
+// and status history are appended. Phone book entries are recorded as saved at the time of the restore.
+func Restore(ctx context.Context, stores Stores, snap *Snapshot) error {
+	for i := range snap.Privacy {
+		settings := &snap.Privacy[i]
+		if settings.Erased {
+			// Snapshots written before erasure times were kept restart the tombstone's retention
+			erasedAt := time.Now().UTC()
+			if settings.ErasedAt != nil {
+				erasedAt = *settings.ErasedAt
+			}
+			if err := stores.Privacy.CreateTombstone(ctx, settings.PhoneNumber, erasedAt); err != nil {
+				return fmt.Errorf("privacy[%d]: %w", i, err)
+			}
+		}
+		if settings.Unlisted {
+			if err := stores.Privacy.SetUnlisted(ctx, settings.PhoneNumber, true); err != nil {
+				return fmt.Errorf("privacy[%d]: %w", i, err)
+			}
+		}
+		if settings.LookupLogOptOut {
+			if err := stores.Privacy.SetLookupLogOptOut(ctx, settings.PhoneNumber, true); err != nil {
+				return fmt.Errorf("privacy[%d]: %w", i, err)
+			}
+		}
+	}
+	for i := range snap.PhoneBooks {
+		if err := stores.PhoneBooks.CreateOrUpdatePhoneBook(ctx, &snap.PhoneBooks[i]); err != nil {
+			return fmt.Errorf("phone_books[%d]: %w", i, err)
+		}
+	}
+	for i := range snap.Users {
+		if err := stores.Users.CreateOrUpdateUser(ctx, &snap.Users[i]); err != nil {
+			return fmt.Errorf("users[%d]: %w", i, err)
+		}
+	}
+	for i := range snap.SpamReports {
+		if err := stores.SpamReports.CreateSpamReport(ctx, &snap.SpamReports[i]); err != nil {
+			return fmt.Errorf("spam_reports[%d]: %w", i, err)
+		}
+	}
+	for i := range snap.SpamStatusHistory {
+		if err := stores.SpamReports.AddSpamStatusChange(ctx, &snap.SpamStatusHistory[i]); err != nil {
+			return fmt.Errorf("spam_status_history[%d]: %w", i, err)
+		}
+	}
+	for i := range snap.NameModerations {
+		if err := stores.Moderation.SaveNameModeration(ctx, &snap.NameModerations[i]); err != nil {
+			return fmt.Errorf("name_moderations[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Read decodes a snapshot written by Write.
+func Read(r io.Reader) (*Snapshot, error) {
+	var snap Snapshot
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&snap); err != nil {
+		return nil, fmt.Errorf("decoding snapshot: %w", err)
+	}
+	if snap.Version != Version {
+		return nil, fmt.Errorf("%w %d", ErrUnsupportedVersion, snap.Version)
+	}
+	return &snap, nil
+}
+
+// Write encodes the snapshot as indented JSON.
+func (s *Snapshot) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
+
+// Load reads the snapshot in dir. A directory without a snapshot file yields an empty snapshot.
+func Load(dir string) (*Snapshot, error) {
+	f, err := os.Open(filepath.Join(dir, FileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Snapshot{Version: Version}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+// Save writes snap to dir, creating the directory if needed. The previous snapshot is replaced atomically, so a
+// failed save leaves it intact.
+func Save(dir string, snap *Snapshot) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, FileName+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := snap.Write(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(dir, FileName))
+}
+
+// values dereferences records into a non-nil slice, so empty sections encode as [] rather than null.
+func values[T any](records []*T) []T {
+	result := make([]T, 0, len(records))
+	for _, record := range records {
+		result = append(result, *record)
+	}
+	return result
+}
@@ -0,0 +1,195 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/truecaller-lite/pkg/dao/mem"
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+func newStores() Stores {
+	return Stores{
+		Users:       mem.NewUserMemDAO(),
+		PhoneBooks:  mem.NewPhoneBookMemDAO(),
+		SpamReports: mem.NewSpamReportMemDAO(),
+		Privacy:     mem.NewPrivacyMemDAO(),
+		Moderation:  mem.NewModerationMemDAO(),
+	}
+}
+
+// fill stores one record of every kind.
+func fill(t *testing.T, stores Stores) {
+	t.Helper()
+	ctx := context.Background()
+	changedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, err := range []error{
+		stores.PhoneBooks.CreateOrUpdatePhoneBook(ctx, &models.PhoneBook{PhoneNumber: "919876543210", Contacts: []models.Contact{{PhoneNumber: "919123456789", Name: "Bob", NormalizedName: "Bob"}}}),
+		stores.Users.CreateOrUpdateUser(ctx, &models.User{PhoneNumber: "919123456789", Name: "Bob", IsSpam: true}),
+		stores.Users.CreateOrUpdateUser(ctx, &models.User{PhoneNumber: "919876543210", DisplayName: "Alice"}),
+		stores.SpamReports.CreateSpamReport(ctx, &models.SpamReport{ReporterPhoneNumber: "919876543210", PhoneNumber: "919123456789", Reason: "loan scam", ReportedAt: changedAt}),
+		stores.SpamReports.AddSpamStatusChange(ctx, &models.SpamStatusChange{PhoneNumber: "919123456789", IsSpam: true, ChangedAt: changedAt}),
+		stores.Privacy.SetUnlisted(ctx, "919876543210", true),
+		stores.Privacy.CreateTombstone(ctx, "919000000001", changedAt),
+		stores.Moderation.SaveNameModeration(ctx, &models.NameModeration{PhoneNumber: "919123456789", Suppressed: []models.SuppressedName{{Name: "Idiot", UploaderPhoneNumber: "919876543210", SavedAt: changedAt}}, UpdatedAt: changedAt}),
+	} {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+// sections encodes everything in snap but the creation time, for comparison.
+func sections(t *testing.T, snap *Snapshot) string {
+	t.Helper()
+	copySnap := *snap
+	copySnap.CreatedAt = time.Time{}
+	b, err := json.Marshal(copySnap)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return string(b)
+}
+
+func TestSnapshot_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	source := newStores()
+	fill(t, source)
+	snap, err := Take(ctx, source)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(snap.Users) != 2 || snap.Users[0].PhoneNumber != "919123456789" || len(snap.PhoneBooks) != 1 || len(snap.SpamReports) != 1 ||
+		len(snap.SpamStatusHistory) != 1 || len(snap.Privacy) != 2 || len(snap.NameModerations) != 1 {
+		t.Fatalf("expected every record in the snapshot, got %+v", snap)
+	}
+
+	var buf bytes.Buffer
+	if err := snap.Write(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	read, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	target := newStores()
+	if err := Restore(ctx, target, read); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	restored, err := Take(ctx, target)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := sections(t, restored), sections(t, snap); got != want {
+		t.Errorf("expected restored stores to match the snapshot\n got: %s\nwant: %s", got, want)
+	}
+	if entries, _ := target.PhoneBooks.GetContactEntriesByPhoneNumber(ctx, "919123456789"); len(entries) != 1 {
+		t.Errorf("expected restored phone books to be reverse indexed, got %+v", entries)
+	}
+}
+
+func TestSnapshot_Empty(t *testing.T) {
+	snap, err := Take(context.Background(), newStores())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var buf bytes.Buffer
+	_ = snap.Write(&buf)
+	if strings.Contains(buf.String(), "null") {
+		t.Errorf("expected empty sections to encode as [], got %s", buf.String())
+	}
+}
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+		wantIs  error
+	}{
+		{name: "current version", input: `{"version":1,"users":[]}`},
+		{name: "newer version", input: `{"version":2}`, wantErr: true, wantIs: ErrUnsupportedVersion},
+		{name: "missing version", input: `{}`, wantErr: true, wantIs: ErrUnsupportedVersion},
+		{name: "unknown field", input: `{"version":1,"contacts":[]}`, wantErr: true},
+		{name: "not JSON", input: `users`, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Read(strings.NewReader(tc.input))
+			if (err != nil) != tc.wantErr || (tc.wantIs != nil && !errors.Is(err, tc.wantIs)) {
+				t.Errorf("expected error %v (%v), got %v", tc.wantErr, tc.wantIs, err)
+			}
+		})
+	}
+}
+
+func TestRestore_ValidationError(t *testing.T) {
+	snap := &Snapshot{Version: Version, PhoneBooks: []models.PhoneBook{
+		{PhoneNumber: "919876543210"},
+		{PhoneNumber: "919876543211", Contacts: []models.Contact{{PhoneNumber: "123", Name: "X"}}},
+	}}
+	err := Restore(context.Background(), newStores(), snap)
+	var ve *models.ValidationError
+	if !errors.As(err, &ve) || !strings.HasPrefix(err.Error(), "phone_books[1]: ") {
+		t.Errorf("expected a validation error for phone_books[1], got %v", err)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "data")
+	snap, err := Load(dir)
+	if err != nil || snap.Version != Version || len(snap.Users) != 0 {
+		t.Fatalf("expected an empty snapshot for a new directory, got %+v (%v)", snap, err)
+	}
+	stores := newStores()
+	fill(t, stores)
+	snap, _ = Take(context.Background(), stores)
+	if err := Save(dir, snap); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := sections(t, loaded), sections(t, snap); got != want {
+		t.Errorf("expected the saved snapshot\n got: %s\nwant: %s", got, want)
+	}
+	if files, _ := os.ReadDir(dir); len(files) != 1 {
+		t.Errorf("expected only the snapshot file in the directory, got %v", files)
+	}
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte("{"), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := Load(dir); err == nil {
+		t.Error("expected an error for a corrupt snapshot, got nil")
+	}
+}
+
+func TestLock(t *testing.T) {
+	if !canLock {
+		t.Skip("no file locks on this system")
+	}
+	dir := filepath.Join(t.TempDir(), "data")
+	lock, err := Lock(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := Lock(dir); !errors.Is(err, ErrLocked) {
+		t.Fatalf("expected ErrLocked while the lock is held, got %v", err)
+	}
+	if err := lock.Unlock(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lock, err = Lock(dir)
+	if err != nil {
+		t.Fatalf("expected the lock to be free again, got %v", err)
+	}
+	_ = lock.Unlock()
+}
@@ -0,0 +1,233 @@
+// Package vcard parses vCard 3.0 and 4.0 address book exports into contacts.
+package vcard
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+// maxLineBytes bounds a single unfolded vCard line, e.g. an inline PHOTO property.
+const maxLineBytes = 1 << 20
+
+// ParseError reports a structural problem in a vCard stream.
+type ParseError struct {
+	// Line is the 1-based line number where the problem was detected.
+	Line int
+	// Message describes the problem.
+	Message string
+}
+
+// Error implements the error interface.
+func (e *ParseError) Error() string {
+	return "vcard: line " + strconv.Itoa(e.Line) + ": " + e.Message
+}
+
+// card accumulates the properties of a single vCard that are relevant to contacts.
+type card struct {
+	formattedName string
+	structured    string
+	org           string
+	phones        []string
+}
+
+// Parse reads every vCard in r and returns one contact per distinct phone number.
+// Names come from FN, falling back to N and then ORG; phone numbers are normalized with
+// models.NormalizePhoneNumber. Contacts are not validated, so callers can report invalid
+// entries, e.g. via UserService.UploadContactsPartial. Cards without a TEL property are skipped.
+func Parse(r io.Reader) ([]models.Contact, error) {
+	var contacts []models.Contact
+	var current *card
+	lineNo := 0
+	lines := unfold(r, &lineNo)
+	for {
+		line, err := lines()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		name, value, ok := splitProperty(line)
+		if !ok {
+			return nil, &ParseError{Line: lineNo, Message: "missing ':' in property"}
+		}
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VCARD"):
+			if current != nil {
+				return nil, &ParseError{Line: lineNo, Message: "nested BEGIN:VCARD"}
+			}
+			current = &card{}
+		case name == "END" && strings.EqualFold(value, "VCARD"):
+			if current == nil {
+				return nil, &ParseError{Line: lineNo, Message: "END:VCARD without BEGIN:VCARD"}
+			}
+			contacts = append(contacts, current.contacts()...)
+			current = nil
+		case current == nil:
+			return nil, &ParseError{Line: lineNo, Message: "property outside of a vCard"}
+		case name == "FN":
+			current.formattedName = unescape(value)
+		case name == "N":
+			current.structured = structuredName(value)
+		case name == "ORG":
+			current.org = strings.TrimSpace(unescape(splitUnescaped(value, ';')[0]))
+		case name == "TEL":
+			current.phones = append(current.phones, telValue(value))
+		}
+	}
+	if current != nil {
+		return nil, &ParseError{Line: lineNo, Message: "missing END:VCARD"}
+	}
+	return contacts, nil
+}
+
+// contacts returns one contact per distinct normalized phone number on the card.
+func (c *card) contacts() []models.Contact {
+	name := strings.TrimSpace(c.formattedName)
+	if name == "" {
+		name = c.structured
+	}
+	if name == "" {
+		name = c.org
+	}
+	seen := make(map[string]bool, len(c.phones))
+	var result []models.Contact
+	for _, raw := range c.phones {
+		phone := models.NormalizePhoneNumber(raw)
+		if phone == "" || seen[phone] {
+			continue
+		}
+		seen[phone] = true
+		result = append(result, models.Contact{PhoneNumber: phone, Name: name})
+	}
+	return result
+}
+
+// unfold returns an iterator over logical vCard lines, joining continuation lines that start
+// with a space or tab (RFC 6350 section 3.2). lineNo is set to the physical line on which the
+// returned logical line starts.
+func unfold(r io.Reader, lineNo *int) func() (string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxLineBytes)
+	var pending string
+	havePending := false
+	physical, pendingLine := 0, 0
+	return func() (string, error) {
+		for scanner.Scan() {
+			physical++
+			text := strings.TrimRight(scanner.Text(), "\r")
+			if havePending && (strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t")) {
+				pending += text[1:]
+				continue
+			}
+			if havePending {
+				line, start := pending, pendingLine
+				pending, pendingLine = text, physical
+				*lineNo = start
+				return line, nil
+			}
+			pending, pendingLine, havePending = text, physical, true
+		}
+		if err := scanner.Err(); err != nil {
+			return "", err
+		}
+		if havePending {
+			havePending = false
+			*lineNo = pendingLine
+			return pending, nil
+		}
+		return "", io.EOF
+	}
+}
+
+// splitProperty splits "group.NAME;PARAM=x:value" into its upper-cased name and value; parameters are ignored.
+func splitProperty(line string) (name, value string, ok bool) {
+	colon := strings.IndexByte(line, ':')
+	if colon < 0 {
+		return "", "", false
+	}
+	head, value := line[:colon], line[colon+1:]
+	if semi := strings.IndexByte(head, ';'); semi >= 0 {
+		head = head[:semi]
+	}
+	if dot := strings.LastIndexByte(head, '.'); dot >= 0 {
+		head = head[dot+1:]
+	}
+	return strings.ToUpper(strings.TrimSpace(head)), value, true
+}
+
+// structuredName renders an N value (family;given;additional;prefix;suffix) as a display name. Components may
+// hold several comma-separated values, which are joined with spaces.
+func structuredName(value string) string {
+	parts := splitUnescaped(value, ';')
+	for len(parts) < 5 {
+		parts = append(parts, "")
+	}
+	ordered := []string{parts[3], parts[1], parts[2], parts[0], parts[4]}
+	var words []string
+	for _, p := range ordered {
+		for _, v := range splitUnescaped(p, ',') {
+			if v = strings.TrimSpace(unescape(v)); v != "" {
+				words = append(words, v)
+			}
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// splitUnescaped splits value at each sep not escaped with a backslash. The parts keep their escapes.
+func splitUnescaped(value string, sep byte) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			i++
+		case sep:
+			parts = append(parts, value[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, value[start:])
+}
+
+// telValue strips the "tel:" URI scheme used by vCard 4.0 and any URI parameters.
+func telValue(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 4 && strings.EqualFold(value[:4], "tel:") {
+		value = value[4:]
+		if semi := strings.IndexByte(value, ';'); semi >= 0 {
+			value = value[:semi]
+		}
+	}
+	return value
+}
+
+// unescape decodes vCard text escapes (\\, \,, \; and \n).
+func unescape(value string) string {
+	if !strings.Contains(value, `\`) {
+		return value
+	}
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' && i+1 < len(value) {
+			i++
+			switch value[i] {
+			case 'n', 'N':
+				b.WriteByte(' ')
+			default:
+				b.WriteByte(value[i])
+			}
+			continue
+		}
+		b.WriteByte(value[i])
+	}
+	return b.String()
+}
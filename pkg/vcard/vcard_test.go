@@ -0,0 +1,98 @@
+package vcard
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/truecaller-lite/pkg/models"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []models.Contact
+		wantErr bool
+		// wantLine is the expected ParseError.Line when wantErr is set.
+		wantLine int
+	}{
+		{
+			name: "vcard 3.0 with multiple numbers",
+			input: "BEGIN:VCARD\r\nVERSION:3.0\r\nN:Rao;Anita;;Dr.;\r\nFN:Dr. Anita Rao\r\n" +
+				"TEL;TYPE=CELL:+91 98765 43210\r\nTEL;TYPE=WORK:098765-43211\r\nEND:VCARD\r\n",
+			want: []models.Contact{
+				{PhoneNumber: "919876543210", Name: "Dr. Anita Rao"},
+				{PhoneNumber: "919876543211", Name: "Dr. Anita Rao"},
+			},
+		},
+		{
+			name: "vcard 4.0 tel uri, grouped property and folded name",
+			input: "BEGIN:VCARD\nVERSION:4.0\nFN:Rahul\n  Sharma\nitem1.TEL;VALUE=uri;TYPE=cell:tel:+91-98765-43212;ext=1\nEND:VCARD\n" +
+				"BEGIN:VCARD\nVERSION:4.0\nFN:No Phone\nEND:VCARD\n",
+			want: []models.Contact{{PhoneNumber: "919876543212", Name: "Rahul Sharma"}},
+		},
+		{
+			name:  "falls back to N then ORG and removes duplicate numbers",
+			input: "BEGIN:VCARD\nVERSION:3.0\nN:Kumar;Ravi;;;\nTEL:9876543213\nTEL:+919876543213\nEND:VCARD\nBEGIN:VCARD\nVERSION:3.0\nORG:Apollo\\, Pharmacy;Billing\nTEL:9876543214\nEND:VCARD\n",
+			want: []models.Contact{
+				{PhoneNumber: "919876543213", Name: "Ravi Kumar"},
+				{PhoneNumber: "919876543214", Name: "Apollo, Pharmacy"},
+			},
+		},
+		{
+			name:  "escaped separators in N and ORG",
+			input: "BEGIN:VCARD\nN:Rao\\;Iyer;Anita,Devi;;;\nTEL:9876543215\nEND:VCARD\nBEGIN:VCARD\nORG:Rao\\; Sons;Accounts\nTEL:9876543216\nEND:VCARD\n",
+			want: []models.Contact{
+				{PhoneNumber: "919876543215", Name: "Anita Devi Rao;Iyer"},
+				{PhoneNumber: "919876543216", Name: "Rao; Sons"},
+			},
+		},
+		{
+			name:     "missing END",
+			input:    "BEGIN:VCARD\nFN:Bob\nTEL:9876543210\n",
+			wantErr:  true,
+			wantLine: 3,
+		},
+		{
+			name:     "property without colon",
+			input:    "BEGIN:VCARD\nFN Bob\nEND:VCARD\n",
+			wantErr:  true,
+			wantLine: 2,
+		},
+		{
+			name:     "error after a folded line reports its physical line",
+			input:    "BEGIN:VCARD\nFN:Rahul\n  Sharma\nTEL 9876543210\nEND:VCARD\n",
+			wantErr:  true,
+			wantLine: 4,
+		},
+		{
+			name:     "folded line with an error reports its first physical line",
+			input:    "BEGIN:VCARD\nFN:Bob\nTEL;TYPE=\n cell 9876543210\nEND:VCARD\n",
+			wantErr:  true,
+			wantLine: 3,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Parse(strings.NewReader(tc.input))
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error: %v, got: %v", tc.wantErr, err)
+			}
+			if tc.wantErr {
+				var pe *ParseError
+				if !errors.As(err, &pe) {
+					t.Fatalf("expected *ParseError, got %T", err)
+				}
+				if pe.Line != tc.wantLine {
+					t.Errorf("expected error on line %d, got %d", tc.wantLine, pe.Line)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}